@@ -0,0 +1,75 @@
+// Command okta-schema-migrate applies a JSON or YAML manifest of custom user profile attributes to the user
+// schema of an OKTA org. It prints the plan of the attributes to add, change and remove, with the destructive
+// changes flagged, and applies it unless -dry-run is set:
+//
+//	okta-schema-migrate -org dev-123456 -domain oktapreview.com -file attributes.yaml -prune -dry-run
+//
+// A manifest that does not start with { is read as YAML, without anchors, aliases and tags, and converted to the
+// JSON read by okta.ParseSchemaManifest. A schema JSON saved from another org with okta-schemagen -save promotes
+// its custom attributes. The API token is read from -token or from the OKTA_API_TEST_TOKEN environment variable.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/chrismalek/oktasdk-go/internal/yamljson"
+	"github.com/chrismalek/oktasdk-go/okta"
+)
+
+func main() {
+	org := flag.String("org", os.Getenv("OKTA_API_TEST_ORG"), "name of the OKTA org, for example dev-123456")
+	domain := flag.String("domain", "okta.com", "domain of the OKTA org, for example oktapreview.com")
+	token := flag.String("token", os.Getenv("OKTA_API_TEST_TOKEN"), "API token of the OKTA org")
+	file := flag.String("file", "", "JSON or YAML manifest of the desired custom attributes")
+	schemaID := flag.String("schema-id", "", "schema ID of a user type, the default user type when empty")
+	prune := flag.Bool("prune", false, "remove the custom attributes missing from the manifest")
+	dryRun := flag.Bool("dry-run", false, "print the plan without applying it")
+	allowDestructive := flag.Bool("allow-destructive", false, "apply a plan with destructive changes")
+	flag.Parse()
+
+	if err := run(*org, *domain, *token, *file, *schemaID, *prune, okta.SchemaApplyOptions{DryRun: *dryRun, AllowDestructive: *allowDestructive}); err != nil {
+		fmt.Fprintf(os.Stderr, "okta-schema-migrate: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(org, domain, token, file, schemaID string, prune bool, opt okta.SchemaApplyOptions) error {
+	if file == "" || org == "" || token == "" {
+		return fmt.Errorf("[ERROR] -file, -org and -token are required")
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || trimmed[0] != '{' {
+		if data, err = yamljson.ToJSON(data); err != nil {
+			return fmt.Errorf("[ERROR] invalid YAML schema manifest: %v", err)
+		}
+	}
+	desired, err := okta.ParseSchemaManifest(data)
+	if err != nil {
+		return err
+	}
+
+	client, err := okta.NewClientWithDomain(nil, org, domain, token)
+	if err != nil {
+		return err
+	}
+	plan, _, err := client.Schemas.PlanUserSchemaForType(schemaID, desired, prune)
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan)
+	if plan.Empty() || opt.DryRun {
+		return nil
+	}
+	if _, err := client.Schemas.ApplyUserSchemaPlanForType(schemaID, plan, opt); err != nil {
+		return err
+	}
+	fmt.Printf("Applied %v changes\n", len(plan.Changes))
+	return nil
+}
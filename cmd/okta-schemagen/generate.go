@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/chrismalek/oktasdk-go/okta"
+)
+
+// initialisms are the words written in upper case in Go identifiers, like ManagerID and ProfileURL
+var initialisms = map[string]bool{
+	"api": true, "dn": true, "guid": true, "http": true, "https": true, "id": true, "ip": true, "json": true,
+	"sso": true, "ssn": true, "uid": true, "uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// attribute is a profile attribute of the schema, base or custom
+type attribute struct {
+	index       string
+	title       string
+	description string
+	schemaType  string
+	itemsType   string
+	required    bool
+	enum        okta.SchemaEnum
+	oneOf       []okta.OneOf
+	enumType    string
+}
+
+// generator keeps the identifiers used by the generated source unique
+type generator struct {
+	names map[string]bool
+}
+
+// generate returns the formatted Go source of a struct named typeName with a field for every attribute of
+// schema, and constants for the allowed values of the attributes
+func generate(schema *okta.Schema, pkg string, typeName string, source string) ([]byte, error) {
+	g := &generator{names: map[string]bool{typeName: true}}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by okta-schemagen from %v. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %v\n\n", pkg)
+
+	attributes := schemaAttributes(schema)
+	title := schema.Title
+	if title == "" {
+		title = schema.Name
+	}
+	fmt.Fprintf(&buf, "// %v is the %v profile", typeName, title)
+	if schema.ID != "" {
+		fmt.Fprintf(&buf, " of the schema %v", schema.ID)
+	}
+	fmt.Fprintf(&buf, "\ntype %v struct {\n", typeName)
+
+	fields := make(map[string]string)
+	for _, a := range attributes {
+		field := g.identifier(a.index, "Attribute")
+		fields[a.index] = field
+		writeComment(&buf, "\t", field, a.title, a.description)
+		fmt.Fprintf(&buf, "\t%v %v `json:\"%v\"`\n", field, a.goType(), a.tag())
+	}
+	buf.WriteString("}\n")
+
+	for _, a := range attributes {
+		if err := g.writeConstants(&buf, fields[a.index], a); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] generated invalid Go source: %v", err)
+	}
+	return src, nil
+}
+
+// schemaAttributes returns the base attributes of schema followed by the custom ones, each sorted by index
+func schemaAttributes(schema *okta.Schema) []attribute {
+	var attributes []attribute
+	required := make(map[string]bool)
+	for _, index := range schema.Definitions.Base.Required {
+		required[index] = true
+	}
+	for _, index := range schema.Definitions.Custom.Required {
+		required[index] = true
+	}
+
+	base := append([]okta.BaseSubSchema(nil), schema.Definitions.Base.Properties...)
+	sort.Slice(base, func(i, j int) bool { return base[i].Index < base[j].Index })
+	for _, sub := range base {
+		attributes = append(attributes, attribute{
+			index:       sub.Index,
+			title:       sub.Title,
+			description: sub.Description,
+			schemaType:  sub.Type,
+			required:    sub.Required || required[sub.Index],
+		})
+	}
+
+	custom := append([]okta.CustomSubSchema(nil), schema.Definitions.Custom.Properties...)
+	sort.Slice(custom, func(i, j int) bool { return custom[i].Index < custom[j].Index })
+	for _, sub := range custom {
+		a := attribute{
+			index:       sub.Index,
+			title:       sub.Title,
+			description: sub.Description,
+			schemaType:  sub.Type,
+			itemsType:   sub.Items.Type,
+			required:    sub.Required || required[sub.Index],
+			enum:        sub.Enum,
+			oneOf:       sub.OneOf,
+			enumType:    sub.Type,
+		}
+		if len(a.enum) == 0 && len(a.oneOf) == 0 {
+			a.enum, a.oneOf, a.enumType = sub.Items.Enum, sub.Items.OneOf, sub.Items.Type
+		}
+		attributes = append(attributes, a)
+	}
+	return attributes
+}
+
+// goType returns the Go type of the attribute. Optional numbers and booleans are pointers, so that zero
+// values are sent
+func (a attribute) goType() string {
+	t := scalarType(a.schemaType)
+	switch a.schemaType {
+	case "array":
+		return "[]" + scalarType(a.itemsType)
+	case "integer", "number", "boolean":
+		if !a.required {
+			return "*" + t
+		}
+	}
+	return t
+}
+
+// tag returns the JSON tag of the attribute, optional attributes are left out when empty
+func (a attribute) tag() string {
+	if a.required {
+		return a.index
+	}
+	return a.index + ",omitempty"
+}
+
+// scalarType returns the Go type of a schema type
+func scalarType(schemaType string) string {
+	switch schemaType {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "object":
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// writeConstants writes a constant for every allowed value of the attribute, named by the field and the
+// title of the value from oneOf, or the value itself from enum
+func (g *generator) writeConstants(buf *bytes.Buffer, field string, a attribute) error {
+	type constant struct {
+		name, title, value string
+	}
+	var constants []constant
+	if len(a.oneOf) > 0 {
+		for _, o := range a.oneOf {
+			constants = append(constants, constant{name: o.Title, title: o.Title, value: o.Const})
+		}
+	} else {
+		for _, v := range a.enum {
+			constants = append(constants, constant{name: v, value: v})
+		}
+	}
+	if len(constants) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(buf, "\n// Values of %v\nconst (\n", field)
+	for _, c := range constants {
+		literal, err := constantLiteral(c.value, a.enumType)
+		if err != nil {
+			return fmt.Errorf("[ERROR] attribute %v: %v", a.index, err)
+		}
+		name := g.identifier(field+"_"+c.name, field+"Value")
+		if c.title != "" {
+			writeComment(buf, "\t", name, c.title, "")
+		}
+		fmt.Fprintf(buf, "\t%v = %v\n", name, literal)
+	}
+	buf.WriteString(")\n")
+	return nil
+}
+
+// constantLiteral returns the Go literal of a value of an enum of type schemaType
+func constantLiteral(value string, schemaType string) (string, error) {
+	switch schemaType {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", fmt.Errorf("%q is not a valid %v", value, schemaType)
+		}
+		return value, nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a valid boolean", value)
+		}
+		return strconv.FormatBool(b), nil
+	}
+	return strconv.Quote(value), nil
+}
+
+// writeComment writes the doc comment of name from the title and description of the schema
+func writeComment(buf *bytes.Buffer, indent string, name string, title string, description string) {
+	title = strings.Join(strings.Fields(title), " ")
+	description = strings.Join(strings.Fields(description), " ")
+	if title == "" {
+		title = description
+	}
+	if title == "" {
+		return
+	}
+	fmt.Fprintf(buf, "%v// %v - %v\n", indent, name, title)
+	if description != "" && description != title {
+		fmt.Fprintf(buf, "%v// %v\n", indent, description)
+	}
+}
+
+// identifier returns a unique exported Go identifier for s, like EmployeeNumber for "employeeNumber" or
+// ManagerID for "manager_id". fallback is used when s has no letters or digits
+func (g *generator) identifier(s string, fallback string) string {
+	var words []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		words = append(words, splitCamel(part)...)
+	}
+	var name strings.Builder
+	for _, word := range words {
+		if initialisms[strings.ToLower(word)] {
+			name.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		name.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	id := name.String()
+	if id == "" {
+		id = fallback
+	} else if !unicode.IsLetter([]rune(id)[0]) {
+		id = fallback + id
+	}
+
+	unique := id
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%v%v", id, i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+// splitCamel splits s at the start of every upper case word, "managerId" into "manager" and "Id"
+func splitCamel(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrismalek/oktasdk-go/okta"
+)
+
+const testSchemaFile = "../../test_data/schemagen/contractor_schema.json"
+
+func TestGenerateFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "okta-schemagen")
+	if err != nil {
+		t.Fatalf("failed to create a temporary directory, error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "profile.go")
+
+	if err := run(options{file: testSchemaFile, pkg: "profile", typeName: "ContractorProfile", out: out}); err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	got, _ := ioutil.ReadFile(out)
+	want, err := ioutil.ReadFile("../../test_data/schemagen/contractor_profile.go.golden")
+	if err != nil {
+		t.Fatalf("failed to load the golden file, error %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("run generated \n%s\nwant \n%s", got, want)
+	}
+}
+
+func TestGenerateFromOrg(t *testing.T) {
+	schema, err := ioutil.ReadFile(testSchemaFile)
+	if err != nil {
+		t.Fatalf("failed to load %v, error %v", testSchemaFile, err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/meta/schemas/apps/0oa25gejWwdXNnFH90g4/default" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, string(schema))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL + "/")
+	client := okta.NewClientWithBaseURL(nil, baseURL, "token")
+
+	if _, _, err := fetchSchema(client, "app", "", ""); err == nil {
+		t.Errorf("fetchSchema of an app user schema without app ID returned no error")
+	}
+	if _, _, err := fetchSchema(client, "device", "", ""); err == nil {
+		t.Errorf("fetchSchema of an unknown schema returned no error")
+	}
+	raw, source, err := fetchSchema(client, "app", "", "0oa25gejWwdXNnFH90g4")
+	if err != nil {
+		t.Fatalf("fetchSchema returned error: %v", err)
+	}
+	if source != server.URL+"/meta/schemas/apps/0oa25gejWwdXNnFH90g4/default" || raw["name"] != "contractor" {
+		t.Errorf("fetchSchema returned %v from %v", raw["name"], source)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	g := &generator{names: map[string]bool{"UserProfile": true}}
+	tests := []struct {
+		in, want string
+	}{
+		{"employeeNumber", "EmployeeNumber"},
+		{"manager_id", "ManagerID"},
+		{"profileUrl", "ProfileURL"},
+		{"x-okta-SSO.enabled", "XOktaSSOEnabled"},
+		{"2fa", "Attribute2fa"},
+		{"---", "Attribute"},
+		{"employee_number", "EmployeeNumber2"},
+		{"userProfile", "UserProfile2"},
+	}
+	for _, test := range tests {
+		if got := g.identifier(test.in, "Attribute"); got != test.want {
+			t.Errorf("identifier(%q) returned %v, want %v", test.in, got, test.want)
+		}
+	}
+}
+
+func TestGenerateInvalidEnum(t *testing.T) {
+	schema := new(okta.Schema)
+	schema.Definitions.Custom.Properties = []okta.CustomSubSchema{{Index: "level", Title: "Level", Type: "integer", Enum: okta.SchemaEnum{"1", "high"}}}
+	if _, err := generate(schema, "main", "UserProfile", "test"); err == nil {
+		t.Errorf("generate of a non integer enum value returned no error")
+	}
+}
@@ -0,0 +1,141 @@
+// Command okta-schemagen generates a Go profile struct from the user, group or app user profile schema of an
+// OKTA org, with a field and JSON tag for every base and custom attribute and constants for their allowed values.
+// The struct can be sent with NewUser.SetProfile by the Users.Create and Users.Update calls of the SDK.
+//
+// The schema is read from the org with the SchemasService, or from a schema JSON file saved with -save for offline
+// builds:
+//
+//	okta-schemagen -org dev-123456 -domain oktapreview.com -save user_schema.json -o profile.go
+//	okta-schemagen -file user_schema.json -package profile -type ContractorProfile -o profile.go
+//
+// The API token is read from -token or from the OKTA_API_TEST_TOKEN environment variable.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/chrismalek/oktasdk-go/okta"
+)
+
+// options are the command line flags
+type options struct {
+	org, domain, token      string
+	schema, schemaID, appID string
+	file, save              string
+	pkg, typeName, out      string
+}
+
+func main() {
+	var opt options
+	flag.StringVar(&opt.org, "org", os.Getenv("OKTA_API_TEST_ORG"), "name of the OKTA org, for example dev-123456")
+	flag.StringVar(&opt.domain, "domain", "okta.com", "domain of the OKTA org, for example oktapreview.com")
+	flag.StringVar(&opt.token, "token", os.Getenv("OKTA_API_TEST_TOKEN"), "API token of the OKTA org")
+	flag.StringVar(&opt.schema, "schema", "user", "schema to generate the struct from: user, group or app")
+	flag.StringVar(&opt.schemaID, "schema-id", "", "schema ID of a user type, the default user type when empty")
+	flag.StringVar(&opt.appID, "app", "", "ID of the app of an app user schema")
+	flag.StringVar(&opt.file, "file", "", "schema JSON file to read instead of the org")
+	flag.StringVar(&opt.save, "save", "", "file to save the schema JSON read from the org to")
+	flag.StringVar(&opt.pkg, "package", "main", "package of the generated source")
+	flag.StringVar(&opt.typeName, "type", "", "name of the generated struct, UserProfile, GroupProfile or AppUserProfile by default")
+	flag.StringVar(&opt.out, "o", "", "file to write the generated source to, standard output when empty")
+	flag.Parse()
+
+	if err := run(opt); err != nil {
+		fmt.Fprintf(os.Stderr, "okta-schemagen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run reads the schema from opt.file or the org and writes the generated source
+func run(opt options) error {
+	var raw map[string]interface{}
+	source := opt.file
+	if opt.file != "" {
+		data, err := ioutil.ReadFile(opt.file)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return fmt.Errorf("[ERROR] %v is not a schema JSON file: %v", opt.file, err)
+		}
+	} else {
+		if opt.org == "" || opt.token == "" {
+			return fmt.Errorf("[ERROR] -org and -token are required without -file")
+		}
+		client, err := okta.NewClientWithDomain(nil, opt.org, opt.domain, opt.token)
+		if err != nil {
+			return err
+		}
+		raw, source, err = fetchSchema(client, opt.schema, opt.schemaID, opt.appID)
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.MarshalIndent(raw, "", "    ")
+	if err != nil {
+		return err
+	}
+	if opt.save != "" {
+		if err := ioutil.WriteFile(opt.save, data, 0644); err != nil {
+			return err
+		}
+	}
+	schema := new(okta.Schema)
+	if err := json.Unmarshal(data, schema); err != nil {
+		return fmt.Errorf("[ERROR] invalid schema: %v", err)
+	}
+
+	typeName := opt.typeName
+	if typeName == "" {
+		typeName = defaultTypeName(opt.schema)
+	}
+	src, err := generate(schema, opt.pkg, typeName, source)
+	if err != nil {
+		return err
+	}
+	if opt.out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(opt.out, src, 0644)
+}
+
+// fetchSchema returns the raw schema of kind from the org, and the URL it was read from
+func fetchSchema(client *okta.Client, kind, schemaID, appID string) (map[string]interface{}, string, error) {
+	var raw map[string]interface{}
+	var resp *okta.Response
+	var err error
+	switch kind {
+	case "user":
+		raw, resp, err = client.Schemas.GetRawUserSchemaForType(schemaID)
+	case "group":
+		raw, resp, err = client.Schemas.GetRawGroupSchema()
+	case "app":
+		if appID == "" {
+			return nil, "", fmt.Errorf("[ERROR] -app is required for an app user schema")
+		}
+		raw, resp, err = client.Schemas.GetRawAppUserSchema(appID)
+	default:
+		return nil, "", fmt.Errorf("[ERROR] -schema supports values \"user\", \"group\" or \"app\"")
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, resp.Request.URL.String(), nil
+}
+
+// defaultTypeName returns the name of the struct generated from a schema of kind
+func defaultTypeName(kind string) string {
+	switch kind {
+	case "group":
+		return "GroupProfile"
+	case "app":
+		return "AppUserProfile"
+	}
+	return "UserProfile"
+}
@@ -57,7 +57,7 @@ func printUser(user okta.User) {
 	if user.Groups != nil {
 		fmt.Printf("\t\t--- Group Memberships ---\n")
 		for _, group := range user.Groups {
-			fmt.Printf("\t\tGroup - Name: %v - ID: %v - Last Enrolled: %v\n", group.Profile.Name, group.ID, group.LastMembershipUpdated)
+			fmt.Printf("\t\tGroup - Name: %v - ID: %v - Last Enrolled: %v\n", group.GroupProfile.Name, group.ID, group.LastMembershipUpdated)
 		}
 	}
 
@@ -70,7 +70,7 @@ func printGroupArray(groups []okta.Group) {
 }
 
 func printGroup(group okta.Group) {
-	fmt.Printf("\tFound Group: ID: %v, Name: %v\n", group.ID, group.Profile.Name)
+	fmt.Printf("\tFound Group: ID: %v, Name: %v\n", group.ID, group.GroupProfile.Name)
 }
 
 func printStart(fName string) string {
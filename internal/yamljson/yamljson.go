@@ -0,0 +1,671 @@
+// Package yamljson converts the YAML documents of configuration files, such as the schema manifests of
+// cmd/okta-schema-migrate, to JSON.
+package yamljson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	yamlIntPattern   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	yamlFloatPattern = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// yamlLine is a line of a YAML document
+type yamlLine struct {
+	num    int
+	indent int
+	text   string // without the indentation, comment and trailing spaces
+	raw    string
+}
+
+// yamlParser reads the block structure of a YAML document
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// yamlError is an error at a line of a YAML document
+type yamlError struct {
+	line int
+	msg  string
+}
+
+func (e yamlError) Error() string {
+	return fmt.Sprintf("line %v: %v", e.line, e.msg)
+}
+
+// ToJSON converts a YAML document to JSON. It reads the subset of YAML used by configuration files:
+// block mappings and sequences, flow collections, plain and quoted scalars, literal and folded block scalars and
+// comments. Anchors, aliases, tags, complex keys, multi-line plain scalars and multiple documents are refused
+func ToJSON(data []byte) ([]byte, error) {
+	if !utf8.Valid(data) {
+		return nil, errors.New("YAML is not UTF-8 encoded")
+	}
+	p := new(yamlParser)
+	text := strings.TrimPrefix(strings.Replace(string(data), "\r\n", "\n", -1), "\ufeff")
+	for i, raw := range strings.Split(text, "\n") {
+		trimmed := strings.TrimLeft(raw, " ")
+		p.lines = append(p.lines, yamlLine{
+			num:    i + 1,
+			indent: len(raw) - len(trimmed),
+			text:   strings.TrimRight(yamlStripComment(trimmed), " \t"),
+			raw:    raw,
+		})
+	}
+
+	if line := p.next(); line != nil && line.indent == 0 && line.text == "---" {
+		p.pos++
+	}
+	// the document ends at a ... marker, a --- marker starts another one
+	for i := p.pos; i < len(p.lines); i++ {
+		if line := p.lines[i]; line.indent == 0 && (line.text == "---" || line.text == "...") {
+			if next := p.skip(i + 1); line.text == "---" || next != nil {
+				return nil, yamlError{line.num, "multiple documents are not supported"}
+			}
+			p.lines = p.lines[:i]
+			break
+		}
+	}
+
+	var v interface{}
+	if line := p.next(); line != nil {
+		var err error
+		if v, err = p.parseBlock(0); err != nil {
+			return nil, err
+		}
+	}
+	if line := p.next(); line != nil {
+		return nil, yamlError{line.num, "unexpected content"}
+	}
+	return json.Marshal(v)
+}
+
+// next returns the next line holding content, nil at the end of the document
+func (p *yamlParser) next() *yamlLine {
+	line := p.skip(p.pos)
+	if line != nil {
+		p.pos = line.num - 1
+	}
+	return line
+}
+
+// skip returns the first line from pos holding content
+func (p *yamlParser) skip(pos int) *yamlLine {
+	for ; pos < len(p.lines); pos++ {
+		if p.lines[pos].text != "" {
+			return &p.lines[pos]
+		}
+	}
+	return nil
+}
+
+// parseBlock parses the node of the next line, indented by at least indent
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	line := p.next()
+	if line == nil || line.indent < indent {
+		return nil, nil
+	}
+	if strings.HasPrefix(strings.TrimLeft(line.raw, " "), "\t") {
+		return nil, yamlError{line.num, "tabs are not allowed in indentation"}
+	}
+	if yamlSequenceItem(line.text) {
+		return p.parseSequence(line.indent)
+	}
+	if _, _, ok := yamlSplitKey(line.text); ok {
+		return p.parseMapping(line.indent)
+	}
+	p.pos++
+	return p.parseValue(line, line.text, line.indent-1)
+}
+
+// parseSequence parses the items of a block sequence at indent
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	items := []interface{}{}
+	for line := p.next(); line != nil && line.indent == indent && yamlSequenceItem(line.text); line = p.next() {
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		var item interface{}
+		var err error
+		if rest == "" {
+			p.pos++
+			item, err = p.parseBlock(indent + 1)
+		} else {
+			// the content of the item is read as a line indented to its first character, so a mapping
+			// starting on the line of the dash continues on the next lines
+			*line = yamlLine{num: line.num, indent: indent + len(line.text) - len(rest), text: rest, raw: strings.Repeat(" ", indent+len(line.text)-len(rest)) + rest}
+			item, err = p.parseBlock(line.indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if line := p.next(); line != nil && line.indent > indent {
+		return nil, yamlError{line.num, "unexpected indentation"}
+	}
+	return items, nil
+}
+
+// parseMapping parses the entries of a block mapping at indent
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	entries := make(map[string]interface{})
+	for line := p.next(); line != nil && line.indent == indent && !yamlSequenceItem(line.text); line = p.next() {
+		rawKey, rest, ok := yamlSplitKey(line.text)
+		if !ok {
+			return nil, yamlError{line.num, "expected a mapping entry"}
+		}
+		key, err := yamlKey(rawKey)
+		if err != nil {
+			return nil, yamlError{line.num, err.Error()}
+		}
+		if _, ok := entries[key]; ok {
+			return nil, yamlError{line.num, fmt.Sprintf("key %q is defined twice", key)}
+		}
+		p.pos++
+
+		var value interface{}
+		if rest == "" {
+			// a sequence may be indented like the key of the mapping holding it
+			if next := p.next(); next != nil && next.indent == indent && yamlSequenceItem(next.text) {
+				value, err = p.parseSequence(indent)
+			} else {
+				value, err = p.parseBlock(indent + 1)
+			}
+		} else {
+			value, err = p.parseValue(line, rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries[key] = value
+	}
+	if line := p.next(); line != nil && line.indent > indent {
+		return nil, yamlError{line.num, "unexpected indentation"}
+	}
+	return entries, nil
+}
+
+// parseValue parses a value written on line, the lines of a block scalar are indented by more than indent
+func (p *yamlParser) parseValue(line *yamlLine, value string, indent int) (interface{}, error) {
+	switch value[0] {
+	case '|', '>':
+		return p.parseBlockScalar(line, value, indent)
+	case '[', '{':
+		// a flow collection continues on the next lines until its brackets are closed
+		for !yamlFlowClosed(value) {
+			next := p.next()
+			if next == nil {
+				return nil, yamlError{line.num, "unclosed flow collection"}
+			}
+			value += " " + next.text
+			p.pos++
+		}
+		f := &yamlFlow{text: value}
+		v, err := f.parse()
+		if err == nil && strings.TrimSpace(f.text[f.pos:]) != "" {
+			err = errors.New("unexpected content after flow collection")
+		}
+		if err != nil {
+			return nil, yamlError{line.num, err.Error()}
+		}
+		return v, nil
+	}
+	v, err := yamlScalar(value)
+	if err != nil {
+		return nil, yamlError{line.num, err.Error()}
+	}
+	if next := p.next(); next != nil && next.indent > indent && !yamlQuoted(value) {
+		return nil, yamlError{next.num, "multi-line plain scalars are not supported"}
+	}
+	return v, nil
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar with the header on line
+func (p *yamlParser) parseBlockScalar(line *yamlLine, header string, indent int) (interface{}, error) {
+	chomp := byte(0)
+	contentIndent := 0
+	for _, c := range header[1:] {
+		switch {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = byte(c)
+		case c >= '1' && c <= '9' && contentIndent == 0:
+			contentIndent = indent + 1 + int(c-'1')
+		default:
+			return nil, yamlError{line.num, fmt.Sprintf("invalid block scalar header %q", header)}
+		}
+	}
+	if indent < 0 {
+		indent = 0
+	}
+
+	var lines []string
+	for ; p.pos < len(p.lines); p.pos++ {
+		raw := p.lines[p.pos].raw
+		if strings.TrimSpace(raw) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		spaces := len(raw) - len(strings.TrimLeft(raw, " "))
+		if contentIndent == 0 {
+			if spaces <= indent {
+				break
+			}
+			contentIndent = spaces
+		}
+		if spaces < contentIndent {
+			break
+		}
+		lines = append(lines, raw[contentIndent:])
+	}
+
+	// trailing empty lines are chomped
+	content := len(lines)
+	for content > 0 && lines[content-1] == "" {
+		content--
+	}
+	trailing := len(lines) - content
+	lines = lines[:content]
+
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			if header[0] == '>' && prev != "" && l != "" && prev[0] != ' ' && l[0] != ' ' {
+				b.WriteString(" ")
+			} else if header[0] == '>' && l == "" && prev != "" {
+				// a blank line of a folded scalar is a line break
+			} else {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(l)
+	}
+	if content > 0 {
+		switch chomp {
+		case '-':
+		case '+':
+			b.WriteString(strings.Repeat("\n", trailing+1))
+		default:
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// yamlSequenceItem reports if text is an item of a block sequence
+func yamlSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlStripComment returns text without its comment, a # at the start or after a space outside of quotes
+func yamlStripComment(text string) string {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				if quote == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+					i++
+				} else {
+					quote = 0
+				}
+			}
+		case c == '#' && (i == 0 || text[i-1] == ' ' || text[i-1] == '\t'):
+			return text[:i]
+		case (c == '"' || c == '\'') && yamlTokenStart(text[:i]):
+			quote = c
+		}
+	}
+	return text
+}
+
+// yamlTokenStart reports if a scalar may start after prefix, where a quote opens a quoted scalar
+func yamlTokenStart(prefix string) bool {
+	prefix = strings.TrimRight(prefix, " ")
+	if prefix == "" {
+		return true
+	}
+	switch prefix[len(prefix)-1] {
+	case ':', '-', '[', '{', ',', '?':
+		return true
+	}
+	return false
+}
+
+// yamlSplitKey splits a mapping entry into its key and value
+func yamlSplitKey(text string) (string, string, bool) {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				if quote == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+					i++
+				} else {
+					quote = 0
+				}
+			}
+		case (c == '"' || c == '\'') && yamlTokenStart(text[:i]):
+			quote = c
+		case c == '[' || c == '{':
+			if i == 0 {
+				return "", "", false
+			}
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ':' && depth <= 0 && (i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\t'):
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), i > 0
+		}
+	}
+	return "", "", false
+}
+
+// yamlKey returns the string of a mapping key
+func yamlKey(key string) (string, error) {
+	if key == "" || key[0] == '?' {
+		return "", errors.New("complex keys are not supported")
+	}
+	if key[0] == '\t' {
+		return "", errors.New("tabs are not allowed in indentation")
+	}
+	if yamlQuoted(key) {
+		v, err := yamlScalar(key)
+		if err != nil {
+			return "", err
+		}
+		return v.(string), nil
+	}
+	if strings.ContainsAny(key[:1], "&*!|>%@`") {
+		return "", fmt.Errorf("unsupported key %q", key)
+	}
+	return key, nil
+}
+
+// yamlQuoted reports if value is a quoted scalar
+func yamlQuoted(value string) bool {
+	return value != "" && (value[0] == '"' || value[0] == '\'')
+}
+
+// yamlScalar returns the JSON value of a plain or quoted scalar, numbers as json.Number
+func yamlScalar(value string) (interface{}, error) {
+	if yamlQuoted(value) {
+		s, rest, err := yamlUnquote(value)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, fmt.Errorf("unexpected content after quoted scalar: %q", rest)
+		}
+		return s, nil
+	}
+	switch value {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "-.inf", ".nan", ".NaN", ".NAN":
+		return nil, fmt.Errorf("%v can not be represented in JSON", value)
+	}
+	switch value[0] {
+	case '&', '*', '!':
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported: %q", value)
+	case '@', '`', '%':
+		return nil, fmt.Errorf("plain scalars can not start with %q", value[0])
+	}
+
+	if yamlIntPattern.MatchString(value) {
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(i, 10)), nil
+		}
+	}
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0o") {
+		base := 16
+		if value[1] == 'o' {
+			base = 8
+		}
+		if i, err := strconv.ParseInt(value[2:], base, 64); err == nil {
+			return json.Number(strconv.FormatInt(i, 10)), nil
+		}
+	}
+	if yamlFloatPattern.MatchString(value) {
+		if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(f, 0) {
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+		}
+	}
+	return value, nil
+}
+
+// yamlUnquote returns the string of the quoted scalar at the start of value and the text after it
+func yamlUnquote(value string) (string, string, error) {
+	quote := value[0]
+	var b strings.Builder
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == quote && quote == '\'' && i+1 < len(value) && value[i+1] == '\'':
+			b.WriteByte('\'')
+			i++
+		case c == quote:
+			return b.String(), value[i+1:], nil
+		case c == '\\' && quote == '"':
+			if i+1 == len(value) {
+				return "", "", errors.New("unterminated escape sequence")
+			}
+			i++
+			n := 0
+			switch value[i] {
+			case '0':
+				b.WriteByte(0)
+			case 'a':
+				b.WriteByte('\a')
+			case 'b':
+				b.WriteByte('\b')
+			case 't', '\t':
+				b.WriteByte('\t')
+			case 'n':
+				b.WriteByte('\n')
+			case 'v':
+				b.WriteByte('\v')
+			case 'f':
+				b.WriteByte('\f')
+			case 'r':
+				b.WriteByte('\r')
+			case 'e':
+				b.WriteByte(0x1b)
+			case ' ', '"', '/', '\\':
+				b.WriteByte(value[i])
+			case 'N':
+				b.WriteRune('\u0085')
+			case '_':
+				b.WriteRune('\u00a0')
+			case 'L':
+				b.WriteRune('\u2028')
+			case 'P':
+				b.WriteRune('\u2029')
+			case 'x':
+				n = 2
+			case 'u':
+				n = 4
+			case 'U':
+				n = 8
+			default:
+				return "", "", fmt.Errorf("invalid escape sequence \\%c", value[i])
+			}
+			if n > 0 {
+				if i+n >= len(value) {
+					return "", "", errors.New("unterminated escape sequence")
+				}
+				r, err := strconv.ParseUint(value[i+1:i+1+n], 16, 32)
+				if err != nil {
+					return "", "", fmt.Errorf("invalid escape sequence \\%v", value[i:i+1+n])
+				}
+				b.WriteRune(rune(r))
+				i += n
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated quoted scalar")
+}
+
+// yamlFlowClosed reports if the brackets of the flow collection in text are closed
+func yamlFlowClosed(text string) bool {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// yamlFlow parses a flow collection
+type yamlFlow struct {
+	text string
+	pos  int
+}
+
+func (f *yamlFlow) skipSpaces() {
+	for f.pos < len(f.text) && (f.text[f.pos] == ' ' || f.text[f.pos] == '\t') {
+		f.pos++
+	}
+}
+
+func (f *yamlFlow) parse() (interface{}, error) {
+	f.skipSpaces()
+	if f.pos == len(f.text) {
+		return nil, errors.New("unexpected end of flow collection")
+	}
+	switch f.text[f.pos] {
+	case '[':
+		f.pos++
+		items := []interface{}{}
+		for {
+			f.skipSpaces()
+			if f.pos < len(f.text) && f.text[f.pos] == ']' {
+				f.pos++
+				return items, nil
+			}
+			item, err := f.parse()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.pos++
+		entries := make(map[string]interface{})
+		for {
+			f.skipSpaces()
+			if f.pos < len(f.text) && f.text[f.pos] == '}' {
+				f.pos++
+				return entries, nil
+			}
+			key, err := f.scalar(true)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := key.(string)
+			if !ok {
+				s = fmt.Sprint(key)
+			}
+			if _, ok := entries[s]; ok {
+				return nil, fmt.Errorf("key %q is defined twice", s)
+			}
+			f.skipSpaces()
+			var value interface{}
+			if f.pos < len(f.text) && f.text[f.pos] == ':' {
+				f.pos++
+				if value, err = f.parse(); err != nil {
+					return nil, err
+				}
+			}
+			entries[s] = value
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.scalar(false)
+}
+
+// separator reads the comma between two entries, leaving the closing bracket to the caller
+func (f *yamlFlow) separator(closing byte) error {
+	f.skipSpaces()
+	if f.pos == len(f.text) {
+		return errors.New("unclosed flow collection")
+	}
+	switch f.text[f.pos] {
+	case ',':
+		f.pos++
+		return nil
+	case closing:
+		return nil
+	}
+	return fmt.Errorf("unexpected %q in flow collection", f.text[f.pos])
+}
+
+// scalar reads a scalar of a flow collection, a key ends at a colon
+func (f *yamlFlow) scalar(key bool) (interface{}, error) {
+	f.skipSpaces()
+	rest := f.text[f.pos:]
+	if yamlQuoted(rest) {
+		s, after, err := yamlUnquote(rest)
+		if err != nil {
+			return nil, err
+		}
+		f.pos = len(f.text) - len(after)
+		return s, nil
+	}
+	end := 0
+	for end < len(rest) {
+		c := rest[end]
+		if c == ',' || c == ']' || c == '}' || c == '[' || c == '{' {
+			break
+		}
+		if c == ':' && (key || end+1 == len(rest) || rest[end+1] == ' ' || rest[end+1] == ',') {
+			break
+		}
+		end++
+	}
+	f.pos += end
+	value := strings.TrimSpace(rest[:end])
+	if key {
+		return value, nil
+	}
+	return yamlScalar(value)
+}
@@ -0,0 +1,56 @@
+package yamljson
+
+import (
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want string
+	}{
+		{"", `null`},
+		{"key: value", `{"key":"value"}`},
+		{"---\nkey: value\n...\n", `{"key":"value"}`},
+		{"a: 1\nb: -2.5\nc: 1e3\nd: 0x1F\ne: true\nf: False\ng: ~\nh: null\ni:\nj: 007\nk: 1.2.3", `{"a":1,"b":-2.5,"c":1000,"d":31,"e":true,"f":false,"g":null,"h":null,"i":null,"j":7,"k":"1.2.3"}`},
+		{`a: "quoted: # not a comment"  # a comment` + "\n" + `b: 'it''s'` + "\n" + `c: "tab\there \u00e9"` + "\n" + `d: "1"`, `{"a":"quoted: # not a comment","b":"it's","c":"tab\there é","d":"1"}`},
+		{"# heading\nurl: http://example.com/#anchor\nkey: value # trailing\n\n", `{"key":"value","url":"http://example.com/#anchor"}`},
+		{"outer:\n  inner:\n    leaf: x\n  other: y\nlast: z", `{"last":"z","outer":{"inner":{"leaf":"x"},"other":"y"}}`},
+		{"list:\n  - a\n  - 2\n  -\n    nested: true\nsame:\n- x\n- y", `{"list":["a",2,{"nested":true}],"same":["x","y"]}`},
+		{"- name: a\n  values:\n    - 1\n- name: b\n  - not: here", ``},
+		{"- name: a\n  values:\n    - 1\n- - x\n  - y", `[{"name":"a","values":[1]},["x","y"]]`},
+		{`flow: [a, "b, c", 3, {k: v, n: 1}, []]` + "\nmap: {a: [1, 2],\n  b: c}", `{"flow":["a","b, c",3,{"k":"v","n":1},[]],"map":{"a":[1,2],"b":"c"}}`},
+		{"literal: |\n  line 1\n    indented\n\n  line 3\nnext: x", `{"literal":"line 1\n  indented\n\nline 3\n","next":"x"}`},
+		{"folded: >-\n  one\n  two\n\n  three\n\n\nkeep: |+\n  x\n\nend: y", `{"end":"y","folded":"one two\nthree","keep":"x\n\n"}`},
+		{"'quoted key': 1\n\"2\": two", `{"2":"two","quoted key":1}`},
+		{"a: 1\na: 2", ``},
+		{"a: &anchor 1", ``},
+		{"a: *alias", ``},
+		{"a: !!str 1", ``},
+		{"? complex\n: key", ``},
+		{"a: plain\n  continued", ``},
+		{"a: [1, 2", ``},
+		{"a: 1\n  b: 2", ``},
+		{"a: .inf", ``},
+		{"a: 1\n---\nb: 2", ``},
+		{"a:\n\t- 1", ``},
+		{`a: "unterminated`, ``},
+		{`a: "\q"`, ``},
+	}
+	for _, test := range tests {
+		got, err := ToJSON([]byte(test.yaml))
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("ToJSON(%q) returned %s, want an error", test.yaml, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ToJSON(%q) returned error: %v", test.yaml, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("ToJSON(%q) returned \n\t%s, want \n\t%v\n", test.yaml, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package okta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status values of an authentication transaction
+const (
+	AuthnStatusUnauthenticated   = "UNAUTHENTICATED"
+	AuthnStatusPasswordWarn      = "PASSWORD_WARN"
+	AuthnStatusPasswordExpired   = "PASSWORD_EXPIRED"
+	AuthnStatusRecovery          = "RECOVERY"
+	AuthnStatusRecoveryChallenge = "RECOVERY_CHALLENGE"
+	AuthnStatusPasswordReset     = "PASSWORD_RESET"
+	AuthnStatusLockedOut         = "LOCKED_OUT"
+	AuthnStatusMFAEnroll         = "MFA_ENROLL"
+	AuthnStatusMFAEnrollActivate = "MFA_ENROLL_ACTIVATE"
+	AuthnStatusMFARequired       = "MFA_REQUIRED"
+	AuthnStatusMFAChallenge      = "MFA_CHALLENGE"
+	AuthnStatusSuccess           = "SUCCESS"
+)
+
+// AuthnService handles communication with the Authentication (authn) API of OKTA.
+// Every call returns an AuthnTransaction whose Status is the next state of the login flow.
+// The transaction carries the stateToken and the links to the next steps, so a flow is
+// driven by passing the last transaction to Next, VerifyFactor, Resend, Prev, Skip or Cancel
+// until the Status is AuthnStatusSuccess and SessionToken is set.
+//
+// Authn requests are sent without the API token, as a public application would, so they are
+// subject to the org's sign-on policies and per IP rate limits.
+// https://developer.okta.com/docs/reference/api/authn/
+type AuthnService service
+
+// AuthnTransaction is the state of an authentication transaction
+type AuthnTransaction struct {
+	StateToken   string         `json:"stateToken,omitempty"`
+	SessionToken string         `json:"sessionToken,omitempty"`
+	ExpiresAt    *time.Time     `json:"expiresAt,omitempty"`
+	Status       string         `json:"status,omitempty"`
+	FactorResult string         `json:"factorResult,omitempty"`
+	FactorType   string         `json:"factorType,omitempty"`
+	RecoveryType string         `json:"recoveryType,omitempty"`
+	RelayState   string         `json:"relayState,omitempty"`
+	Embedded     *AuthnEmbedded `json:"_embedded,omitempty"`
+	Links        *AuthnLinks    `json:"_links,omitempty"`
+}
+
+// AuthnEmbedded holds the embedded resources of a transaction. Factors is set for MFA_ENROLL and
+// MFA_REQUIRED, Factor for MFA_ENROLL_ACTIVATE and MFA_CHALLENGE
+type AuthnEmbedded struct {
+	User    *AuthnUser   `json:"user,omitempty"`
+	Factors []UserFactor `json:"factors,omitempty"`
+	Factor  *UserFactor  `json:"factor,omitempty"`
+	Policy  *AuthnPolicy `json:"policy,omitempty"`
+}
+
+// AuthnUser is the user of a transaction
+type AuthnUser struct {
+	ID              string     `json:"id,omitempty"`
+	PasswordChanged *time.Time `json:"passwordChanged,omitempty"`
+	Profile         struct {
+		Login     string `json:"login,omitempty"`
+		FirstName string `json:"firstName,omitempty"`
+		LastName  string `json:"lastName,omitempty"`
+		Locale    string `json:"locale,omitempty"`
+		TimeZone  string `json:"timeZone,omitempty"`
+	} `json:"profile,omitempty"`
+	RecoveryQuestion *struct {
+		Question string `json:"question,omitempty"`
+	} `json:"recovery_question,omitempty"`
+}
+
+// AuthnPolicy is the password or MFA enrollment policy of a transaction
+type AuthnPolicy struct {
+	Expiration *struct {
+		PasswordExpireDays int `json:"passwordExpireDays,omitempty"`
+	} `json:"expiration,omitempty"`
+	Complexity *struct {
+		MinLength         int      `json:"minLength,omitempty"`
+		MinLowerCase      int      `json:"minLowerCase,omitempty"`
+		MinUpperCase      int      `json:"minUpperCase,omitempty"`
+		MinNumber         int      `json:"minNumber,omitempty"`
+		MinSymbol         int      `json:"minSymbol,omitempty"`
+		ExcludeUsername   bool     `json:"excludeUsername,omitempty"`
+		ExcludeAttributes []string `json:"excludeAttributes,omitempty"`
+	} `json:"complexity,omitempty"`
+	Age *struct {
+		MinAgeMinutes int `json:"minAgeMinutes,omitempty"`
+		HistoryCount  int `json:"historyCount,omitempty"`
+	} `json:"age,omitempty"`
+	AllowRememberDevice             bool `json:"allowRememberDevice,omitempty"`
+	RememberDeviceByDefault         bool `json:"rememberDeviceByDefault,omitempty"`
+	RememberDeviceLifetimeInMinutes int  `json:"rememberDeviceLifetimeInMinutes,omitempty"`
+}
+
+// AuthnLinks are the links to the next steps of a transaction. Next.Name tells what Next
+// does, for example "verify", "activate", "changePassword", "resetPassword", "answer" or "poll"
+type AuthnLinks struct {
+	Next   *FactorLink  `json:"next,omitempty"`
+	Prev   *FactorLink  `json:"prev,omitempty"`
+	Cancel *FactorLink  `json:"cancel,omitempty"`
+	Skip   *FactorLink  `json:"skip,omitempty"`
+	Verify *FactorLink  `json:"verify,omitempty"`
+	Resend []FactorLink `json:"resend,omitempty"`
+}
+
+// AuthnRequest starts a primary authentication
+type AuthnRequest struct {
+	Username   string        `json:"username,omitempty"`
+	Password   string        `json:"password,omitempty"`
+	RelayState string        `json:"relayState,omitempty"`
+	Options    *AuthnOptions `json:"options,omitempty"`
+	Context    *AuthnContext `json:"context,omitempty"`
+}
+
+// AuthnOptions of a primary authentication
+type AuthnOptions struct {
+	MultiOptionalFactorEnroll bool `json:"multiOptionalFactorEnroll,omitempty"`
+	WarnBeforePasswordExpired bool `json:"warnBeforePasswordExpired,omitempty"`
+}
+
+// AuthnContext of a primary authentication
+type AuthnContext struct {
+	DeviceToken string `json:"deviceToken,omitempty"`
+}
+
+// AuthnStepRequest is the body of a step of a transaction. Only the fields needed by the step are
+// set, for example PassCode to verify an otp factor or OldPassword and NewPassword to change an
+// expired password. The stateToken is taken from the transaction
+type AuthnStepRequest struct {
+	StateToken   string `json:"stateToken"`
+	PassCode     string `json:"passCode,omitempty"`
+	NextPassCode string `json:"nextPassCode,omitempty"`
+	Answer       string `json:"answer,omitempty"`
+	OldPassword  string `json:"oldPassword,omitempty"`
+	NewPassword  string `json:"newPassword,omitempty"`
+
+	// Enrollment of a factor in MFA_ENROLL
+	FactorType string      `json:"factorType,omitempty"`
+	Provider   string      `json:"provider,omitempty"`
+	Profile    interface{} `json:"profile,omitempty"`
+
+	// WebAuthn activation and verification
+	Attestation       string `json:"attestation,omitempty"`
+	ClientData        string `json:"clientData,omitempty"`
+	AuthenticatorData string `json:"authenticatorData,omitempty"`
+	SignatureData     string `json:"signatureData,omitempty"`
+}
+
+// AuthnRecoveryRequest starts a forgot password or unlock account transaction
+type AuthnRecoveryRequest struct {
+	Username   string `json:"username"`
+	FactorType string `json:"factorType,omitempty"`
+	RelayState string `json:"relayState,omitempty"`
+}
+
+// Authenticate: Start a transaction with primary authentication of a username and password
+func (p *AuthnService) Authenticate(authn AuthnRequest) (*AuthnTransaction, *Response, error) {
+	return p.post("authn", authn)
+}
+
+// GetTransaction: Get the current state of a transaction
+func (p *AuthnService) GetTransaction(stateToken string) (*AuthnTransaction, *Response, error) {
+	return p.post("authn", AuthnStepRequest{StateToken: stateToken})
+}
+
+// Next: Follow the next link of a transaction
+func (p *AuthnService) Next(tx *AuthnTransaction, step AuthnStepRequest) (*AuthnTransaction, *Response, error) {
+	return p.follow(tx, authnLinks(tx).Next, step, "next")
+}
+
+// Prev: Follow the prev link of a transaction, for example to pick another factor in MFA_ENROLL_ACTIVATE
+func (p *AuthnService) Prev(tx *AuthnTransaction) (*AuthnTransaction, *Response, error) {
+	return p.follow(tx, authnLinks(tx).Prev, AuthnStepRequest{}, "prev")
+}
+
+// Skip: Follow the skip link of a transaction, for example to skip a PASSWORD_WARN or optional enrollment
+func (p *AuthnService) Skip(tx *AuthnTransaction) (*AuthnTransaction, *Response, error) {
+	return p.follow(tx, authnLinks(tx).Skip, AuthnStepRequest{}, "skip")
+}
+
+// Cancel: Follow the cancel link of a transaction. The stateToken can not be used afterwards
+func (p *AuthnService) Cancel(tx *AuthnTransaction) (*AuthnTransaction, *Response, error) {
+	return p.follow(tx, authnLinks(tx).Cancel, AuthnStepRequest{}, "cancel")
+}
+
+// Resend: Follow a resend link of a transaction to send a new sms, call or email challenge.
+// name selects the link by factor type, for example "sms". An empty name uses the first link
+func (p *AuthnService) Resend(tx *AuthnTransaction, name string) (*AuthnTransaction, *Response, error) {
+	var link *FactorLink
+	resend := authnLinks(tx).Resend
+	for i := range resend {
+		if name == "" || resend[i].Name == name {
+			link = &resend[i]
+			break
+		}
+	}
+	return p.follow(tx, link, AuthnStepRequest{}, "resend")
+}
+
+// VerifyFactor: Verify a factor of a transaction. In MFA_REQUIRED it follows the verify link of the
+// factor with factorID, in MFA_CHALLENGE and RECOVERY_CHALLENGE the verify link of the transaction.
+// An empty step sends a challenge for sms, call, email and push factors
+func (p *AuthnService) VerifyFactor(tx *AuthnTransaction, factorID string, step AuthnStepRequest) (*AuthnTransaction, *Response, error) {
+	var link *FactorLink
+	if tx != nil && tx.Embedded != nil {
+		for _, factor := range tx.Embedded.Factors {
+			if factor.ID == factorID && factor.Links != nil {
+				link = factor.Links.Verify
+				break
+			}
+		}
+	}
+	if link == nil {
+		link = authnLinks(tx).Verify
+	}
+	if next := authnLinks(tx).Next; link == nil && next != nil && next.Name == "verify" {
+		link = next
+	}
+	return p.follow(tx, link, step, "verify")
+}
+
+// EnrollFactor: Enroll a factor of a transaction in MFA_ENROLL. The transaction moves to MFA_ENROLL_ACTIVATE,
+// or to SUCCESS for factors that do not need activation
+func (p *AuthnService) EnrollFactor(tx *AuthnTransaction, factor UserFactor) (*AuthnTransaction, *Response, error) {
+	if tx == nil || tx.StateToken == "" {
+		return nil, nil, errors.New("[ERROR] Authn.EnrollFactor requires a transaction with a stateToken")
+	}
+	step := AuthnStepRequest{
+		StateToken: tx.StateToken,
+		FactorType: factor.FactorType,
+		Provider:   factor.Provider,
+		Profile:    factor.Profile,
+	}
+	return p.post("authn/factors", step)
+}
+
+// ChangePassword: Change the expired password of a transaction in PASSWORD_EXPIRED or PASSWORD_WARN
+func (p *AuthnService) ChangePassword(tx *AuthnTransaction, oldPassword string, newPassword string) (*AuthnTransaction, *Response, error) {
+	if tx == nil || tx.StateToken == "" {
+		return nil, nil, errors.New("[ERROR] Authn.ChangePassword requires a transaction with a stateToken")
+	}
+	step := AuthnStepRequest{
+		StateToken:  tx.StateToken,
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+	}
+	return p.post("authn/credentials/change_password", step)
+}
+
+// PollFactor: Poll a push challenge of a transaction in MFA_CHALLENGE every pollInterval until the user
+// answers, the challenge expires, or ctx is done. A pollInterval of 0 uses 5 seconds
+func (p *AuthnService) PollFactor(ctx context.Context, tx *AuthnTransaction, pollInterval time.Duration) (*AuthnTransaction, *Response, error) {
+	if tx == nil {
+		return nil, nil, errors.New("[ERROR] Authn.PollFactor requires a transaction")
+	}
+	if pollInterval <= 0 {
+		pollInterval = defaultFactorPollInterval
+	}
+
+	var resp *Response
+	var err error
+	for err == nil && tx.Status == AuthnStatusMFAChallenge && tx.FactorResult == FactorResultWaiting {
+		if tx.Links == nil || tx.Links.Next == nil || tx.Links.Next.Name != "poll" {
+			return tx, resp, errors.New("[ERROR] Authn.PollFactor transaction has no poll link")
+		}
+		select {
+		case <-ctx.Done():
+			return tx, resp, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+
+		var next *AuthnTransaction
+		next, resp, err = p.followWithContext(ctx, tx, tx.Links.Next, AuthnStepRequest{}, "poll")
+		if err == nil {
+			tx = next
+		}
+	}
+
+	return tx, resp, err
+}
+
+// ForgotPassword: Start a recovery transaction to reset the password of a user. factorType is
+// FactorTypeEmail, FactorTypeSMS or FactorTypeCall. The transaction starts in RECOVERY_CHALLENGE
+func (p *AuthnService) ForgotPassword(recovery AuthnRecoveryRequest) (*AuthnTransaction, *Response, error) {
+	return p.post("authn/recovery/password", recovery)
+}
+
+// UnlockAccount: Start a recovery transaction to unlock a LOCKED_OUT user. factorType is
+// FactorTypeEmail, FactorTypeSMS or FactorTypeCall. The transaction starts in RECOVERY_CHALLENGE
+func (p *AuthnService) UnlockAccount(recovery AuthnRecoveryRequest) (*AuthnTransaction, *Response, error) {
+	return p.post("authn/recovery/unlock", recovery)
+}
+
+// VerifyRecoveryToken: Verify the recovery token sent by email to start a RECOVERY transaction
+func (p *AuthnService) VerifyRecoveryToken(recoveryToken string) (*AuthnTransaction, *Response, error) {
+	return p.post("authn/recovery/token", map[string]string{"recoveryToken": recoveryToken})
+}
+
+// authnLinks returns the links of tx, or no links for a nil transaction
+func authnLinks(tx *AuthnTransaction) *AuthnLinks {
+	if tx == nil || tx.Links == nil {
+		return &AuthnLinks{}
+	}
+	return tx.Links
+}
+
+// follow posts step with the stateToken of tx to link
+func (p *AuthnService) follow(tx *AuthnTransaction, link *FactorLink, step AuthnStepRequest, name string) (*AuthnTransaction, *Response, error) {
+	return p.followWithContext(context.Background(), tx, link, step, name)
+}
+
+func (p *AuthnService) followWithContext(ctx context.Context, tx *AuthnTransaction, link *FactorLink, step AuthnStepRequest, name string) (*AuthnTransaction, *Response, error) {
+	if tx == nil || tx.StateToken == "" {
+		return nil, nil, fmt.Errorf("[ERROR] Authn %v requires a transaction with a stateToken", name)
+	}
+	if link == nil || link.Href == "" {
+		return nil, nil, fmt.Errorf("[ERROR] Authn transaction in status %v has no %v link", tx.Status, name)
+	}
+	step.StateToken = tx.StateToken
+
+	return p.postWithContext(ctx, link.Href, step)
+}
+
+func (p *AuthnService) post(u string, body interface{}) (*AuthnTransaction, *Response, error) {
+	return p.postWithContext(context.Background(), u, body)
+}
+
+func (p *AuthnService) postWithContext(ctx context.Context, u string, body interface{}) (*AuthnTransaction, *Response, error) {
+	req, err := p.client.NewRequest("POST", u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Del(headerAuthorization)
+
+	tx := new(AuthnTransaction)
+	resp, err := p.client.Do(req.WithContext(ctx), tx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tx, resp, err
+}
@@ -0,0 +1,387 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testAuthnStateToken = "007ucIX7PATyn94hsHfOLVaXAmOBkKHWnOOLG43bsb"
+
+// testAuthnStep registers a handler for path that checks the request body and answers with
+// response, in which %[1]v is replaced with the test server URL
+func testAuthnStep(t *testing.T, path string, want interface{}, response string) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testNoAuthHeader(t, r)
+		testBody(t, r, want)
+		fmt.Fprintf(w, response, server.URL)
+	})
+}
+
+func testNoAuthHeader(t *testing.T, r *http.Request) {
+	if value := r.Header.Get("Authorization"); value != "" {
+		t.Errorf("Authorization Header %s, want none", value)
+	}
+}
+
+func testAuthnStatus(t *testing.T, step string, tx *AuthnTransaction, err error, want string) {
+	if err != nil {
+		t.Fatalf("Authn.%v returned error: %v", step, err)
+	}
+	if tx.Status != want {
+		t.Fatalf("client.Authn.%v returned status %v, want %v", step, tx.Status, want)
+	}
+}
+
+func TestAuthnSuccess(t *testing.T) {
+	setup()
+	defer teardown()
+
+	authn := AuthnRequest{Username: "dade.murphy@example.com", Password: "correcthorsebatterystaple", Options: &AuthnOptions{WarnBeforePasswordExpired: true}}
+	testAuthnStep(t, "/authn", authn, `{
+		"expiresAt": "2015-11-03T10:15:57.000Z",
+		"status": "SUCCESS",
+		"relayState": "/myapp/some/deep/link/i/want/to/return/to",
+		"sessionToken": "00Fpzf4en68pCXTsMjcX8JPMctzN2Wiw4LDOBL_9pe",
+		"_embedded": {
+			"user": {
+				"id": "00ub0oNGTSWTBKOLGLNR",
+				"passwordChanged": "2015-09-08T20:14:45.000Z",
+				"profile": {"login": "dade.murphy@example.com", "firstName": "Dade", "lastName": "Murphy"}
+			}
+		}
+	}`)
+
+	tx, _, err := client.Authn.Authenticate(authn)
+	testAuthnStatus(t, "Authenticate", tx, err, AuthnStatusSuccess)
+	if tx.SessionToken != "00Fpzf4en68pCXTsMjcX8JPMctzN2Wiw4LDOBL_9pe" {
+		t.Errorf("client.Authn.Authenticate returned sessionToken %v", tx.SessionToken)
+	}
+	if tx.Embedded.User.Profile.Login != "dade.murphy@example.com" {
+		t.Errorf("client.Authn.Authenticate returned user %+v", tx.Embedded.User)
+	}
+}
+
+func TestAuthnPasswordExpired(t *testing.T) {
+	setup()
+	defer teardown()
+
+	authn := AuthnRequest{Username: "dade.murphy@example.com", Password: "correcthorsebatterystaple"}
+	testAuthnStep(t, "/authn", authn, `{
+		"stateToken": "`+testAuthnStateToken+`",
+		"status": "PASSWORD_EXPIRED",
+		"_embedded": {"policy": {"complexity": {"minLength": 8, "minNumber": 1}}},
+		"_links": {
+			"next": {"name": "changePassword", "href": "%[1]v/authn/credentials/change_password"},
+			"cancel": {"href": "%[1]v/authn/cancel"}
+		}
+	}`)
+	testAuthnStep(t, "/authn/credentials/change_password",
+		AuthnStepRequest{StateToken: testAuthnStateToken, OldPassword: "correcthorsebatterystaple", NewPassword: "Ch-ch-ch-ch-Changes!"},
+		`{"status": "SUCCESS", "sessionToken": "00t6IUQiVbWpMLgtmwSjMFzqykb5QcaBNtveiWlGeM"}`)
+
+	tx, _, err := client.Authn.Authenticate(authn)
+	testAuthnStatus(t, "Authenticate", tx, err, AuthnStatusPasswordExpired)
+	if tx.Embedded.Policy.Complexity.MinLength != 8 {
+		t.Errorf("client.Authn.Authenticate returned policy %+v", tx.Embedded.Policy)
+	}
+
+	tx, _, err = client.Authn.ChangePassword(tx, "correcthorsebatterystaple", "Ch-ch-ch-ch-Changes!")
+	testAuthnStatus(t, "ChangePassword", tx, err, AuthnStatusSuccess)
+	if tx.SessionToken == "" {
+		t.Errorf("client.Authn.ChangePassword returned no sessionToken")
+	}
+}
+
+func TestAuthnMFAEnroll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	authn := AuthnRequest{Username: "dade.murphy@example.com", Password: "correcthorsebatterystaple"}
+	testAuthnStep(t, "/authn", authn, `{
+		"stateToken": "`+testAuthnStateToken+`",
+		"status": "MFA_ENROLL",
+		"_embedded": {
+			"factors": [
+				{"factorType": "token:software:totp", "provider": "OKTA", "status": "NOT_SETUP", "enrollment": "REQUIRED",
+				 "_links": {"enroll": {"href": "%[1]v/authn/factors"}}}
+			]
+		},
+		"_links": {"cancel": {"href": "%[1]v/authn/cancel"}}
+	}`)
+	testAuthnStep(t, "/authn/factors",
+		AuthnStepRequest{StateToken: testAuthnStateToken, FactorType: FactorTypeTOTP, Provider: FactorProviderOkta},
+		`{
+		"stateToken": "`+testAuthnStateToken+`",
+		"status": "MFA_ENROLL_ACTIVATE",
+		"_embedded": {
+			"factor": {
+				"id": "uft1fmaMGJLMNGNLIVG",
+				"factorType": "token:software:totp",
+				"provider": "OKTA",
+				"profile": {"credentialId": "dade.murphy@example.com"},
+				"_embedded": {"activation": {"timeStep": 30, "sharedSecret": "JBSWY3DPEHPK3PXP", "encoding": "base32", "keyLength": 16}}
+			}
+		},
+		"_links": {
+			"next": {"name": "activate", "href": "%[1]v/authn/factors/uft1fmaMGJLMNGNLIVG/lifecycle/activate"},
+			"prev": {"href": "%[1]v/authn/previous"},
+			"cancel": {"href": "%[1]v/authn/cancel"}
+		}
+	}`)
+
+	// the activation passcode is computed from the shared secret returned on enrollment
+	serverTOTP, _ := NewTOTP("JBSWY3DPEHPK3PXP")
+	mux.HandleFunc("/authn/factors/uft1fmaMGJLMNGNLIVG/lifecycle/activate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testNoAuthHeader(t, r)
+		var step AuthnStepRequest
+		json.NewDecoder(r.Body).Decode(&step)
+		if step.StateToken != testAuthnStateToken || !serverTOTP.Validate(step.PassCode, time.Now(), 1) {
+			t.Errorf("Authn activation sent %+v, want the stateToken and a valid passCode", step)
+		}
+		fmt.Fprint(w, `{"status": "SUCCESS", "sessionToken": "00Fpzf4en68pCXTsMjcX8JPMctzN2Wiw4LDOBL_9pe"}`)
+	})
+
+	tx, _, err := client.Authn.Authenticate(authn)
+	testAuthnStatus(t, "Authenticate", tx, err, AuthnStatusMFAEnroll)
+
+	tx, _, err = client.Authn.EnrollFactor(tx, tx.Embedded.Factors[0])
+	testAuthnStatus(t, "EnrollFactor", tx, err, AuthnStatusMFAEnrollActivate)
+
+	activation, err := NewTOTPFromActivation(tx.Embedded.Factor.Embedded.Activation)
+	if err != nil {
+		t.Fatalf("NewTOTPFromActivation returned error: %v", err)
+	}
+	passCode, _ := activation.Now()
+	tx, _, err = client.Authn.Next(tx, AuthnStepRequest{PassCode: passCode})
+	testAuthnStatus(t, "Next", tx, err, AuthnStatusSuccess)
+}
+
+func TestAuthnMFARequiredSMS(t *testing.T) {
+	setup()
+	defer teardown()
+
+	authn := AuthnRequest{Username: "dade.murphy@example.com", Password: "correcthorsebatterystaple"}
+	testAuthnStep(t, "/authn", authn, `{
+		"stateToken": "`+testAuthnStateToken+`",
+		"status": "MFA_REQUIRED",
+		"_embedded": {
+			"factors": [
+				{"id": "sms193zUBEROPBNZKPPE", "factorType": "sms", "provider": "OKTA", "profile": {"phoneNumber": "+1 XXX-XXX-1337"},
+				 "_links": {"verify": {"href": "%[1]v/authn/factors/sms193zUBEROPBNZKPPE/verify"}}}
+			]
+		},
+		"_links": {"cancel": {"href": "%[1]v/authn/cancel"}}
+	}`)
+
+	challenge := `{
+		"stateToken": "` + testAuthnStateToken + `",
+		"status": "MFA_CHALLENGE",
+		"factorResult": "CHALLENGE",
+		"_embedded": {"factor": {"id": "sms193zUBEROPBNZKPPE", "factorType": "sms", "provider": "OKTA"}},
+		"_links": {
+			"next": {"name": "verify", "href": "%[1]v/authn/factors/sms193zUBEROPBNZKPPE/verify"},
+			"resend": [{"name": "sms", "href": "%[1]v/authn/factors/sms193zUBEROPBNZKPPE/verify/resend"}],
+			"prev": {"href": "%[1]v/authn/previous"},
+			"cancel": {"href": "%[1]v/authn/cancel"}
+		}
+	}`
+	verifications := 0
+	mux.HandleFunc("/authn/factors/sms193zUBEROPBNZKPPE/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testNoAuthHeader(t, r)
+		verifications++
+		if verifications == 1 {
+			testBody(t, r, AuthnStepRequest{StateToken: testAuthnStateToken})
+			fmt.Fprintf(w, challenge, server.URL)
+			return
+		}
+		testBody(t, r, AuthnStepRequest{StateToken: testAuthnStateToken, PassCode: "657866"})
+		fmt.Fprint(w, `{"status": "SUCCESS", "sessionToken": "00Fpzf4en68pCXTsMjcX8JPMctzN2Wiw4LDOBL_9pe"}`)
+	})
+	testAuthnStep(t, "/authn/factors/sms193zUBEROPBNZKPPE/verify/resend", AuthnStepRequest{StateToken: testAuthnStateToken}, challenge)
+
+	tx, _, err := client.Authn.Authenticate(authn)
+	testAuthnStatus(t, "Authenticate", tx, err, AuthnStatusMFARequired)
+	if profile, ok := tx.Embedded.Factors[0].Profile.(*SMSFactorProfile); !ok || profile.PhoneNumber != "+1 XXX-XXX-1337" {
+		t.Errorf("client.Authn.Authenticate returned factor profile %+v", tx.Embedded.Factors[0].Profile)
+	}
+
+	tx, _, err = client.Authn.VerifyFactor(tx, "sms193zUBEROPBNZKPPE", AuthnStepRequest{})
+	testAuthnStatus(t, "VerifyFactor", tx, err, AuthnStatusMFAChallenge)
+
+	tx, _, err = client.Authn.Resend(tx, FactorTypeSMS)
+	testAuthnStatus(t, "Resend", tx, err, AuthnStatusMFAChallenge)
+
+	tx, _, err = client.Authn.VerifyFactor(tx, "sms193zUBEROPBNZKPPE", AuthnStepRequest{PassCode: "657866"})
+	testAuthnStatus(t, "VerifyFactor", tx, err, AuthnStatusSuccess)
+	if tx.SessionToken == "" || verifications != 2 {
+		t.Errorf("client.Authn.VerifyFactor returned sessionToken %q after %v verifications", tx.SessionToken, verifications)
+	}
+}
+
+func TestAuthnMFAChallengePush(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tx := &AuthnTransaction{
+		StateToken:   testAuthnStateToken,
+		Status:       AuthnStatusMFAChallenge,
+		FactorResult: FactorResultWaiting,
+		Links:        &AuthnLinks{Next: &FactorLink{Name: "poll", Href: server.URL + "/authn/factors/opfh52xcuft3J4uZc0g3/verify"}},
+	}
+
+	polls := 0
+	mux.HandleFunc("/authn/factors/opfh52xcuft3J4uZc0g3/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, AuthnStepRequest{StateToken: testAuthnStateToken})
+		polls++
+		if polls < 2 {
+			fmt.Fprintf(w, `{"stateToken": "%v", "status": "MFA_CHALLENGE", "factorResult": "WAITING",
+				"_links": {"next": {"name": "poll", "href": "%v%v"}}}`, testAuthnStateToken, server.URL, r.URL.Path)
+			return
+		}
+		fmt.Fprint(w, `{"status": "SUCCESS", "sessionToken": "00Fpzf4en68pCXTsMjcX8JPMctzN2Wiw4LDOBL_9pe"}`)
+	})
+
+	tx, _, err := client.Authn.PollFactor(context.Background(), tx, time.Millisecond)
+	testAuthnStatus(t, "PollFactor", tx, err, AuthnStatusSuccess)
+	if polls != 2 {
+		t.Errorf("client.Authn.PollFactor polled %v times, want 2", polls)
+	}
+}
+
+func TestAuthnMFAChallengePushRejected(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tx := &AuthnTransaction{
+		StateToken:   testAuthnStateToken,
+		Status:       AuthnStatusMFAChallenge,
+		FactorResult: FactorResultWaiting,
+		Links:        &AuthnLinks{Next: &FactorLink{Name: "poll", Href: server.URL + "/authn/factors/opfh52xcuft3J4uZc0g3/verify"}},
+	}
+	testAuthnStep(t, "/authn/factors/opfh52xcuft3J4uZc0g3/verify", AuthnStepRequest{StateToken: testAuthnStateToken},
+		`{"stateToken": "`+testAuthnStateToken+`", "status": "MFA_CHALLENGE", "factorResult": "REJECTED",
+		"_links": {"next": {"name": "verify", "href": "%[1]v/authn/factors/opfh52xcuft3J4uZc0g3/verify"}}}`)
+
+	tx, _, err := client.Authn.PollFactor(context.Background(), tx, time.Millisecond)
+	testAuthnStatus(t, "PollFactor", tx, err, AuthnStatusMFAChallenge)
+	if tx.FactorResult != FactorResultRejected {
+		t.Errorf("client.Authn.PollFactor returned factorResult %v, want %v", tx.FactorResult, FactorResultRejected)
+	}
+
+	if _, _, err := client.Authn.PollFactor(context.Background(), nil, time.Millisecond); err == nil {
+		t.Errorf("client.Authn.PollFactor of a nil transaction returned no error")
+	}
+}
+
+func TestAuthnLockedOutUnlock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	authn := AuthnRequest{Username: "dade.murphy@example.com", Password: "correcthorsebatterystaple"}
+	testAuthnStep(t, "/authn", authn, `{
+		"status": "LOCKED_OUT",
+		"_links": {"next": {"name": "unlock", "href": "%[1]v/authn/recovery/unlock"}}
+	}`)
+	unlock := AuthnRecoveryRequest{Username: "dade.murphy@example.com", FactorType: "EMAIL"}
+	testAuthnStep(t, "/authn/recovery/unlock", unlock, `{"status": "RECOVERY_CHALLENGE", "factorResult": "WAITING", "factorType": "EMAIL", "recoveryType": "UNLOCK"}`)
+	testAuthnStep(t, "/authn/recovery/token", map[string]string{"recoveryToken": "VBQ0gwBLLyD7jeEZsIj3"}, `{
+		"stateToken": "`+testAuthnStateToken+`",
+		"status": "RECOVERY",
+		"recoveryType": "UNLOCK",
+		"_embedded": {"user": {"id": "00ub0oNGTSWTBKOLGLNR", "recovery_question": {"question": "Who's a major player in the cowboy scene?"}}},
+		"_links": {"next": {"name": "answer", "href": "%[1]v/authn/recovery/answer"}, "cancel": {"href": "%[1]v/authn/cancel"}}
+	}`)
+	testAuthnStep(t, "/authn/recovery/answer", AuthnStepRequest{StateToken: testAuthnStateToken, Answer: "Cowboy Dan"},
+		`{"status": "SUCCESS", "recoveryType": "UNLOCK"}`)
+
+	tx, _, err := client.Authn.Authenticate(authn)
+	testAuthnStatus(t, "Authenticate", tx, err, AuthnStatusLockedOut)
+
+	tx, _, err = client.Authn.UnlockAccount(unlock)
+	testAuthnStatus(t, "UnlockAccount", tx, err, AuthnStatusRecoveryChallenge)
+
+	tx, _, err = client.Authn.VerifyRecoveryToken("VBQ0gwBLLyD7jeEZsIj3")
+	testAuthnStatus(t, "VerifyRecoveryToken", tx, err, AuthnStatusRecovery)
+	if tx.Embedded.User.RecoveryQuestion == nil || tx.Embedded.User.RecoveryQuestion.Question == "" {
+		t.Errorf("client.Authn.VerifyRecoveryToken returned user %+v", tx.Embedded.User)
+	}
+
+	tx, _, err = client.Authn.Next(tx, AuthnStepRequest{Answer: "Cowboy Dan"})
+	testAuthnStatus(t, "Next", tx, err, AuthnStatusSuccess)
+	if tx.RecoveryType != "UNLOCK" {
+		t.Errorf("client.Authn.Next returned recoveryType %v, want UNLOCK", tx.RecoveryType)
+	}
+}
+
+func TestAuthnRecoveryPasswordReset(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tx := &AuthnTransaction{
+		StateToken: testAuthnStateToken,
+		Status:     AuthnStatusRecovery,
+		Links:      &AuthnLinks{Next: &FactorLink{Name: "answer", Href: server.URL + "/authn/recovery/answer"}},
+	}
+	testAuthnStep(t, "/authn/recovery/answer", AuthnStepRequest{StateToken: testAuthnStateToken, Answer: "Cowboy Dan"}, `{
+		"stateToken": "`+testAuthnStateToken+`",
+		"status": "PASSWORD_RESET",
+		"recoveryType": "PASSWORD",
+		"_links": {"next": {"name": "resetPassword", "href": "%[1]v/authn/credentials/reset_password"}}
+	}`)
+	testAuthnStep(t, "/authn/credentials/reset_password", AuthnStepRequest{StateToken: testAuthnStateToken, NewPassword: "Ch-ch-ch-ch-Changes!"},
+		`{"status": "SUCCESS", "recoveryType": "PASSWORD", "sessionToken": "00t6IUQiVbWpMLgtmwSjMFzqykb5QcaBNtveiWlGeM"}`)
+
+	tx, _, err := client.Authn.Next(tx, AuthnStepRequest{Answer: "Cowboy Dan"})
+	testAuthnStatus(t, "Next", tx, err, AuthnStatusPasswordReset)
+
+	tx, _, err = client.Authn.Next(tx, AuthnStepRequest{NewPassword: "Ch-ch-ch-ch-Changes!"})
+	testAuthnStatus(t, "Next", tx, err, AuthnStatusSuccess)
+}
+
+func TestAuthnCancel(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tx := &AuthnTransaction{
+		StateToken: testAuthnStateToken,
+		Status:     AuthnStatusMFARequired,
+		Links:      &AuthnLinks{Cancel: &FactorLink{Href: server.URL + "/authn/cancel"}},
+	}
+	testAuthnStep(t, "/authn/cancel", AuthnStepRequest{StateToken: testAuthnStateToken}, `{"status": "UNAUTHENTICATED"}`)
+
+	if _, _, err := client.Authn.Skip(tx); err == nil {
+		t.Errorf("Authn.Skip expected an error for a transaction without a skip link")
+	}
+	if _, _, err := client.Authn.Next(nil, AuthnStepRequest{}); err == nil {
+		t.Errorf("Authn.Next expected an error without a transaction")
+	}
+
+	tx, _, err := client.Authn.Cancel(tx)
+	testAuthnStatus(t, "Cancel", tx, err, AuthnStatusUnauthenticated)
+}
+
+func TestAuthnInvalidCredentials(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/authn", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errorCode": "E0000004", "errorSummary": "Authentication failed"}`)
+	})
+
+	tx, _, err := client.Authn.Authenticate(AuthnRequest{Username: "dade.murphy@example.com", Password: "wrong"})
+	if err == nil || tx != nil {
+		t.Errorf("Authn.Authenticate returned %+v, %v, want an error", tx, err)
+	}
+	if client.OktaErrorCode != "E0000004" {
+		t.Errorf("client.OktaErrorCode = %v, want E0000004", client.OktaErrorCode)
+	}
+}
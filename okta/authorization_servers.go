@@ -0,0 +1,591 @@
+package okta
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Values of AuthorizationServerClaim.ClaimType, ValueType and GroupFilterType
+const (
+	ClaimTypeResource = "RESOURCE"
+	ClaimTypeIdentity = "IDENTITY"
+
+	ClaimValueTypeExpression = "EXPRESSION"
+	ClaimValueTypeGroups     = "GROUPS"
+	ClaimValueTypeSystem     = "SYSTEM"
+
+	ClaimGroupFilterStartsWith = "STARTS_WITH"
+	ClaimGroupFilterEquals     = "EQUALS"
+	ClaimGroupFilterContains   = "CONTAINS"
+	ClaimGroupFilterRegex      = "REGEX"
+)
+
+// Values of AuthorizationServerScope.Consent and MetadataPublish
+const (
+	ScopeConsentImplicit = "IMPLICIT"
+	ScopeConsentRequired = "REQUIRED"
+	ScopeConsentFlexible = "FLEXIBLE"
+
+	ScopeMetadataPublishAllClients = "ALL_CLIENTS"
+	ScopeMetadataPublishNoClients  = "NO_CLIENTS"
+)
+
+// Grant types of an AuthorizationServerPolicyRule
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeImplicit          = "implicit"
+	GrantTypePassword          = "password"
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantTypeSAML2Bearer       = "urn:ietf:params:oauth:grant-type:saml2-bearer"
+)
+
+const (
+	// AuthorizationServerPolicyType is the type of an authorization server access policy
+	AuthorizationServerPolicyType = "OAUTH_AUTHORIZATION_POLICY"
+	// AuthorizationServerPolicyRuleType is the type of an access policy rule
+	AuthorizationServerPolicyRuleType = "RESOURCE_ACCESS"
+)
+
+// AuthorizationServersService handles communication with the custom Authorization Servers
+// related methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/authorization-servers/
+type AuthorizationServersService service
+
+// AuthorizationServer is a custom authorization server
+type AuthorizationServer struct {
+	ID          string                          `json:"id,omitempty"`
+	Name        string                          `json:"name,omitempty"`
+	Description string                          `json:"description,omitempty"`
+	Audiences   []string                        `json:"audiences,omitempty"`
+	Issuer      string                          `json:"issuer,omitempty"`
+	IssuerMode  string                          `json:"issuerMode,omitempty"`
+	Status      string                          `json:"status,omitempty"`
+	Created     *time.Time                      `json:"created,omitempty"`
+	LastUpdated *time.Time                      `json:"lastUpdated,omitempty"`
+	Credentials *AuthorizationServerCredentials `json:"credentials,omitempty"`
+}
+
+// AuthorizationServerCredentials holds the signing key settings of an authorization server
+type AuthorizationServerCredentials struct {
+	Signing struct {
+		Kid          string     `json:"kid,omitempty"`
+		LastRotated  *time.Time `json:"lastRotated,omitempty"`
+		NextRotation *time.Time `json:"nextRotation,omitempty"`
+		RotationMode string     `json:"rotationMode,omitempty"`
+		Use          string     `json:"use,omitempty"`
+	} `json:"signing,omitempty"`
+}
+
+// AuthorizationServerScope is a scope of an authorization server
+type AuthorizationServerScope struct {
+	ID              string `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	DisplayName     string `json:"displayName,omitempty"`
+	Description     string `json:"description,omitempty"`
+	Consent         string `json:"consent,omitempty"`
+	Default         bool   `json:"default,omitempty"`
+	MetadataPublish string `json:"metadataPublish,omitempty"`
+	System          bool   `json:"system,omitempty"`
+}
+
+// AuthorizationServerClaim is a claim of an authorization server. Value is an Okta expression
+// for ClaimValueTypeExpression, or the group name to match with GroupFilterType for ClaimValueTypeGroups
+type AuthorizationServerClaim struct {
+	ID                   string           `json:"id,omitempty"`
+	Name                 string           `json:"name,omitempty"`
+	Status               string           `json:"status,omitempty"`
+	ClaimType            string           `json:"claimType,omitempty"`
+	ValueType            string           `json:"valueType,omitempty"`
+	Value                string           `json:"value,omitempty"`
+	AlwaysIncludeInToken bool             `json:"alwaysIncludeInToken,omitempty"`
+	GroupFilterType      string           `json:"group_filter_type,omitempty"`
+	Conditions           *ClaimConditions `json:"conditions,omitempty"`
+	System               bool             `json:"system,omitempty"`
+}
+
+// ClaimConditions limits a claim to tokens requested with one of Scopes
+type ClaimConditions struct {
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// AuthorizationServerPolicy is an access policy of an authorization server
+type AuthorizationServerPolicy struct {
+	ID          string                               `json:"id,omitempty"`
+	Type        string                               `json:"type,omitempty"`
+	Name        string                               `json:"name,omitempty"`
+	Description string                               `json:"description,omitempty"`
+	Priority    int                                  `json:"priority,omitempty"`
+	Status      string                               `json:"status,omitempty"`
+	System      bool                                 `json:"system,omitempty"`
+	Created     *time.Time                           `json:"created,omitempty"`
+	LastUpdated *time.Time                           `json:"lastUpdated,omitempty"`
+	Conditions  *AuthorizationServerPolicyConditions `json:"conditions,omitempty"`
+}
+
+// AuthorizationServerPolicyConditions limits a policy to the clients in Clients.Include, or "ALL_CLIENTS"
+type AuthorizationServerPolicyConditions struct {
+	Clients *IncludeExclude `json:"clients,omitempty"`
+}
+
+// IncludeExclude is a condition including and excluding a list of IDs or values
+type IncludeExclude struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// AuthorizationServerPolicyRule is a rule of an access policy
+type AuthorizationServerPolicyRule struct {
+	ID          string                                   `json:"id,omitempty"`
+	Type        string                                   `json:"type,omitempty"`
+	Name        string                                   `json:"name,omitempty"`
+	Priority    int                                      `json:"priority,omitempty"`
+	Status      string                                   `json:"status,omitempty"`
+	System      bool                                     `json:"system,omitempty"`
+	Created     *time.Time                               `json:"created,omitempty"`
+	LastUpdated *time.Time                               `json:"lastUpdated,omitempty"`
+	Conditions  *AuthorizationServerPolicyRuleConditions `json:"conditions,omitempty"`
+	Actions     *AuthorizationServerPolicyRuleActions    `json:"actions,omitempty"`
+}
+
+// AuthorizationServerPolicyRuleConditions are the people, grant types and scopes a rule applies to.
+// Scopes.Include can be "*" for any scope
+type AuthorizationServerPolicyRuleConditions struct {
+	People     *RulePeopleCondition `json:"people,omitempty"`
+	GrantTypes *IncludeExclude      `json:"grantTypes,omitempty"`
+	Scopes     *IncludeExclude      `json:"scopes,omitempty"`
+}
+
+// RulePeopleCondition are the users and groups a rule applies to. Groups.Include can be "EVERYONE"
+type RulePeopleCondition struct {
+	Users  *IncludeExclude `json:"users,omitempty"`
+	Groups *IncludeExclude `json:"groups,omitempty"`
+}
+
+// AuthorizationServerPolicyRuleActions are the token lifetimes of a rule. A RefreshTokenLifetimeMinutes
+// of 0 means unlimited
+type AuthorizationServerPolicyRuleActions struct {
+	Token struct {
+		AccessTokenLifetimeMinutes  int `json:"accessTokenLifetimeMinutes,omitempty"`
+		RefreshTokenLifetimeMinutes int `json:"refreshTokenLifetimeMinutes"`
+		RefreshTokenWindowMinutes   int `json:"refreshTokenWindowMinutes,omitempty"`
+		InlineHook                  *struct {
+			ID string `json:"id,omitempty"`
+		} `json:"inlineHook,omitempty"`
+	} `json:"token"`
+}
+
+// JSONWebKey is a public key of a JSON Web Key Set
+type JSONWebKey struct {
+	Kid    string   `json:"kid,omitempty"`
+	Kty    string   `json:"kty,omitempty"`
+	Alg    string   `json:"alg,omitempty"`
+	Use    string   `json:"use,omitempty"`
+	Status string   `json:"status,omitempty"`
+	N      string   `json:"n,omitempty"`
+	E      string   `json:"e,omitempty"`
+	Crv    string   `json:"crv,omitempty"`
+	X      string   `json:"x,omitempty"`
+	Y      string   `json:"y,omitempty"`
+	X5c    []string `json:"x5c,omitempty"`
+	X5t    string   `json:"x5t,omitempty"`
+	// X5tS256, Created, LastUpdated and ExpiresAt are returned for the keys of Identity Providers
+	X5tS256     string     `json:"x5t#S256,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+// AuthorizationServerListOptions are the optional query parameters of ListAuthorizationServers
+type AuthorizationServerListOptions struct {
+	Q     string `url:"q,omitempty"`
+	Limit int    `url:"limit,omitempty"`
+	After string `url:"after,omitempty"`
+}
+
+// ListAuthorizationServers: List the custom authorization servers of the org. Q searches by name
+func (p *AuthorizationServersService) ListAuthorizationServers(opt *AuthorizationServerListOptions) ([]AuthorizationServer, *Response, error) {
+	u, err := addOptions("authorizationServers", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var servers []AuthorizationServer
+	resp, err := p.client.do("GET", u, nil, &servers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return servers, resp, err
+}
+
+// GetAuthorizationServer: Get an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) GetAuthorizationServer(id string) (*AuthorizationServer, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v", id)
+	server := new(AuthorizationServer)
+	resp, err := p.client.do("GET", u, nil, server)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return server, resp, err
+}
+
+// CreateAuthorizationServer: Create an authorization server with a name, description and audiences
+func (p *AuthorizationServersService) CreateAuthorizationServer(server AuthorizationServer) (*AuthorizationServer, *Response, error) {
+	newServer := new(AuthorizationServer)
+	resp, err := p.client.do("POST", "authorizationServers", server, newServer)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newServer, resp, err
+}
+
+// UpdateAuthorizationServer: Update an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) UpdateAuthorizationServer(id string, server AuthorizationServer) (*AuthorizationServer, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v", id)
+	updatedServer := new(AuthorizationServer)
+	resp, err := p.client.do("PUT", u, server, updatedServer)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updatedServer, resp, err
+}
+
+// DeleteAuthorizationServer: Delete an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) DeleteAuthorizationServer(id string) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v", id)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// ActivateAuthorizationServer: Activate/Deactivate an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object and a boolean to activate or deactivate
+func (p *AuthorizationServersService) ActivateAuthorizationServer(id string, activate bool) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/lifecycle/%v", id, lifecycleAction(activate))
+	return p.client.do("POST", u, nil, nil)
+}
+
+// ListKeys: List the signing keys of an authorization server, including the next key once a rotation is pending
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) ListKeys(id string) ([]JSONWebKey, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/credentials/keys", id)
+	var keys []JSONWebKey
+	resp, err := p.client.do("GET", u, nil, &keys)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return keys, resp, err
+}
+
+// RotateKeys: Rotate the signing keys of an authorization server. The rotation mode of the server
+// must be MANUAL. Returns the new set of keys
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) RotateKeys(id string) ([]JSONWebKey, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/credentials/lifecycle/keyRotate", id)
+	var keys []JSONWebKey
+	resp, err := p.client.do("POST", u, map[string]string{"use": "sig"}, &keys)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return keys, resp, err
+}
+
+// ListScopes: List the scopes of an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) ListScopes(serverID string) ([]AuthorizationServerScope, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/scopes", serverID)
+	var scopes []AuthorizationServerScope
+	resp, err := p.client.do("GET", u, nil, &scopes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return scopes, resp, err
+}
+
+// GetScope: Get a scope of an authorization server
+// Requires AuthorizationServer ID and Scope ID
+func (p *AuthorizationServersService) GetScope(serverID string, scopeID string) (*AuthorizationServerScope, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/scopes/%v", serverID, scopeID)
+	scope := new(AuthorizationServerScope)
+	resp, err := p.client.do("GET", u, nil, scope)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return scope, resp, err
+}
+
+// CreateScope: Create a scope of an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) CreateScope(serverID string, scope AuthorizationServerScope) (*AuthorizationServerScope, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/scopes", serverID)
+	newScope := new(AuthorizationServerScope)
+	resp, err := p.client.do("POST", u, scope, newScope)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newScope, resp, err
+}
+
+// UpdateScope: Update a scope of an authorization server
+// Requires AuthorizationServer ID and Scope ID
+func (p *AuthorizationServersService) UpdateScope(serverID string, scopeID string, scope AuthorizationServerScope) (*AuthorizationServerScope, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/scopes/%v", serverID, scopeID)
+	updatedScope := new(AuthorizationServerScope)
+	resp, err := p.client.do("PUT", u, scope, updatedScope)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updatedScope, resp, err
+}
+
+// DeleteScope: Delete a scope of an authorization server
+// Requires AuthorizationServer ID and Scope ID
+func (p *AuthorizationServersService) DeleteScope(serverID string, scopeID string) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/scopes/%v", serverID, scopeID)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// ListClaims: List the claims of an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) ListClaims(serverID string) ([]AuthorizationServerClaim, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/claims", serverID)
+	var claims []AuthorizationServerClaim
+	resp, err := p.client.do("GET", u, nil, &claims)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return claims, resp, err
+}
+
+// GetClaim: Get a claim of an authorization server
+// Requires AuthorizationServer ID and Claim ID
+func (p *AuthorizationServersService) GetClaim(serverID string, claimID string) (*AuthorizationServerClaim, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/claims/%v", serverID, claimID)
+	claim := new(AuthorizationServerClaim)
+	resp, err := p.client.do("GET", u, nil, claim)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return claim, resp, err
+}
+
+// CreateClaim: Create a claim of an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) CreateClaim(serverID string, claim AuthorizationServerClaim) (*AuthorizationServerClaim, *Response, error) {
+	if err := validateClaim(claim); err != nil {
+		return nil, nil, err
+	}
+	u := fmt.Sprintf("authorizationServers/%v/claims", serverID)
+	newClaim := new(AuthorizationServerClaim)
+	resp, err := p.client.do("POST", u, claim, newClaim)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newClaim, resp, err
+}
+
+// UpdateClaim: Update a claim of an authorization server
+// Requires AuthorizationServer ID and Claim ID
+func (p *AuthorizationServersService) UpdateClaim(serverID string, claimID string, claim AuthorizationServerClaim) (*AuthorizationServerClaim, *Response, error) {
+	if err := validateClaim(claim); err != nil {
+		return nil, nil, err
+	}
+	u := fmt.Sprintf("authorizationServers/%v/claims/%v", serverID, claimID)
+	updatedClaim := new(AuthorizationServerClaim)
+	resp, err := p.client.do("PUT", u, claim, updatedClaim)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updatedClaim, resp, err
+}
+
+// DeleteClaim: Delete a claim of an authorization server
+// Requires AuthorizationServer ID and Claim ID
+func (p *AuthorizationServersService) DeleteClaim(serverID string, claimID string) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/claims/%v", serverID, claimID)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// validateClaim checks the value of a claim matches its value type before it is sent
+func validateClaim(claim AuthorizationServerClaim) error {
+	switch claim.ValueType {
+	case ClaimValueTypeExpression:
+		if claim.Value == "" {
+			return errors.New("[ERROR] an EXPRESSION claim requires an expression Value")
+		}
+	case ClaimValueTypeGroups:
+		if claim.GroupFilterType == "" || claim.Value == "" {
+			return errors.New("[ERROR] a GROUPS claim requires a GroupFilterType and a Value to filter groups with")
+		}
+	}
+	return nil
+}
+
+// ListPolicies: List the access policies of an authorization server
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) ListPolicies(serverID string) ([]AuthorizationServerPolicy, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies", serverID)
+	var policies []AuthorizationServerPolicy
+	resp, err := p.client.do("GET", u, nil, &policies)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return policies, resp, err
+}
+
+// GetPolicy: Get an access policy of an authorization server
+// Requires AuthorizationServer ID and Policy ID
+func (p *AuthorizationServersService) GetPolicy(serverID string, policyID string) (*AuthorizationServerPolicy, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v", serverID, policyID)
+	policy := new(AuthorizationServerPolicy)
+	resp, err := p.client.do("GET", u, nil, policy)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return policy, resp, err
+}
+
+// CreatePolicy: Create an access policy of an authorization server. Type defaults to OAUTH_AUTHORIZATION_POLICY
+// Requires AuthorizationServer ID from AuthorizationServer object
+func (p *AuthorizationServersService) CreatePolicy(serverID string, policy AuthorizationServerPolicy) (*AuthorizationServerPolicy, *Response, error) {
+	if policy.Type == "" {
+		policy.Type = AuthorizationServerPolicyType
+	}
+	u := fmt.Sprintf("authorizationServers/%v/policies", serverID)
+	newPolicy := new(AuthorizationServerPolicy)
+	resp, err := p.client.do("POST", u, policy, newPolicy)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newPolicy, resp, err
+}
+
+// UpdatePolicy: Update an access policy of an authorization server
+// Requires AuthorizationServer ID and Policy ID
+func (p *AuthorizationServersService) UpdatePolicy(serverID string, policyID string, policy AuthorizationServerPolicy) (*AuthorizationServerPolicy, *Response, error) {
+	if policy.Type == "" {
+		policy.Type = AuthorizationServerPolicyType
+	}
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v", serverID, policyID)
+	updatedPolicy := new(AuthorizationServerPolicy)
+	resp, err := p.client.do("PUT", u, policy, updatedPolicy)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updatedPolicy, resp, err
+}
+
+// DeletePolicy: Delete an access policy of an authorization server
+// Requires AuthorizationServer ID and Policy ID
+func (p *AuthorizationServersService) DeletePolicy(serverID string, policyID string) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v", serverID, policyID)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// ActivatePolicy: Activate/Deactivate an access policy of an authorization server
+// Requires AuthorizationServer ID, Policy ID and a boolean to activate or deactivate
+func (p *AuthorizationServersService) ActivatePolicy(serverID string, policyID string, activate bool) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/lifecycle/%v", serverID, policyID, lifecycleAction(activate))
+	return p.client.do("POST", u, nil, nil)
+}
+
+// ListPolicyRules: List the rules of an access policy
+// Requires AuthorizationServer ID and Policy ID
+func (p *AuthorizationServersService) ListPolicyRules(serverID string, policyID string) ([]AuthorizationServerPolicyRule, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/rules", serverID, policyID)
+	var rules []AuthorizationServerPolicyRule
+	resp, err := p.client.do("GET", u, nil, &rules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rules, resp, err
+}
+
+// GetPolicyRule: Get a rule of an access policy
+// Requires AuthorizationServer ID, Policy ID and Rule ID
+func (p *AuthorizationServersService) GetPolicyRule(serverID string, policyID string, ruleID string) (*AuthorizationServerPolicyRule, *Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/rules/%v", serverID, policyID, ruleID)
+	rule := new(AuthorizationServerPolicyRule)
+	resp, err := p.client.do("GET", u, nil, rule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rule, resp, err
+}
+
+// CreatePolicyRule: Create a rule of an access policy. Type defaults to RESOURCE_ACCESS
+// Requires AuthorizationServer ID and Policy ID
+func (p *AuthorizationServersService) CreatePolicyRule(serverID string, policyID string, rule AuthorizationServerPolicyRule) (*AuthorizationServerPolicyRule, *Response, error) {
+	if rule.Type == "" {
+		rule.Type = AuthorizationServerPolicyRuleType
+	}
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/rules", serverID, policyID)
+	newRule := new(AuthorizationServerPolicyRule)
+	resp, err := p.client.do("POST", u, rule, newRule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newRule, resp, err
+}
+
+// UpdatePolicyRule: Update a rule of an access policy
+// Requires AuthorizationServer ID, Policy ID and Rule ID
+func (p *AuthorizationServersService) UpdatePolicyRule(serverID string, policyID string, ruleID string, rule AuthorizationServerPolicyRule) (*AuthorizationServerPolicyRule, *Response, error) {
+	if rule.Type == "" {
+		rule.Type = AuthorizationServerPolicyRuleType
+	}
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/rules/%v", serverID, policyID, ruleID)
+	updatedRule := new(AuthorizationServerPolicyRule)
+	resp, err := p.client.do("PUT", u, rule, updatedRule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updatedRule, resp, err
+}
+
+// DeletePolicyRule: Delete a rule of an access policy
+// Requires AuthorizationServer ID, Policy ID and Rule ID
+func (p *AuthorizationServersService) DeletePolicyRule(serverID string, policyID string, ruleID string) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/rules/%v", serverID, policyID, ruleID)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// ActivatePolicyRule: Activate/Deactivate a rule of an access policy
+// Requires AuthorizationServer ID, Policy ID, Rule ID and a boolean to activate or deactivate
+func (p *AuthorizationServersService) ActivatePolicyRule(serverID string, policyID string, ruleID string, activate bool) (*Response, error) {
+	u := fmt.Sprintf("authorizationServers/%v/policies/%v/rules/%v/lifecycle/%v", serverID, policyID, ruleID, lifecycleAction(activate))
+	return p.client.do("POST", u, nil, nil)
+}
+
+// lifecycleAction returns the lifecycle operation to activate or deactivate a resource
+func lifecycleAction(activate bool) string {
+	if activate {
+		return "activate"
+	}
+	return "deactivate"
+}
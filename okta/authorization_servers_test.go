@@ -0,0 +1,217 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListAuthorizationServers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/authorizationServers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		if got := r.URL.Query().Get("q"); got != "Sample" {
+			t.Errorf("AuthorizationServers.ListAuthorizationServers sent q=%v, want Sample", got)
+		}
+		fmt.Fprint(w, `[{
+			"id": "ausain6z9zIedDCxB0h7",
+			"name": "Sample Authorization Server",
+			"description": "Authorization Server Description",
+			"audiences": ["https://api.resource.com"],
+			"issuer": "https://your-domain.okta.com/oauth2/ausain6z9zIedDCxB0h7",
+			"issuerMode": "ORG_URL",
+			"status": "ACTIVE",
+			"credentials": {"signing": {"kid": "RQ8DuhdxCczyMvy7GNJb4Ka3lQ99vrSo3oFBUiZjzzc", "rotationMode": "AUTO", "use": "sig"}}
+		}]`)
+	})
+
+	servers, _, err := client.AuthorizationServers.ListAuthorizationServers(&AuthorizationServerListOptions{Q: "Sample"})
+	if err != nil {
+		t.Fatalf("AuthorizationServers.ListAuthorizationServers returned error: %v", err)
+	}
+	if len(servers) != 1 || servers[0].Audiences[0] != "https://api.resource.com" || servers[0].Credentials.Signing.RotationMode != "AUTO" {
+		t.Errorf("client.AuthorizationServers.ListAuthorizationServers returned %+v", servers)
+	}
+}
+
+func TestAuthorizationServerLifecycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	server := AuthorizationServer{Name: "Sample Authorization Server", Description: "Sample", Audiences: []string{"api://sample"}}
+	want := server
+	want.ID = "ausain6z9zIedDCxB0h7"
+	want.Status = "ACTIVE"
+
+	mux.HandleFunc("/authorizationServers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, server)
+		json.NewEncoder(w).Encode(want)
+	})
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7/lifecycle/deactivate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+	})
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	created, _, err := client.AuthorizationServers.CreateAuthorizationServer(server)
+	if err != nil {
+		t.Errorf("AuthorizationServers.CreateAuthorizationServer returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*created, want) {
+		t.Errorf("client.AuthorizationServers.CreateAuthorizationServer returned \n\t%+v, want \n\t%+v\n", created, want)
+	}
+	if _, err := client.AuthorizationServers.ActivateAuthorizationServer(created.ID, false); err != nil {
+		t.Errorf("AuthorizationServers.ActivateAuthorizationServer returned error: %v", err)
+	}
+	if _, err := client.AuthorizationServers.DeleteAuthorizationServer(created.ID); err != nil {
+		t.Errorf("AuthorizationServers.DeleteAuthorizationServer returned error: %v", err)
+	}
+}
+
+func TestCreateAuthorizationServerScope(t *testing.T) {
+	setup()
+	defer teardown()
+
+	scope := AuthorizationServerScope{Name: "car:drive", Description: "Drive car", Consent: ScopeConsentRequired, MetadataPublish: ScopeMetadataPublishAllClients}
+	want := scope
+	want.ID = "scpCmCCV1DpxVkCaye2X"
+
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7/scopes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, scope)
+		json.NewEncoder(w).Encode(want)
+	})
+
+	created, _, err := client.AuthorizationServers.CreateScope("ausain6z9zIedDCxB0h7", scope)
+	if err != nil {
+		t.Errorf("AuthorizationServers.CreateScope returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*created, want) {
+		t.Errorf("client.AuthorizationServers.CreateScope returned \n\t%+v, want \n\t%+v\n", created, want)
+	}
+}
+
+func TestCreateAuthorizationServerClaims(t *testing.T) {
+	setup()
+	defer teardown()
+
+	groups := AuthorizationServerClaim{
+		Name:            "groups",
+		Status:          "ACTIVE",
+		ClaimType:       ClaimTypeResource,
+		ValueType:       ClaimValueTypeGroups,
+		Value:           "Everyone",
+		GroupFilterType: ClaimGroupFilterStartsWith,
+		Conditions:      &ClaimConditions{Scopes: []string{"profile"}},
+	}
+
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7/claims", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"id":"oclain6z9zIedDCxB0h7","name":"groups","status":"ACTIVE","claimType":"RESOURCE","valueType":"GROUPS","value":"Everyone","group_filter_type":"STARTS_WITH","conditions":{"scopes":["profile"]}}`)
+	})
+
+	claim, _, err := client.AuthorizationServers.CreateClaim("ausain6z9zIedDCxB0h7", groups)
+	if err != nil {
+		t.Errorf("AuthorizationServers.CreateClaim returned error: %v", err)
+	}
+	want := groups
+	want.ID = "oclain6z9zIedDCxB0h7"
+	if !reflect.DeepEqual(*claim, want) {
+		t.Errorf("client.AuthorizationServers.CreateClaim returned \n\t%+v, want \n\t%+v\n", claim, want)
+	}
+
+	invalid := []AuthorizationServerClaim{
+		{Name: "groups", ValueType: ClaimValueTypeGroups, Value: "Everyone"},
+		{Name: "email", ValueType: ClaimValueTypeExpression},
+	}
+	for _, c := range invalid {
+		if _, _, err := client.AuthorizationServers.CreateClaim("ausain6z9zIedDCxB0h7", c); err == nil {
+			t.Errorf("AuthorizationServers.CreateClaim expected an error for %+v", c)
+		}
+	}
+}
+
+func TestCreateAuthorizationServerPolicyRule(t *testing.T) {
+	setup()
+	defer teardown()
+
+	rule := AuthorizationServerPolicyRule{Name: "Default Policy Rule", Priority: 1, Conditions: &AuthorizationServerPolicyRuleConditions{
+		People:     &RulePeopleCondition{Groups: &IncludeExclude{Include: []string{"EVERYONE"}}},
+		GrantTypes: &IncludeExclude{Include: []string{GrantTypeAuthorizationCode, GrantTypeRefreshToken}},
+		Scopes:     &IncludeExclude{Include: []string{"*"}},
+	}}
+	rule.Actions = &AuthorizationServerPolicyRuleActions{}
+	rule.Actions.Token.AccessTokenLifetimeMinutes = 60
+	rule.Actions.Token.RefreshTokenWindowMinutes = 10080
+
+	sent := rule
+	sent.Type = AuthorizationServerPolicyRuleType
+
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7/policies/00palyaappA22DPkj0h7/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, sent)
+		created := sent
+		created.ID = "0prbsjfyl01zfSZ9K0h7"
+		json.NewEncoder(w).Encode(created)
+	})
+
+	created, _, err := client.AuthorizationServers.CreatePolicyRule("ausain6z9zIedDCxB0h7", "00palyaappA22DPkj0h7", rule)
+	if err != nil {
+		t.Fatalf("AuthorizationServers.CreatePolicyRule returned error: %v", err)
+	}
+	if created.ID != "0prbsjfyl01zfSZ9K0h7" || created.Actions.Token.RefreshTokenWindowMinutes != 10080 || created.Conditions.People.Groups.Include[0] != "EVERYONE" {
+		t.Errorf("client.AuthorizationServers.CreatePolicyRule returned %+v", created)
+	}
+}
+
+func TestAuthorizationServerKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	keys := []JSONWebKey{
+		{Kid: "RQ8DuhdxCczyMvy7GNJb4Ka3lQ99vrSo3oFBUiZjzzc", Kty: "RSA", Alg: "RS256", Use: "sig", Status: "ACTIVE", E: "AQAB", N: "g0MirhrysJMPm_wK45jvMbbyanfhl-jmTBv0o69GeifPaISaXGv8LKn3-CyJvUJcjjeHAa5URSfTfuhLXZRaFjRm7AE7zOMnfQg"},
+		{Kid: "Y3vBOdYT-l-I0j-gRQ26XjutSX00TeWiSguuDhW3ngo", Kty: "RSA", Alg: "RS256", Use: "sig", Status: "NEXT", E: "AQAB", N: "l1hZ_g2sgBE3oHvu34T-5XP18FYJWgtul_nRNg-5xra5ySkaXEOJUDRERUG0HrR42uqf9jYrUTwg9fp-SqqNIdHRaN8EwRSDRsKAwK3HIJ2NJfgmrrO2ABkeyUq6rzHxAumiKv1iLFpSawSIiTEBJERtUCDcjbbqyHVFuivIFgH8L37-XDIDb0XG-R8DOoOHLJPTpsgH-rJeM5w96VIRZInsGC5OGWkFdtgk6OkbvVd7_TXcxLCpWeg1vlbmX-0TmG5yjSj7ek05txcpxIqYu-7FIGT0KKvXge_BOSEUlJpBhLKU28OtsOnmc3NLIGXB-GeDiUZiBYQdPR-myB4ZoQ"},
+	}
+
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7/credentials/keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		json.NewEncoder(w).Encode(keys)
+	})
+	mux.HandleFunc("/authorizationServers/ausain6z9zIedDCxB0h7/credentials/lifecycle/keyRotate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, map[string]string{"use": "sig"})
+		json.NewEncoder(w).Encode(keys[1:])
+	})
+
+	listed, _, err := client.AuthorizationServers.ListKeys("ausain6z9zIedDCxB0h7")
+	if err != nil {
+		t.Errorf("AuthorizationServers.ListKeys returned error: %v", err)
+	}
+	if !reflect.DeepEqual(listed, keys) {
+		t.Errorf("client.AuthorizationServers.ListKeys returned \n\t%+v, want \n\t%+v\n", listed, keys)
+	}
+
+	rotated, _, err := client.AuthorizationServers.RotateKeys("ausain6z9zIedDCxB0h7")
+	if err != nil {
+		t.Errorf("AuthorizationServers.RotateKeys returned error: %v", err)
+	}
+	if len(rotated) != 1 || rotated[0].Kid != keys[1].Kid {
+		t.Errorf("client.AuthorizationServers.RotateKeys returned %+v", rotated)
+	}
+}
@@ -0,0 +1,209 @@
+package okta
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchConcurrency = 4
+	defaultBatchMaxRetries  = 3
+	defaultBatchRetryWait   = 1 * time.Second
+)
+
+// BatchOperation is a single unit of work run by Client.Batch. It should make one API call
+// with the client it is handed and return that call's Response and error, for example:
+//
+//	func(c *Client) (*Response, error) { return c.Users.Deactivate(id) }
+type BatchOperation func(c *Client) (*Response, error)
+
+// BatchOptions controls how Client.Batch runs its operations
+type BatchOptions struct {
+	// Concurrency is the maximum number of operations in flight. It is reduced automatically as the
+	// X-Rate-Limit-Remaining header approaches the client's RateRemainingFloor. Defaults to 4
+	Concurrency int
+
+	// MaxRetries is the number of times an operation failing with a transient error
+	// (rate limited, 5xx or network error) is retried. Defaults to 3. Set to -1 to disable retries
+	MaxRetries int
+
+	// RetryWait is the base wait before a retry. It doubles with every attempt. Rate limited
+	// operations wait until the rate limit reset time instead. Defaults to 1 second
+	RetryWait time.Duration
+}
+
+// BatchResult is the outcome of a single BatchOperation
+type BatchResult struct {
+	// Index of the operation in the slice passed to Client.Batch
+	Index int
+	// Attempts is the number of times the operation was run
+	Attempts int
+	// OKTARequestID of the last attempt, if a response was received
+	OKTARequestID string
+	// Response of the last attempt
+	Response *Response
+	// Err of the last attempt. nil if the operation succeeded
+	Err error
+}
+
+// BatchReport holds a BatchResult per operation, in the same order as the operations
+type BatchReport struct {
+	Results   []BatchResult
+	Succeeded int
+	Failed    int
+}
+
+// Errors returns the results of the operations that failed
+func (r *BatchReport) Errors() []BatchResult {
+	var failed []BatchResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Batch runs ops concurrently and returns a per operation report. Concurrency adapts to the rate
+// limit values parsed from the most recent API response: as Remaining approaches the client's
+// RateRemainingFloor fewer operations are run at once, down to one at a time. Transient failures
+// are retried with backoff according to opt. A nil opt uses the defaults.
+func (c *Client) Batch(ops []BatchOperation, opt *BatchOptions) *BatchReport {
+	concurrency, maxRetries, retryWait := defaultBatchConcurrency, defaultBatchMaxRetries, defaultBatchRetryWait
+	if opt != nil {
+		if opt.Concurrency > 0 {
+			concurrency = opt.Concurrency
+		}
+		if opt.MaxRetries > 0 {
+			maxRetries = opt.MaxRetries
+		} else if opt.MaxRetries < 0 {
+			maxRetries = 0
+		}
+		if opt.RetryWait > 0 {
+			retryWait = opt.RetryWait
+		}
+	}
+
+	report := &BatchReport{Results: make([]BatchResult, len(ops))}
+	limiter := &batchLimiter{client: c, max: concurrency}
+	limiter.cond = sync.NewCond(&limiter.mu)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				report.Results[index] = c.runBatchOperation(index, ops[index], limiter, maxRetries, retryWait)
+			}
+		}()
+	}
+	for i := range ops {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+	}
+	return report
+}
+
+// runBatchOperation runs a single operation, retrying transient failures
+func (c *Client) runBatchOperation(index int, op BatchOperation, limiter *batchLimiter, maxRetries int, retryWait time.Duration) BatchResult {
+	result := BatchResult{Index: index}
+	for {
+		limiter.acquire()
+		resp, err := op(c)
+		limiter.release()
+
+		result.Attempts++
+		result.Response = resp
+		result.Err = err
+		if resp != nil {
+			result.OKTARequestID = resp.OKTARequestID
+		}
+		if err == nil || result.Attempts > maxRetries || !isTransientError(resp, err) {
+			return result
+		}
+		<-time.After(batchRetryDelay(err, result.Attempts, retryWait))
+	}
+}
+
+// isTransientError reports whether a failed call is worth retrying
+func isTransientError(resp *Response, err error) bool {
+	switch err.(type) {
+	case *RateLimitError:
+		return true
+	case net.Error:
+		return true
+	}
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
+
+// batchRetryDelay returns how long to wait before the next attempt. Rate limited calls
+// wait for the rate limit to reset, everything else backs off exponentially
+func batchRetryDelay(err error, attempt int, retryWait time.Duration) time.Duration {
+	if rateErr, ok := err.(*RateLimitError); ok && !rateErr.Rate.ResetTime.IsZero() {
+		if wait := time.Until(rateErr.Rate.ResetTime); wait > 0 {
+			return wait
+		}
+	}
+	return retryWait * time.Duration(1<<uint(attempt-1))
+}
+
+// batchLimiter bounds the number of batch operations in flight. The bound shrinks linearly
+// from max to one as the client's remaining rate limit drops towards RateRemainingFloor
+type batchLimiter struct {
+	client *Client
+	max    int
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+}
+
+func (l *batchLimiter) acquire() {
+	l.mu.Lock()
+	for l.active >= l.allowed() {
+		l.cond.Wait()
+	}
+	l.active++
+	l.mu.Unlock()
+}
+
+func (l *batchLimiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+// allowed returns the current concurrency bound based on the most recent rate limit headers
+func (l *batchLimiter) allowed() int {
+	l.client.rateMu.Lock()
+	rate := l.client.mostRecentRate
+	l.client.rateMu.Unlock()
+
+	floor := l.client.RateRemainingFloor
+	// no rate limit seen yet or the window has reset
+	if rate.ResetTime.IsZero() || time.Now().After(rate.ResetTime) || rate.RatePerMinuteLimit <= floor {
+		return l.max
+	}
+	allowed := l.max * (rate.Remaining - floor) / (rate.RatePerMinuteLimit - floor)
+	if allowed < 1 {
+		return 1
+	}
+	if allowed > l.max {
+		return l.max
+	}
+	return allowed
+}
@@ -0,0 +1,121 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatchRetriesTransientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		mu.Lock()
+		calls[r.URL.Path]++
+		n := calls[r.URL.Path]
+		mu.Unlock()
+
+		w.Header().Set(headerOKTARequestID, fmt.Sprintf("req-%v-%v", r.URL.Path, n))
+		switch r.URL.Path {
+		case "/users/00u2/lifecycle/deactivate":
+			// fail once with a transient error
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/users/00u3/lifecycle/deactivate":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errorCode":"E0000007","errorSummary":"Not found: Resource not found: 00u3 (User)"}`)
+			return
+		}
+		fmt.Fprint(w, "")
+	})
+
+	var ops []BatchOperation
+	for _, id := range []string{"00u1", "00u2", "00u3"} {
+		id := id
+		ops = append(ops, func(c *Client) (*Response, error) {
+			return c.Users.Deactivate(id)
+		})
+	}
+
+	report := client.Batch(ops, &BatchOptions{Concurrency: 2, RetryWait: time.Millisecond})
+
+	if report.Succeeded != 2 || report.Failed != 1 {
+		t.Errorf("client.Batch returned %v succeeded and %v failed, want 2 and 1", report.Succeeded, report.Failed)
+	}
+	if got := report.Results[1].Attempts; got != 2 {
+		t.Errorf("client.Batch retried transient error %v times, want 2 attempts", got)
+	}
+	if got := report.Results[1].OKTARequestID; got != "req-/users/00u2/lifecycle/deactivate-2" {
+		t.Errorf("client.Batch returned request ID %v", got)
+	}
+	failed := report.Errors()
+	if len(failed) != 1 || failed[0].Index != 2 {
+		t.Fatalf("client.Batch Errors returned %+v, want the third operation", failed)
+	}
+	if failed[0].Attempts != 1 {
+		t.Errorf("client.Batch retried a non transient error %v times", failed[0].Attempts)
+	}
+}
+
+func TestBatchSlowsDownNearRateLimitFloor(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	mux.HandleFunc("/groups/00g1/users/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		// one request left above the client's floor
+		w.Header().Add(headerRateLimit, "600")
+		w.Header().Add(headerRateRemaining, strconv.Itoa(client.RateRemainingFloor+1))
+		w.Header().Add(headerRateReset, strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	op := func(c *Client) (*Response, error) {
+		req, err := c.NewRequest("PUT", "groups/00g1/users/00u1", nil)
+		if err != nil {
+			return nil, err
+		}
+		return c.Do(req, nil)
+	}
+
+	// prime the client with the rate limit headers
+	if report := client.Batch([]BatchOperation{op}, nil); report.Failed != 0 {
+		t.Fatalf("client.Batch returned errors %+v", report.Errors())
+	}
+
+	mu.Lock()
+	maxInFlight = 0
+	mu.Unlock()
+
+	report := client.Batch([]BatchOperation{op, op, op, op, op, op}, &BatchOptions{Concurrency: 6})
+	if report.Failed != 0 {
+		t.Errorf("client.Batch returned errors %+v", report.Errors())
+	}
+	if maxInFlight != 1 {
+		t.Errorf("client.Batch ran %v operations at once near the rate limit floor, want 1", maxInFlight)
+	}
+}
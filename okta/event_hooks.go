@@ -0,0 +1,285 @@
+package okta
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// EventHookEventsType is the type of the events an event hook subscribes to
+	EventHookEventsType = "EVENT_TYPE"
+	// EventHookChannelTypeHTTP is the type of the channel an event hook is delivered on
+	EventHookChannelTypeHTTP = "HTTP"
+	// EventHookChannelVersion is the version of the event hook channel
+	EventHookChannelVersion = "1.0.0"
+	// EventHookAuthSchemeHeader - OKTA authenticates deliveries with a secret in a header
+	EventHookAuthSchemeHeader = "HEADER"
+
+	// EventHookVerificationHeader is the header of the one-time verification request holding the challenge
+	EventHookVerificationHeader = "X-Okta-Verification-Challenge"
+	// EventHookAllEvents registers an EventHookFunc called for every event type
+	EventHookAllEvents = "*"
+	// EventHookMaxDeliveryBytes is the largest delivery body an EventHookHandler reads
+	EventHookMaxDeliveryBytes = 1 << 20
+)
+
+// EventHooksService handles communication with the Event Hooks related
+// methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/event-hooks/
+type EventHooksService service
+
+// EventHook is an endpoint OKTA delivers events of the subscribed types to
+type EventHook struct {
+	ID                 string            `json:"id,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	VerificationStatus string            `json:"verificationStatus,omitempty"`
+	Name               string            `json:"name,omitempty"`
+	Created            *time.Time        `json:"created,omitempty"`
+	CreatedBy          string            `json:"createdBy,omitempty"`
+	LastUpdated        *time.Time        `json:"lastUpdated,omitempty"`
+	Events             *EventHookEvents  `json:"events,omitempty"`
+	Channel            *EventHookChannel `json:"channel,omitempty"`
+}
+
+// EventHookEvents are the event types an EventHook subscribes to
+type EventHookEvents struct {
+	Type  string   `json:"type,omitempty"`
+	Items []string `json:"items,omitempty"`
+}
+
+// EventHookChannel is how an EventHook is delivered
+type EventHookChannel struct {
+	Type    string                  `json:"type,omitempty"`
+	Version string                  `json:"version,omitempty"`
+	Config  *EventHookChannelConfig `json:"config,omitempty"`
+}
+
+// EventHookChannelConfig is the endpoint and headers of an EventHook. The Value of AuthScheme is never returned by OKTA
+type EventHookChannelConfig struct {
+	URI        string               `json:"uri,omitempty"`
+	Headers    []EventHookHeader    `json:"headers,omitempty"`
+	AuthScheme *EventHookAuthScheme `json:"authScheme,omitempty"`
+}
+
+// EventHookHeader is a header sent with every delivery of an EventHook
+type EventHookHeader struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// EventHookAuthScheme is the header and shared secret deliveries of an EventHook are authenticated with
+type EventHookAuthScheme struct {
+	Type  string `json:"type,omitempty"`
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// NewEventHook returns an EventHook delivering the events of eventTypes to uri, authenticated with secret in
+// the authHeader header
+func NewEventHook(name string, uri string, authHeader string, secret string, eventTypes ...string) EventHook {
+	return EventHook{
+		Name:   name,
+		Events: &EventHookEvents{Type: EventHookEventsType, Items: eventTypes},
+		Channel: &EventHookChannel{
+			Type:    EventHookChannelTypeHTTP,
+			Version: EventHookChannelVersion,
+			Config: &EventHookChannelConfig{
+				URI:        uri,
+				AuthScheme: &EventHookAuthScheme{Type: EventHookAuthSchemeHeader, Key: authHeader, Value: secret},
+			},
+		},
+	}
+}
+
+// ListEventHooks: List all Event Hooks
+func (p *EventHooksService) ListEventHooks() ([]EventHook, *Response, error) {
+	var hooks []EventHook
+	resp, err := p.client.do("GET", "eventHooks", nil, &hooks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hooks, resp, err
+}
+
+// GetEventHook: Get an Event Hook
+// Requires EventHook ID from EventHook object
+func (p *EventHooksService) GetEventHook(id string) (*EventHook, *Response, error) {
+	u := fmt.Sprintf("eventHooks/%v", id)
+	return p.doHook("GET", u, nil)
+}
+
+// CreateEventHook: Create an Event Hook. OKTA does not deliver events until the hook is verified with VerifyEventHook
+func (p *EventHooksService) CreateEventHook(hook EventHook) (*EventHook, *Response, error) {
+	return p.doHook("POST", "eventHooks", hook)
+}
+
+// UpdateEventHook: Replace an Event Hook
+// Requires EventHook ID from EventHook object
+func (p *EventHooksService) UpdateEventHook(id string, hook EventHook) (*EventHook, *Response, error) {
+	u := fmt.Sprintf("eventHooks/%v", id)
+	return p.doHook("PUT", u, hook)
+}
+
+// ActivateEventHook: Activate/Deactivate an Event Hook
+// Requires EventHook ID from EventHook object and a boolean to activate or deactivate
+func (p *EventHooksService) ActivateEventHook(id string, activate bool) (*EventHook, *Response, error) {
+	u := fmt.Sprintf("eventHooks/%v/lifecycle/%v", id, lifecycleAction(activate))
+	return p.doHook("POST", u, nil)
+}
+
+// VerifyEventHook: Have OKTA send the one-time verification request to the endpoint of an Event Hook
+// Requires EventHook ID from EventHook object
+func (p *EventHooksService) VerifyEventHook(id string) (*EventHook, *Response, error) {
+	u := fmt.Sprintf("eventHooks/%v/lifecycle/verify", id)
+	return p.doHook("POST", u, nil)
+}
+
+// DeleteEventHook: Delete an Event Hook. Only inactive hooks can be deleted
+// Requires EventHook ID from EventHook object
+func (p *EventHooksService) DeleteEventHook(id string) (*Response, error) {
+	u := fmt.Sprintf("eventHooks/%v", id)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+func (p *EventHooksService) doHook(method string, u string, body interface{}) (*EventHook, *Response, error) {
+	hook := new(EventHook)
+	resp, err := p.client.do(method, u, body, hook)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hook, resp, err
+}
+
+// EventHookDelivery is a batch of events OKTA posts to an event hook endpoint
+type EventHookDelivery struct {
+	EventType          string     `json:"eventType,omitempty"`
+	EventTypeVersion   string     `json:"eventTypeVersion,omitempty"`
+	CloudEventsVersion string     `json:"cloudEventsVersion,omitempty"`
+	Source             string     `json:"source,omitempty"`
+	EventID            string     `json:"eventId,omitempty"`
+	EventTime          *time.Time `json:"eventTime,omitempty"`
+	ContentType        string     `json:"contentType,omitempty"`
+	Data               struct {
+		Events []LogEvent `json:"events"`
+	} `json:"data"`
+}
+
+// EventHookFunc handles an event delivered to an EventHookHandler. A returned error fails the delivery,
+// which OKTA retries once
+type EventHookFunc func(event LogEvent) error
+
+// EventHookHandler is an http.Handler receiving the deliveries of an event hook. It answers the one-time
+// verification request, rejects requests without the shared secret in the auth scheme header and calls the
+// EventHookFunc registered for the type of every delivered event. Without a Secret every request is rejected,
+// unless AllowUnauthenticated is set for a hook that has no auth scheme
+type EventHookHandler struct {
+	AuthHeader           string
+	Secret               string
+	AllowUnauthenticated bool
+
+	mu    sync.RWMutex
+	funcs map[string][]EventHookFunc
+}
+
+// NewEventHookHandler returns an EventHookHandler authenticating deliveries with secret in the authHeader header,
+// the Key and Value of the EventHookAuthScheme of the hook. A handler with an empty secret rejects every request,
+// set AllowUnauthenticated to accept them
+func NewEventHookHandler(authHeader string, secret string) *EventHookHandler {
+	return &EventHookHandler{AuthHeader: authHeader, Secret: secret, funcs: make(map[string][]EventHookFunc)}
+}
+
+// Handle registers fn for the events of eventType, for example "user.lifecycle.create", or of every type
+// with EventHookAllEvents
+func (h *EventHookHandler) Handle(eventType string, fn EventHookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.funcs == nil {
+		h.funcs = make(map[string][]EventHookFunc)
+	}
+	h.funcs[eventType] = append(h.funcs[eventType], fn)
+}
+
+func (h *EventHookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authenticated(r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		challenge := r.Header.Get(EventHookVerificationHeader)
+		if challenge == "" {
+			http.Error(w, "missing "+EventHookVerificationHeader+" header", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeJSON)
+		json.NewEncoder(w).Encode(map[string]string{"verification": challenge})
+	case "POST":
+		var delivery EventHookDelivery
+		body := http.MaxBytesReader(w, r.Body, EventHookMaxDeliveryBytes)
+		if err := json.NewDecoder(body).Decode(&delivery); err != nil {
+			http.Error(w, fmt.Sprintf("invalid event hook delivery: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := h.dispatch(delivery.Data.Events); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// authenticated reports if r holds the secret of the handler. Requests to a handler without a secret are only
+// authenticated with AllowUnauthenticated
+func (h *EventHookHandler) authenticated(r *http.Request) bool {
+	if h.Secret == "" {
+		return h.AllowUnauthenticated
+	}
+	return hookAuthenticated(r, h.AuthHeader, h.Secret)
+}
+
+// hookAuthenticated reports if r holds secret in header, compared in constant time. The header defaults
+// to Authorization. It must only be called with a non-empty secret, handlers decide themselves whether a hook
+// without a secret accepts requests. An empty secret authenticates no request
+func hookAuthenticated(r *http.Request, header string, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	if header == "" {
+		header = headerAuthorization
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get(header)), []byte(secret)) == 1
+}
+
+// dispatch calls the EventHookFuncs of every event, returning the first error after all events are handled.
+// The funcs are called without holding the lock, so they can register other funcs with Handle
+func (h *EventHookHandler) dispatch(events []LogEvent) error {
+	var firstErr error
+	for _, event := range events {
+		for _, fn := range h.funcsFor(event.EventType) {
+			if err := fn(event); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("[ERROR] event %v (%v): %v", event.UUID, event.EventType, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// funcsFor returns a copy of the EventHookFuncs registered for eventType and for every event type
+func (h *EventHookHandler) funcsFor(eventType string) []EventHookFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var funcs []EventHookFunc
+	funcs = append(funcs, h.funcs[eventType]...)
+	return append(funcs, h.funcs[EventHookAllEvents]...)
+}
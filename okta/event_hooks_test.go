@@ -0,0 +1,289 @@
+package okta
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testEventHookDelivery = `{
+	"eventType": "com.okta.event_hook",
+	"eventTypeVersion": "1.0",
+	"cloudEventsVersion": "0.1",
+	"source": "https://your-domain.okta.com/api/v1/eventHooks/whoql0HfiLGPWc8Jx0g3",
+	"eventId": "b5a188b9-5ece-4636-b041-482ffda96311",
+	"eventTime": "2019-03-27T16:59:53.032Z",
+	"contentType": "application/json",
+	"data": {"events": [{
+		"uuid": "4e1ba6b5-50b5-11e9-8d3b-77b45d38f4c2",
+		"published": "2019-03-27T16:59:52.858Z",
+		"eventType": "user.lifecycle.create",
+		"version": "0",
+		"displayMessage": "Create okta user",
+		"severity": "INFO",
+		"actor": {"id": "00uonuvh1SDhZbsp10g3", "type": "User", "alternateId": "admin@example.com", "displayName": "Admin"},
+		"client": {"userAgent": {"rawUserAgent": "Mozilla/5.0", "os": "Mac OS X", "browser": "CHROME"}, "zone": "null", "ipAddress": "192.0.2.1", "geographicalContext": {"city": "San Francisco", "country": "United States", "geolocation": {"lat": 37.7749, "lon": -122.4194}}},
+		"outcome": {"result": "SUCCESS"},
+		"target": [{"id": "00u1lm1ZJYpD9NFR40g4", "type": "User", "alternateId": "john.doe@example.com", "displayName": "John Doe"}],
+		"transaction": {"type": "WEB", "id": "XJurN0Z8GmT1WkWl5CjVPwAAAaA"},
+		"debugContext": {"debugData": {"requestUri": "/api/v1/users"}},
+		"authenticationContext": {"authenticationStep": 0, "externalSessionId": "102-ps3TGJTSTmGQ5h8JOjLUw"},
+		"securityContext": {"isp": "Example ISP"},
+		"request": {"ipChain": [{"ip": "192.0.2.1", "version": "V4"}]}
+	}, {
+		"uuid": "4e1ba6b6-50b5-11e9-8d3b-77b45d38f4c2",
+		"eventType": "user.session.start",
+		"outcome": {"result": "FAILURE", "reason": "INVALID_CREDENTIALS"}
+	}]}
+}`
+
+func TestEventHookLifecycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hook := NewEventHook("Event Hook Test", "https://example.com/okta/events", "Authorization", "my-shared-secret", "user.lifecycle.create", "user.lifecycle.delete.initiated")
+	want := hook
+	want.ID = "whoql0HfiLGPWc8Jx0g3"
+	want.Status = "ACTIVE"
+	want.VerificationStatus = "UNVERIFIED"
+
+	mux.HandleFunc("/eventHooks", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		switch r.Method {
+		case "POST":
+			testBody(t, r, hook)
+			json.NewEncoder(w).Encode(want)
+		default:
+			testMethod(t, r, "GET")
+			json.NewEncoder(w).Encode([]EventHook{want})
+		}
+	})
+	mux.HandleFunc("/eventHooks/whoql0HfiLGPWc8Jx0g3/lifecycle/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		verified := want
+		verified.VerificationStatus = "VERIFIED"
+		json.NewEncoder(w).Encode(verified)
+	})
+	mux.HandleFunc("/eventHooks/whoql0HfiLGPWc8Jx0g3/lifecycle/deactivate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		inactive := want
+		inactive.Status = "INACTIVE"
+		json.NewEncoder(w).Encode(inactive)
+	})
+	mux.HandleFunc("/eventHooks/whoql0HfiLGPWc8Jx0g3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	created, _, err := client.EventHooks.CreateEventHook(hook)
+	if err != nil {
+		t.Fatalf("EventHooks.CreateEventHook returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*created, want) {
+		t.Errorf("client.EventHooks.CreateEventHook returned \n\t%+v, want \n\t%+v\n", created, want)
+	}
+
+	hooks, _, err := client.EventHooks.ListEventHooks()
+	if err != nil {
+		t.Errorf("EventHooks.ListEventHooks returned error: %v", err)
+	}
+	if len(hooks) != 1 || hooks[0].Channel.Config.URI != "https://example.com/okta/events" {
+		t.Errorf("client.EventHooks.ListEventHooks returned %+v", hooks)
+	}
+
+	verified, _, err := client.EventHooks.VerifyEventHook(created.ID)
+	if err != nil {
+		t.Errorf("EventHooks.VerifyEventHook returned error: %v", err)
+	}
+	if verified.VerificationStatus != "VERIFIED" {
+		t.Errorf("client.EventHooks.VerifyEventHook returned %+v", verified)
+	}
+
+	inactive, _, err := client.EventHooks.ActivateEventHook(created.ID, false)
+	if err != nil {
+		t.Errorf("EventHooks.ActivateEventHook returned error: %v", err)
+	}
+	if inactive.Status != "INACTIVE" {
+		t.Errorf("client.EventHooks.ActivateEventHook returned %+v", inactive)
+	}
+
+	if _, err := client.EventHooks.DeleteEventHook(created.ID); err != nil {
+		t.Errorf("EventHooks.DeleteEventHook returned error: %v", err)
+	}
+}
+
+func TestEventHookHandlerVerification(t *testing.T) {
+	handler := NewEventHookHandler("Authorization", "my-shared-secret")
+
+	r := httptest.NewRequest("GET", "/okta/events", nil)
+	r.Header.Set("Authorization", "my-shared-secret")
+	r.Header.Set(EventHookVerificationHeader, "X5G_fmBDFXUKKm7g1Vm6")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"verification":"X5G_fmBDFXUKKm7g1Vm6"}` {
+		t.Errorf("EventHookHandler verification returned %v %v", w.Code, w.Body.String())
+	}
+
+	r.Header.Set("Authorization", "wrong-secret")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("EventHookHandler verification with a wrong secret returned %v, want %v", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestEventHookHandlerDispatch(t *testing.T) {
+	handler := NewEventHookHandler("X-Hook-Secret", "my-shared-secret")
+
+	var created []LogEvent
+	var all []string
+	handler.Handle("user.lifecycle.create", func(event LogEvent) error {
+		created = append(created, event)
+		return nil
+	})
+	handler.Handle(EventHookAllEvents, func(event LogEvent) error {
+		all = append(all, event.EventType)
+		return nil
+	})
+
+	deliver := func(secret string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/okta/events", strings.NewReader(testEventHookDelivery))
+		r.Header.Set("X-Hook-Secret", secret)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := deliver("wrong-secret"); w.Code != http.StatusUnauthorized || len(all) != 0 {
+		t.Errorf("EventHookHandler delivery with a wrong secret returned %v and dispatched %v", w.Code, all)
+	}
+
+	if w := deliver("my-shared-secret"); w.Code != http.StatusOK {
+		t.Fatalf("EventHookHandler delivery returned %v %v", w.Code, w.Body.String())
+	}
+	if want := []string{"user.lifecycle.create", "user.session.start"}; !reflect.DeepEqual(all, want) {
+		t.Errorf("EventHookHandler dispatched %v, want %v", all, want)
+	}
+	if len(created) != 1 {
+		t.Fatalf("EventHookHandler dispatched %v user.lifecycle.create events, want 1", len(created))
+	}
+
+	event := created[0]
+	if event.Actor.AlternateID != "admin@example.com" || event.Target[0].DisplayName != "John Doe" || event.Outcome.Result != "SUCCESS" {
+		t.Errorf("EventHookHandler decoded %+v", event)
+	}
+	if event.Client.GeographicalContext.Geolocation.Lat != 37.7749 || event.Request.IPChain[0].IP != "192.0.2.1" || event.DebugContext.DebugData["requestUri"] != "/api/v1/users" {
+		t.Errorf("EventHookHandler decoded client %+v request %+v", event.Client, event.Request)
+	}
+
+	handler.Handle("user.session.start", func(event LogEvent) error {
+		return errors.New("unavailable")
+	})
+	if w := deliver("my-shared-secret"); w.Code != http.StatusInternalServerError {
+		t.Errorf("EventHookHandler delivery with a failing EventHookFunc returned %v, want %v", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestEventHookHandlerInvalidDelivery(t *testing.T) {
+	handler := NewEventHookHandler("", "my-shared-secret")
+
+	for method, want := range map[string]int{"POST": http.StatusBadRequest, "GET": http.StatusBadRequest, "PUT": http.StatusMethodNotAllowed} {
+		r := httptest.NewRequest(method, "/okta/events", strings.NewReader(`{"data":`))
+		r.Header.Set("Authorization", "my-shared-secret")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != want {
+			t.Errorf("EventHookHandler %v returned %v, want %v", method, w.Code, want)
+		}
+	}
+}
+
+func TestEventHookHandlerWithoutSecret(t *testing.T) {
+	handler := NewEventHookHandler("", "")
+	var dispatched int
+	handler.Handle(EventHookAllEvents, func(event LogEvent) error {
+		dispatched++
+		return nil
+	})
+
+	deliver := func() int {
+		r := httptest.NewRequest("POST", "/okta/events", strings.NewReader(testEventHookDelivery))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := deliver(); code != http.StatusUnauthorized || dispatched != 0 {
+		t.Errorf("EventHookHandler without secret returned %v and dispatched %v events, want %v", code, dispatched, http.StatusUnauthorized)
+	}
+	handler.AllowUnauthenticated = true
+	if code := deliver(); code != http.StatusOK || dispatched != 2 {
+		t.Errorf("EventHookHandler with AllowUnauthenticated returned %v and dispatched %v events", code, dispatched)
+	}
+}
+
+func TestEventHookHandlerReentrantHandle(t *testing.T) {
+	handler := NewEventHookHandler("", "my-shared-secret")
+	var registered int
+	handler.Handle("user.lifecycle.create", func(event LogEvent) error {
+		handler.Handle("user.lifecycle.deactivate", func(event LogEvent) error { return nil })
+		registered++
+		return nil
+	})
+
+	done := make(chan int, 1)
+	go func() {
+		r := httptest.NewRequest("POST", "/okta/events", strings.NewReader(testEventHookDelivery))
+		r.Header.Set("Authorization", "my-shared-secret")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		done <- w.Code
+	}()
+
+	select {
+	case code := <-done:
+		if code != http.StatusOK || registered != 1 {
+			t.Errorf("EventHookHandler delivery returned %v and called Handle %v times", code, registered)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("EventHookHandler deadlocked calling Handle from an EventHookFunc")
+	}
+}
+
+func TestEventHookHandlerLargeDelivery(t *testing.T) {
+	handler := NewEventHookHandler("", "my-shared-secret")
+	var dispatched int
+	handler.Handle(EventHookAllEvents, func(event LogEvent) error {
+		dispatched++
+		return nil
+	})
+
+	padding := strings.Repeat(" ", EventHookMaxDeliveryBytes)
+	r := httptest.NewRequest("POST", "/okta/events", strings.NewReader(padding+testEventHookDelivery))
+	r.Header.Set("Authorization", "my-shared-secret")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest || dispatched != 0 {
+		t.Errorf("EventHookHandler delivery larger than %v bytes returned %v and dispatched %v events", EventHookMaxDeliveryBytes, w.Code, dispatched)
+	}
+}
+
+func TestHookAuthenticatedEmptySecret(t *testing.T) {
+	r := httptest.NewRequest("POST", "/okta/hook", nil)
+	if hookAuthenticated(r, "", "") {
+		t.Errorf("hookAuthenticated with an empty secret authenticated a request without the header")
+	}
+	r.Header.Set("Authorization", "my-shared-secret")
+	if !hookAuthenticated(r, "", "my-shared-secret") {
+		t.Errorf("hookAuthenticated rejected a request with the secret")
+	}
+}
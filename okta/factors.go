@@ -5,4 +5,49 @@ const (
 	MFAStatusActive = "ACTIVE"
 	// MFAStatusPending is a user MFA Status of NOT Active
 	MFAStatusPending = "PENDING_ACTIVATION"
+
+	// FactorTypeSMS is the factorType of an SMS factor
+	FactorTypeSMS = "sms"
+	// FactorTypeCall is the factorType of a voice call factor
+	FactorTypeCall = "call"
+	// FactorTypeEmail is the factorType of an email factor
+	FactorTypeEmail = "email"
+	// FactorTypeQuestion is the factorType of a security question factor
+	FactorTypeQuestion = "question"
+	// FactorTypeTOTP is the factorType of a software TOTP factor (Okta Verify or Google Authenticator)
+	FactorTypeTOTP = "token:software:totp"
+	// FactorTypePush is the factorType of an Okta Verify push factor
+	FactorTypePush = "push"
+	// FactorTypeWebAuthn is the factorType of a WebAuthn factor
+	FactorTypeWebAuthn = "webauthn"
+	// FactorTypeU2F is the factorType of a U2F factor
+	FactorTypeU2F = "u2f"
+	// FactorTypeToken is the factorType of a token factor (RSA SecurID or Symantec VIP)
+	FactorTypeToken = "token"
+	// FactorTypeHardwareToken is the factorType of a hardware token factor (YubiKey)
+	FactorTypeHardwareToken = "token:hardware"
+
+	// FactorProviderOkta is the provider of the Okta factors
+	FactorProviderOkta = "OKTA"
+	// FactorProviderGoogle is the provider of the Google Authenticator factor
+	FactorProviderGoogle = "GOOGLE"
+	// FactorProviderRSA is the provider of the RSA SecurID factor
+	FactorProviderRSA = "RSA"
+	// FactorProviderSymantec is the provider of the Symantec VIP factor
+	FactorProviderSymantec = "SYMANTEC"
+	// FactorProviderYubico is the provider of the YubiKey factor
+	FactorProviderYubico = "YUBICO"
+	// FactorProviderFido is the provider of the WebAuthn and U2F factors
+	FactorProviderFido = "FIDO"
+
+	// FactorResultSuccess - the factor was verified
+	FactorResultSuccess = "SUCCESS"
+	// FactorResultWaiting - verification is pending, for example a push that has not been answered yet
+	FactorResultWaiting = "WAITING"
+	// FactorResultChallenge - a challenge was sent (sms, call, email) and a passCode is expected
+	FactorResultChallenge = "CHALLENGE"
+	// FactorResultRejected - the user rejected the push verification
+	FactorResultRejected = "REJECTED"
+	// FactorResultTimeout - the verification expired before it was answered
+	FactorResultTimeout = "TIMEOUT"
 )
@@ -0,0 +1,264 @@
+package okta
+
+import (
+	"fmt"
+)
+
+const (
+	// PolicyTypeIdpDiscovery is the type of the IdP discovery policy, which routes users to an Identity Provider
+	// when they sign in. Every org has exactly one
+	PolicyTypeIdpDiscovery = "IDP_DISCOVERY"
+
+	// UserIdentifierTypeIdentifier matches the patterns against the username the user signs in with
+	UserIdentifierTypeIdentifier = "IDENTIFIER"
+	// UserIdentifierTypeAttribute matches the patterns against an attribute of the profile of the user
+	UserIdentifierTypeAttribute = "ATTRIBUTE"
+
+	// PatternMatchSuffix matches a value ending with the pattern, like the domain of an email address
+	PatternMatchSuffix = "SUFFIX"
+	// PatternMatchEquals matches a value equal to the pattern
+	PatternMatchEquals = "EQUALS"
+	// PatternMatchStartsWith matches a value starting with the pattern
+	PatternMatchStartsWith = "STARTS_WITH"
+	// PatternMatchContains matches a value containing the pattern
+	PatternMatchContains = "CONTAINS"
+	// PatternMatchExpression matches a value with the regular expression of the pattern
+	PatternMatchExpression = "EXPRESSION"
+
+	// IdpDiscoveryProviderOkta routes the users to the sign in of the org itself
+	IdpDiscoveryProviderOkta = "OKTA"
+)
+
+// UserIdentifier is the userIdentifier condition of an IDP_DISCOVERY rule, matched when one of the patterns
+// matches the username or the attribute of the user
+type UserIdentifier struct {
+	Type      string                  `json:"type,omitempty"`
+	Attribute string                  `json:"attribute,omitempty"`
+	Patterns  []UserIdentifierPattern `json:"patterns,omitempty"`
+}
+
+// UserIdentifierPattern is a pattern of a UserIdentifier condition
+type UserIdentifierPattern struct {
+	MatchType string `json:"matchType,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+// AppCondition is the app condition of an IDP_DISCOVERY rule, the apps the user signs in to
+// when creating an obj, Include & Exclude are exclusive
+type AppCondition struct {
+	Include []AppConditionApp `json:"include,omitempty"`
+	Exclude []AppConditionApp `json:"exclude,omitempty"`
+}
+
+// AppConditionApp is an app instance, by Type "APP" and ID, or all the apps of an app name, by Type "APP_TYPE"
+// and Name
+type AppConditionApp struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// IdpDiscoveryRule represents the Rule Object from the OKTA API
+// used to create or update an IdP discovery rule
+type IdpDiscoveryRule struct {
+	Type       string                   `json:"type,omitempty"`
+	Status     string                   `json:"status,omitempty"`
+	Name       string                   `json:"name,omitempty"`
+	Priority   int                      `json:"priority,omitempty"`
+	Conditions *PolicyConditions        `json:"conditions,omitempty"`
+	Actions    *IdpDiscoveryRuleActions `json:"actions,omitempty"`
+}
+
+// IdpDiscoveryRuleActions represents the actions of an IdP discovery rule
+type IdpDiscoveryRuleActions struct {
+	IDP *IdpDiscoveryAction `json:"idp,omitempty"`
+}
+
+// IdpDiscoveryAction redirects the users matched by a rule to the providers
+type IdpDiscoveryAction struct {
+	Providers []IdpDiscoveryProvider `json:"providers,omitempty"`
+}
+
+// IdpDiscoveryProvider is the OKTA org, by Type IdpDiscoveryProviderOkta, or an Identity Provider, by its Type
+// and ID
+type IdpDiscoveryProvider struct {
+	Type string `json:"type,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+// Return the IdpDiscoveryRule object. Used to create & update the IdP discovery rule
+func (p *PoliciesService) IdpDiscoveryRule() IdpDiscoveryRule {
+	return IdpDiscoveryRule{
+		Type:       PolicyTypeIdpDiscovery,
+		Conditions: &PolicyConditions{},
+	}
+}
+
+// IdpDiscoveryRule returns the rule as an IdpDiscoveryRule, to update a rule read with GetPolicyRule
+// without sending the actions of the other rule types
+func (r Rule) IdpDiscoveryRule() IdpDiscoveryRule {
+	rule := IdpDiscoveryRule{
+		Type:       r.Type,
+		Status:     r.Status,
+		Name:       r.Name,
+		Priority:   r.Priority,
+		Conditions: r.Conditions,
+	}
+	if r.Actions.IDP != nil {
+		rule.Actions = &IdpDiscoveryRuleActions{IDP: r.Actions.IDP}
+	}
+	return rule
+}
+
+// GetIdpDiscoveryPolicy: Get the IdP discovery policy of the org
+func (p *PoliciesService) GetIdpDiscoveryPolicy() (*Policy, *Response, error) {
+	policies, resp, err := p.GetPoliciesByType(PolicyTypeIdpDiscovery)
+	if err != nil {
+		return nil, resp, err
+	}
+	if policies == nil {
+		return nil, resp, fmt.Errorf("[ERROR] the org has no %v policy", PolicyTypeIdpDiscovery)
+	}
+	return &policies.Policies[0], resp, err
+}
+
+// ReorderIdpDiscoveryRules: Set the priorities of the rules of an IdP discovery policy to the order of ruleIDs,
+// from priority 1. The rules left out keep their order after them, the default rule stays last
+// Requires Policy ID from Policy object and Rule IDs from Rule objects
+func (p *PoliciesService) ReorderIdpDiscoveryRules(policyID string, ruleIDs []string) ([]Rule, *Response, error) {
+	current, resp, err := p.GetPolicyRules(policyID)
+	if err != nil {
+		return nil, resp, err
+	}
+	byID := make(map[string]Rule)
+	if current != nil {
+		for _, rule := range current.Rules {
+			byID[rule.ID] = rule
+		}
+	}
+
+	var reordered []Rule
+	for i, id := range ruleIDs {
+		rule, ok := byID[id]
+		switch {
+		case !ok:
+			return reordered, resp, fmt.Errorf("[ERROR] policy %v has no rule %v", policyID, id)
+		case rule.System:
+			return reordered, resp, fmt.Errorf("[ERROR] rule %v is the default rule, which has the lowest priority", id)
+		}
+		update := rule.IdpDiscoveryRule()
+		update.Priority = i + 1
+		var updated *Rule
+		updated, resp, err = p.UpdatePolicyRule(policyID, id, update)
+		if err != nil {
+			return reordered, resp, err
+		}
+		reordered = append(reordered, *updated)
+	}
+	return reordered, resp, err
+}
+
+// IdpDiscoveryRule DomainCondition routes the users signing in with a username of one of the email domains
+func (r *IdpDiscoveryRule) DomainCondition(domains ...string) error {
+	return r.UserIdentifierCondition(UserIdentifierTypeIdentifier, "", PatternMatchSuffix, domains...)
+}
+
+// IdpDiscoveryRule UserIdentifierCondition updates the userIdentifier condition for the input IdP discovery rule
+// requires inputs string UserIdentifierTypeIdentifier or UserIdentifierTypeAttribute, the profile attribute
+// of the user for UserIdentifierTypeAttribute, a PatternMatch type & the values of the patterns
+func (r *IdpDiscoveryRule) UserIdentifierCondition(identifierType string, attribute string, matchType string, values ...string) error {
+	switch {
+	case identifierType == UserIdentifierTypeIdentifier && attribute != "":
+		return fmt.Errorf("[ERROR] UserIdentifierCondition attribute is only supported by type %v", UserIdentifierTypeAttribute)
+	case identifierType == UserIdentifierTypeAttribute && attribute == "":
+		return fmt.Errorf("[ERROR] UserIdentifierCondition attribute is required by type %v", UserIdentifierTypeAttribute)
+	case identifierType != UserIdentifierTypeIdentifier && identifierType != UserIdentifierTypeAttribute:
+		return fmt.Errorf("[ERROR] UserIdentifierCondition input string var supports values %q or %q", UserIdentifierTypeIdentifier, UserIdentifierTypeAttribute)
+	}
+	switch matchType {
+	case PatternMatchSuffix, PatternMatchEquals, PatternMatchStartsWith, PatternMatchContains, PatternMatchExpression:
+	default:
+		return fmt.Errorf("[ERROR] UserIdentifierCondition match type %v is not supported", matchType)
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("[ERROR] UserIdentifierCondition requires at least one pattern")
+	}
+
+	condition := &UserIdentifier{Type: identifierType, Attribute: attribute}
+	for _, value := range values {
+		condition.Patterns = append(condition.Patterns, UserIdentifierPattern{MatchType: matchType, Value: value})
+	}
+	r.conditions().UserIdentifier = condition
+	return nil
+}
+
+// IdpDiscoveryRule AppCondition updates the app condition for the input IdP discovery rule
+// requires inputs string "include" or "exclude" & the apps
+func (r *IdpDiscoveryRule) AppCondition(clude string, apps ...AppConditionApp) error {
+	var condition *AppCondition
+	switch {
+	case clude == "include":
+		condition = &AppCondition{Include: apps}
+	case clude == "exclude":
+		condition = &AppCondition{Exclude: apps}
+	default:
+		return fmt.Errorf("[ERROR] AppCondition input string var supports values \"include\" or \"exclude\"")
+	}
+	r.conditions().App = condition
+	return nil
+}
+
+// IdpDiscoveryRule NetworkCondition updates the network condition for the input IdP discovery rule
+// requires inputs string connection "ANYWHERE" or "ZONE" and for "ZONE", "include" or "exclude"
+// plus a string slice of network zone IDs
+func (r *IdpDiscoveryRule) NetworkCondition(connection string, clude string, zones []string) error {
+	network := &Network{Connection: connection}
+	switch {
+	case connection == "ANYWHERE":
+	case connection == "ZONE" && clude == "include":
+		network.Include = zones
+	case connection == "ZONE" && clude == "exclude":
+		network.Exclude = zones
+	case connection == "ZONE":
+		return fmt.Errorf("[ERROR] NetworkCondition input string var supports values \"include\" or \"exclude\"")
+	default:
+		return fmt.Errorf("[ERROR] NetworkCondition connection supports values \"ANYWHERE\" or \"ZONE\"")
+	}
+	r.conditions().Network = network
+	return nil
+}
+
+// IdpDiscoveryRule PeopleCondition updates the People condition for the input IdP discovery rule
+// requires inputs string "users" or "groups & "include" or "exclude"
+// plus a string slice of Okta group or user IDs
+func (r *IdpDiscoveryRule) PeopleCondition(condition string, clude string, values []string) error {
+	pop, err := peopleCondition(condition, clude, values)
+	if err != nil {
+		return err
+	}
+	r.conditions().People = pop
+	return nil
+}
+
+// IdpDiscoveryRule RedirectToIdp routes the users matched by the rule to the Identity Provider of idpType and ID,
+// or to the org itself with IdpDiscoveryProviderOkta and no ID
+func (r *IdpDiscoveryRule) RedirectToIdp(idpType string, id string) error {
+	switch {
+	case idpType == "":
+		return fmt.Errorf("[ERROR] RedirectToIdp requires the type of the provider")
+	case idpType != IdpDiscoveryProviderOkta && id == "":
+		return fmt.Errorf("[ERROR] RedirectToIdp requires the ID of the %v Identity Provider", idpType)
+	}
+	r.Actions = &IdpDiscoveryRuleActions{IDP: &IdpDiscoveryAction{
+		Providers: []IdpDiscoveryProvider{{Type: idpType, ID: id}},
+	}}
+	return nil
+}
+
+// conditions (unexported) returns the conditions of the rule, created when nil
+func (r *IdpDiscoveryRule) conditions() *PolicyConditions {
+	if r.Conditions == nil {
+		r.Conditions = &PolicyConditions{}
+	}
+	return r.Conditions
+}
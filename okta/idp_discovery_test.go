@@ -0,0 +1,177 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIdpDiscoveryRuleCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	rule := client.Policies.IdpDiscoveryRule()
+	rule.Name = "Example IdP"
+	rule.Priority = 1
+	if err := rule.DomainCondition("example.com", "example.org"); err != nil {
+		t.Fatalf("IdpDiscoveryRule.DomainCondition returned error: %v", err)
+	}
+	if err := rule.AppCondition("include", AppConditionApp{Type: "APP", ID: "0oa7vfhs8fkY9yJvh0h7"}); err != nil {
+		t.Fatalf("IdpDiscoveryRule.AppCondition returned error: %v", err)
+	}
+	if err := rule.NetworkCondition("ZONE", "exclude", []string{"nzowdja2YRaQmOQYp0g3"}); err != nil {
+		t.Fatalf("IdpDiscoveryRule.NetworkCondition returned error: %v", err)
+	}
+	if err := rule.RedirectToIdp(IdpTypeSAML2, "0oa62bfdiumsUndnZ0h7"); err != nil {
+		t.Fatalf("IdpDiscoveryRule.RedirectToIdp returned error: %v", err)
+	}
+
+	ruleJSON := `{"type":"IDP_DISCOVERY","name":"Example IdP","priority":1,"conditions":{` +
+		`"network":{"connection":"ZONE","exclude":["nzowdja2YRaQmOQYp0g3"]},` +
+		`"userIdentifier":{"type":"IDENTIFIER","patterns":[{"matchType":"SUFFIX","value":"example.com"},{"matchType":"SUFFIX","value":"example.org"}]},` +
+		`"app":{"include":[{"type":"APP","id":"0oa7vfhs8fkY9yJvh0h7"}]}},` +
+		`"actions":{"idp":{"providers":[{"type":"SAML2","id":"0oa62bfdiumsUndnZ0h7"}]}}}`
+	mux.HandleFunc("/policies/00p5ikpfnuHmfuqKs0h7/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		if body, _ := ioutil.ReadAll(r.Body); strings.TrimSpace(string(body)) != ruleJSON {
+			t.Errorf("Request body: %s, want %v", body, ruleJSON)
+		}
+		fmt.Fprint(w, strings.Replace(ruleJSON, `{"type"`, `{"id":"0pr7vfjh3dgS6bxrj0h7","status":"ACTIVE","type"`, 1))
+	})
+
+	created, _, err := client.Policies.CreatePolicyRule("00p5ikpfnuHmfuqKs0h7", rule)
+	if err != nil {
+		t.Fatalf("Policies.CreatePolicyRule returned error: %v", err)
+	}
+	if created.ID != "0pr7vfjh3dgS6bxrj0h7" || created.Conditions.UserIdentifier.Patterns[1].Value != "example.org" ||
+		created.Conditions.App.Include[0].ID != "0oa7vfhs8fkY9yJvh0h7" || created.Actions.IDP.Providers[0].ID != "0oa62bfdiumsUndnZ0h7" {
+		t.Errorf("client.Policies.CreatePolicyRule returned %+v", created)
+	}
+
+	want := rule
+	want.Status = "ACTIVE"
+	if got := created.IdpDiscoveryRule(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rule.IdpDiscoveryRule returned \n\t%+v, want \n\t%+v\n", got, want)
+	}
+}
+
+func TestIdpDiscoveryRuleConditions(t *testing.T) {
+	var rule IdpDiscoveryRule
+	if err := rule.UserIdentifierCondition(UserIdentifierTypeAttribute, "department", PatternMatchEquals, "Engineering"); err != nil {
+		t.Fatalf("IdpDiscoveryRule.UserIdentifierCondition returned error: %v", err)
+	}
+	want := &UserIdentifier{Type: "ATTRIBUTE", Attribute: "department", Patterns: []UserIdentifierPattern{{MatchType: "EQUALS", Value: "Engineering"}}}
+	if !reflect.DeepEqual(rule.Conditions.UserIdentifier, want) {
+		t.Errorf("IdpDiscoveryRule.UserIdentifierCondition set \n\t%+v, want \n\t%+v\n", rule.Conditions.UserIdentifier, want)
+	}
+	if err := rule.RedirectToIdp(IdpDiscoveryProviderOkta, ""); err != nil {
+		t.Errorf("IdpDiscoveryRule.RedirectToIdp to OKTA returned error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"attribute of IDENTIFIER", rule.UserIdentifierCondition(UserIdentifierTypeIdentifier, "login", PatternMatchSuffix, "example.com")},
+		{"ATTRIBUTE without attribute", rule.UserIdentifierCondition(UserIdentifierTypeAttribute, "", PatternMatchSuffix, "example.com")},
+		{"unknown type", rule.UserIdentifierCondition("GROUP", "", PatternMatchSuffix, "example.com")},
+		{"unknown match type", rule.UserIdentifierCondition(UserIdentifierTypeIdentifier, "", "REGEX", "example.com")},
+		{"no pattern", rule.DomainCondition()},
+		{"app condition", rule.AppCondition("only")},
+		{"network connection", rule.NetworkCondition("ON_NETWORK", "", nil)},
+		{"network zones", rule.NetworkCondition("ZONE", "only", []string{"nzowdja2YRaQmOQYp0g3"})},
+		{"people condition", rule.PeopleCondition("apps", "include", nil)},
+		{"provider without ID", rule.RedirectToIdp(IdpTypeSAML2, "")},
+	}
+	for _, test := range tests {
+		if test.err == nil {
+			t.Errorf("IdpDiscoveryRule with %v returned no error", test.name)
+		}
+	}
+}
+
+func TestGetIdpDiscoveryPolicy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/policies", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		if got := r.URL.Query().Get("type"); got != PolicyTypeIdpDiscovery {
+			t.Errorf("Policies.GetIdpDiscoveryPolicy sent type %v", got)
+		}
+		fmt.Fprint(w, `[{"id":"00p5ikpfnuHmfuqKs0h7","type":"IDP_DISCOVERY","name":"Idp Discovery Policy","system":true,"status":"ACTIVE"}]`)
+	})
+
+	policy, _, err := client.Policies.GetIdpDiscoveryPolicy()
+	if err != nil {
+		t.Fatalf("Policies.GetIdpDiscoveryPolicy returned error: %v", err)
+	}
+	if policy.ID != "00p5ikpfnuHmfuqKs0h7" || !policy.System {
+		t.Errorf("client.Policies.GetIdpDiscoveryPolicy returned %+v", policy)
+	}
+}
+
+func TestReorderIdpDiscoveryRules(t *testing.T) {
+	setup()
+	defer teardown()
+
+	rules := map[string]string{
+		"0pr7vfjh3dgS6bxrj0h7": `{"id":"0pr7vfjh3dgS6bxrj0h7","type":"IDP_DISCOVERY","name":"Example","priority":1,` +
+			`"conditions":{"userIdentifier":{"type":"IDENTIFIER","patterns":[{"matchType":"SUFFIX","value":"example.com"}]}},` +
+			`"actions":{"idp":{"providers":[{"type":"SAML2","id":"0oa62bfdiumsUndnZ0h7"}]}}}`,
+		"0pr7vfk2ZzGZhKbTe0h7": `{"id":"0pr7vfk2ZzGZhKbTe0h7","type":"IDP_DISCOVERY","name":"Partner","priority":2,` +
+			`"conditions":{"userIdentifier":{"type":"IDENTIFIER","patterns":[{"matchType":"SUFFIX","value":"partner.com"}]}},` +
+			`"actions":{"idp":{"providers":[{"type":"OIDC","id":"0oa62bc8wppPw0UGr0h7"}]}}}`,
+		"0pr5ikpfnwJIMv2Ab0h7": `{"id":"0pr5ikpfnwJIMv2Ab0h7","type":"IDP_DISCOVERY","name":"Default Rule","priority":3,"system":true,` +
+			`"actions":{"idp":{"providers":[{"type":"OKTA"}]}}}`,
+	}
+	mux.HandleFunc("/policies/00p5ikpfnuHmfuqKs0h7/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("X-Request-Id", "rules")
+		fmt.Fprintf(w, "[%v,%v,%v]", rules["0pr7vfjh3dgS6bxrj0h7"], rules["0pr7vfk2ZzGZhKbTe0h7"], rules["0pr5ikpfnwJIMv2Ab0h7"])
+	})
+	var updates []string
+	mux.HandleFunc("/policies/00p5ikpfnuHmfuqKs0h7/rules/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		id := strings.TrimPrefix(r.URL.Path, "/policies/00p5ikpfnuHmfuqKs0h7/rules/")
+		var rule map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&rule)
+		actions, _ := rule["actions"].(map[string]interface{})
+		if _, ok := actions["signon"]; ok || rule["conditions"] == nil || actions["idp"] == nil {
+			t.Errorf("Policies.ReorderIdpDiscoveryRules sent %v", rule)
+		}
+		updates = append(updates, fmt.Sprintf("%v:%v", id, rule["priority"]))
+		var updated map[string]interface{}
+		json.Unmarshal([]byte(rules[id]), &updated)
+		updated["priority"] = rule["priority"]
+		w.Header().Set("X-Request-Id", id)
+		json.NewEncoder(w).Encode(updated)
+	})
+
+	reordered, resp, err := client.Policies.ReorderIdpDiscoveryRules("00p5ikpfnuHmfuqKs0h7", []string{"0pr7vfk2ZzGZhKbTe0h7", "0pr7vfjh3dgS6bxrj0h7"})
+	if err != nil {
+		t.Fatalf("Policies.ReorderIdpDiscoveryRules returned error: %v", err)
+	}
+	if got := resp.Header.Get("X-Request-Id"); got != "0pr7vfjh3dgS6bxrj0h7" {
+		t.Errorf("Policies.ReorderIdpDiscoveryRules returned the response of %v, want the last update", got)
+	}
+	if want := []string{"0pr7vfk2ZzGZhKbTe0h7:1", "0pr7vfjh3dgS6bxrj0h7:2"}; !reflect.DeepEqual(updates, want) {
+		t.Errorf("Policies.ReorderIdpDiscoveryRules updated %v, want %v", updates, want)
+	}
+	if len(reordered) != 2 || reordered[0].Name != "Partner" || reordered[0].Priority != 1 || reordered[1].Priority != 2 {
+		t.Errorf("client.Policies.ReorderIdpDiscoveryRules returned %+v", reordered)
+	}
+
+	if _, _, err := client.Policies.ReorderIdpDiscoveryRules("00p5ikpfnuHmfuqKs0h7", []string{"0pr5ikpfnwJIMv2Ab0h7"}); err == nil {
+		t.Errorf("Policies.ReorderIdpDiscoveryRules of the default rule returned no error")
+	}
+	if _, _, err := client.Policies.ReorderIdpDiscoveryRules("00p5ikpfnuHmfuqKs0h7", []string{"0pr000000000000000"}); err == nil {
+		t.Errorf("Policies.ReorderIdpDiscoveryRules of an unknown rule returned no error")
+	}
+}
@@ -0,0 +1,279 @@
+package okta
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"time"
+)
+
+const (
+	mediaTypePKIXCert = "application/pkix-cert"
+	mediaTypePEMFile  = "application/x-pem-file"
+)
+
+// IdpKeyListOptions are the optional query parameters of ListKeys
+type IdpKeyListOptions struct {
+	Limit         int      `url:"limit,omitempty"`
+	After         string   `url:"after,omitempty"`
+	NextURL       *url.URL `url:"-"`
+	GetAllPages   bool     `url:"-"`
+	NumberOfPages int      `url:"-"`
+}
+
+// IdpCSR is a certificate signing request of the signing key pair OKTA generates for an Identity Provider.
+// Csr is the base64 encoded DER request to sign by a certificate authority
+type IdpCSR struct {
+	ID      string                            `json:"id,omitempty"`
+	Created *time.Time                        `json:"created,omitempty"`
+	Csr     string                            `json:"csr,omitempty"`
+	Kty     string                            `json:"kty,omitempty"`
+	Links   map[string]map[string]interface{} `json:"_links,omitempty"`
+}
+
+// IdpCSRMetadata is the subject of a certificate signing request to create
+type IdpCSRMetadata struct {
+	Subject         *IdpCSRSubject         `json:"subject,omitempty"`
+	SubjectAltNames *IdpCSRSubjectAltNames `json:"subjectAltNames,omitempty"`
+}
+
+// IdpCSRSubject is the distinguished name of the subject of a certificate signing request
+type IdpCSRSubject struct {
+	CountryName            string `json:"countryName,omitempty"`
+	StateOrProvinceName    string `json:"stateOrProvinceName,omitempty"`
+	LocalityName           string `json:"localityName,omitempty"`
+	OrganizationName       string `json:"organizationName,omitempty"`
+	OrganizationalUnitName string `json:"organizationalUnitName,omitempty"`
+	CommonName             string `json:"commonName,omitempty"`
+}
+
+// IdpCSRSubjectAltNames are the subject alternative names of a certificate signing request
+type IdpCSRSubjectAltNames struct {
+	DNSNames []string `json:"dnsNames,omitempty"`
+}
+
+// ListKeys: List the certificates of the org that verify the signatures of Identity Providers
+func (p *IdentityProvidersService) ListKeys(opt *IdpKeyListOptions) ([]JSONWebKey, *Response, error) {
+	if opt == nil {
+		opt = new(IdpKeyListOptions)
+	}
+	var keys []JSONWebKey
+	resp, err := p.client.listPages("idps/credentials/keys", opt, opt.NextURL, opt.GetAllPages, opt.NumberOfPages, &keys)
+	return keys, resp, err
+}
+
+// GetKey: Get a certificate of the org that verifies the signatures of Identity Providers
+// Requires Kid from JSONWebKey object
+func (p *IdentityProvidersService) GetKey(kid string) (*JSONWebKey, *Response, error) {
+	u := fmt.Sprintf("idps/credentials/keys/%v", kid)
+	key := new(JSONWebKey)
+	resp, err := p.client.do("GET", u, nil, key)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return key, resp, err
+}
+
+// AddKey: Add an X.509 certificate to verify the signatures of Identity Providers with. The Kid of the returned
+// key is the Kid of the IdpTrust of a SAML 2.0 Identity Provider
+func (p *IdentityProvidersService) AddKey(cert *x509.Certificate) (*JSONWebKey, *Response, error) {
+	if cert == nil {
+		return nil, nil, fmt.Errorf("[ERROR] certificate is required")
+	}
+	body := map[string][]string{"x5c": {base64.StdEncoding.EncodeToString(cert.Raw)}}
+	key := new(JSONWebKey)
+	resp, err := p.client.do("POST", "idps/credentials/keys", body, key)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return key, resp, err
+}
+
+// AddKeyPEM: Add the first certificate of PEM encoded data, like the signing certificate downloaded from the
+// SAML metadata of an Identity Provider, to verify the signatures of Identity Providers with
+func (p *IdentityProvidersService) AddKeyPEM(data []byte) (*JSONWebKey, *Response, error) {
+	cert, err := parsePEMCertificate(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.AddKey(cert)
+}
+
+// DeleteKey: Delete a certificate of the org. A key used by an Identity Provider can not be deleted
+// Requires Kid from JSONWebKey object
+func (p *IdentityProvidersService) DeleteKey(kid string) (*Response, error) {
+	u := fmt.Sprintf("idps/credentials/keys/%v", kid)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// CreateCSR: Generate a new signing key pair for an Identity Provider and return its certificate signing request
+// Requires IdentityProvider ID from IdentityProvider object
+func (p *IdentityProvidersService) CreateCSR(idpID string, metadata IdpCSRMetadata) (*IdpCSR, *Response, error) {
+	u := fmt.Sprintf("idps/%v/credentials/csrs", idpID)
+	csr := new(IdpCSR)
+	resp, err := p.client.do("POST", u, metadata, csr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return csr, resp, err
+}
+
+// ListCSRs: List the pending certificate signing requests of an Identity Provider
+// Requires IdentityProvider ID from IdentityProvider object
+func (p *IdentityProvidersService) ListCSRs(idpID string) ([]IdpCSR, *Response, error) {
+	u := fmt.Sprintf("idps/%v/credentials/csrs", idpID)
+	var csrs []IdpCSR
+	resp, err := p.client.do("GET", u, nil, &csrs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return csrs, resp, err
+}
+
+// GetCSR: Get a certificate signing request of an Identity Provider
+// Requires IdentityProvider ID from IdentityProvider object & IdpCSR ID from IdpCSR object
+func (p *IdentityProvidersService) GetCSR(idpID string, csrID string) (*IdpCSR, *Response, error) {
+	u := fmt.Sprintf("idps/%v/credentials/csrs/%v", idpID, csrID)
+	csr := new(IdpCSR)
+	resp, err := p.client.do("GET", u, nil, csr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return csr, resp, err
+}
+
+// PublishCSR: Publish the certificate signed from a certificate signing request, which completes the request and
+// adds the signing key to the Identity Provider. The certificate is sent DER encoded in base64. Returns the new
+// signing key
+// Requires IdentityProvider ID from IdentityProvider object & IdpCSR ID from IdpCSR object
+func (p *IdentityProvidersService) PublishCSR(idpID string, csrID string, cert *x509.Certificate) (*JSONWebKey, *Response, error) {
+	if cert == nil {
+		return nil, nil, fmt.Errorf("[ERROR] certificate is required")
+	}
+	body := []byte(base64.StdEncoding.EncodeToString(cert.Raw))
+	return p.publishCSR(idpID, csrID, mediaTypePKIXCert, body, true)
+}
+
+// PublishCSRPEM: Publish the PEM encoded certificate signed from a certificate signing request, as issued by most
+// certificate authorities, see PublishCSR
+// Requires IdentityProvider ID from IdentityProvider object & IdpCSR ID from IdpCSR object
+func (p *IdentityProvidersService) PublishCSRPEM(idpID string, csrID string, data []byte) (*JSONWebKey, *Response, error) {
+	if _, err := parsePEMCertificate(data); err != nil {
+		return nil, nil, err
+	}
+	return p.publishCSR(idpID, csrID, mediaTypePEMFile, data, false)
+}
+
+// publishCSR (unexported) posts the certificate of a certificate signing request in body, encoded as contentType
+func (p *IdentityProvidersService) publishCSR(idpID string, csrID string, contentType string, body []byte, base64Encoded bool) (*JSONWebKey, *Response, error) {
+	u := fmt.Sprintf("idps/%v/credentials/csrs/%v/lifecycle/publish", idpID, csrID)
+	req, err := p.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", contentType)
+	if base64Encoded {
+		req.Header.Set("Content-Transfer-Encoding", "base64")
+	}
+
+	key := new(JSONWebKey)
+	resp, err := p.client.Do(req, key)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return key, resp, err
+}
+
+// RevokeCSR: Revoke a certificate signing request of an Identity Provider and delete its key pair
+// Requires IdentityProvider ID from IdentityProvider object & IdpCSR ID from IdpCSR object
+func (p *IdentityProvidersService) RevokeCSR(idpID string, csrID string) (*Response, error) {
+	u := fmt.Sprintf("idps/%v/credentials/csrs/%v", idpID, csrID)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// CertificateRequest returns the parsed certificate signing request, to sign by a certificate authority
+func (c IdpCSR) CertificateRequest() (*x509.CertificateRequest, error) {
+	der, err := base64.StdEncoding.DecodeString(c.Csr)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] csr %v is not base64 encoded: %v", c.ID, err)
+	}
+	return x509.ParseCertificateRequest(der)
+}
+
+// Certificate returns the first certificate of the x5c chain of a JSONWebKey
+func (k JSONWebKey) Certificate() (*x509.Certificate, error) {
+	if len(k.X5c) == 0 {
+		return nil, fmt.Errorf("[ERROR] jwk %v has no x5c certificate", k.Kid)
+	}
+	der, err := base64.StdEncoding.DecodeString(k.X5c[0])
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] x5c certificate of jwk %v is not base64 encoded: %v", k.Kid, err)
+	}
+	return x509.ParseCertificate(der)
+}
+
+// NewCertificateRequest returns a PEM encoded certificate signing request for the subject of metadata, signed
+// with a local private key. The certificate signed from it is added with AddKey, for keys that are not
+// generated by OKTA
+func NewCertificateRequest(signer crypto.Signer, metadata IdpCSRMetadata) ([]byte, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("[ERROR] signer is required")
+	}
+	template := &x509.CertificateRequest{}
+	if s := metadata.Subject; s != nil {
+		template.Subject = pkix.Name{CommonName: s.CommonName}
+		if s.CountryName != "" {
+			template.Subject.Country = []string{s.CountryName}
+		}
+		if s.StateOrProvinceName != "" {
+			template.Subject.Province = []string{s.StateOrProvinceName}
+		}
+		if s.LocalityName != "" {
+			template.Subject.Locality = []string{s.LocalityName}
+		}
+		if s.OrganizationName != "" {
+			template.Subject.Organization = []string{s.OrganizationName}
+		}
+		if s.OrganizationalUnitName != "" {
+			template.Subject.OrganizationalUnit = []string{s.OrganizationalUnitName}
+		}
+	}
+	if metadata.SubjectAltNames != nil {
+		template.DNSNames = metadata.SubjectAltNames.DNSNames
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, signer)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] failed to create the certificate signing request: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// parsePEMCertificate (unexported) returns the first certificate of PEM encoded data
+func parsePEMCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("[ERROR] no PEM encoded certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
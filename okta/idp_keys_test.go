@@ -0,0 +1,257 @@
+package okta
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testIdpCertificate returns a self-signed certificate for the tests
+func testIdpCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate a key, error %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("failed to create a certificate, error %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse the certificate, error %v", err)
+	}
+	return cert
+}
+
+func TestIdpKeys(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cert := testIdpCertificate(t)
+	x5c := base64.StdEncoding.EncodeToString(cert.Raw)
+	key := JSONWebKey{Kid: "your-key-id", Kty: "EC", Use: "sig", X5c: []string{x5c}}
+
+	mux.HandleFunc("/idps/credentials/keys", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			testBody(t, r, map[string][]string{"x5c": {x5c}})
+			json.NewEncoder(w).Encode(key)
+			return
+		}
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("limit"); got != "20" {
+			t.Errorf("IdentityProviders.ListKeys sent limit %v, want 20", got)
+		}
+		json.NewEncoder(w).Encode([]JSONWebKey{key})
+	})
+	mux.HandleFunc("/idps/credentials/keys/your-key-id", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		testMethod(t, r, "GET")
+		json.NewEncoder(w).Encode(key)
+	})
+
+	keys, _, err := client.IdentityProviders.ListKeys(&IdpKeyListOptions{Limit: 20})
+	if err != nil {
+		t.Errorf("IdentityProviders.ListKeys returned error: %v", err)
+	}
+	if want := []JSONWebKey{key}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("client.IdentityProviders.ListKeys returned \n\t%+v, want \n\t%+v\n", keys, want)
+	}
+
+	got, _, err := client.IdentityProviders.GetKey("your-key-id")
+	if err != nil {
+		t.Errorf("IdentityProviders.GetKey returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, &key) {
+		t.Errorf("client.IdentityProviders.GetKey returned \n\t%+v, want \n\t%+v\n", got, &key)
+	}
+	parsed, err := got.Certificate()
+	if err != nil || !parsed.Equal(cert) {
+		t.Errorf("JSONWebKey.Certificate returned %v, error %v", parsed, err)
+	}
+
+	added, _, err := client.IdentityProviders.AddKey(cert)
+	if err != nil {
+		t.Errorf("IdentityProviders.AddKey returned error: %v", err)
+	}
+	if !reflect.DeepEqual(added, &key) {
+		t.Errorf("client.IdentityProviders.AddKey returned \n\t%+v, want \n\t%+v\n", added, &key)
+	}
+
+	// the certificate is sent without the other PEM blocks
+	data := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("ignored")})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	if _, _, err := client.IdentityProviders.AddKeyPEM(data); err != nil {
+		t.Errorf("IdentityProviders.AddKeyPEM returned error: %v", err)
+	}
+	if _, _, err := client.IdentityProviders.AddKeyPEM([]byte("not a certificate")); err == nil {
+		t.Errorf("IdentityProviders.AddKeyPEM of data without a certificate returned no error")
+	}
+
+	if _, err := client.IdentityProviders.DeleteKey("your-key-id"); err != nil {
+		t.Errorf("IdentityProviders.DeleteKey returned error: %v", err)
+	}
+}
+
+func TestIdpCSRs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate a key, error %v", err)
+	}
+	metadata := IdpCSRMetadata{
+		Subject:         &IdpCSRSubject{CountryName: "US", OrganizationName: "Example", CommonName: "SP Issuer"},
+		SubjectAltNames: &IdpCSRSubjectAltNames{DNSNames: []string{"dev-123456.okta.com"}},
+	}
+	request, err := NewCertificateRequest(key, metadata)
+	if err != nil {
+		t.Fatalf("NewCertificateRequest returned error: %v", err)
+	}
+	block, _ := pem.Decode(request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		t.Fatalf("NewCertificateRequest returned %s, want a PEM encoded CERTIFICATE REQUEST", request)
+	}
+	csr := IdpCSR{ID: "h9zkutaSe7fZX0SwN1GqDApofgD1OW8g2B5l2azha50", Kty: "EC", Csr: base64.StdEncoding.EncodeToString(block.Bytes)}
+
+	mux.HandleFunc("/idps/0oa4lb6lbtmH355Hx0h7/credentials/csrs", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			testBody(t, r, metadata)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(csr)
+			return
+		}
+		testMethod(t, r, "GET")
+		json.NewEncoder(w).Encode([]IdpCSR{csr})
+	})
+	mux.HandleFunc("/idps/0oa4lb6lbtmH355Hx0h7/credentials/csrs/"+csr.ID, func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		testMethod(t, r, "GET")
+		json.NewEncoder(w).Encode(csr)
+	})
+
+	created, _, err := client.IdentityProviders.CreateCSR("0oa4lb6lbtmH355Hx0h7", metadata)
+	if err != nil {
+		t.Errorf("IdentityProviders.CreateCSR returned error: %v", err)
+	}
+	if !reflect.DeepEqual(created, &csr) {
+		t.Errorf("client.IdentityProviders.CreateCSR returned \n\t%+v, want \n\t%+v\n", created, &csr)
+	}
+	parsed, err := created.CertificateRequest()
+	if err != nil {
+		t.Fatalf("IdpCSR.CertificateRequest returned error: %v", err)
+	}
+	if err := parsed.CheckSignature(); err != nil {
+		t.Errorf("NewCertificateRequest returned a request with an invalid signature: %v", err)
+	}
+	if parsed.Subject.CommonName != "SP Issuer" || !reflect.DeepEqual(parsed.Subject.Country, []string{"US"}) ||
+		!reflect.DeepEqual(parsed.DNSNames, []string{"dev-123456.okta.com"}) {
+		t.Errorf("NewCertificateRequest returned a request for %v %v", parsed.Subject, parsed.DNSNames)
+	}
+
+	csrs, _, err := client.IdentityProviders.ListCSRs("0oa4lb6lbtmH355Hx0h7")
+	if err != nil {
+		t.Errorf("IdentityProviders.ListCSRs returned error: %v", err)
+	}
+	if want := []IdpCSR{csr}; !reflect.DeepEqual(csrs, want) {
+		t.Errorf("client.IdentityProviders.ListCSRs returned \n\t%+v, want \n\t%+v\n", csrs, want)
+	}
+
+	got, _, err := client.IdentityProviders.GetCSR("0oa4lb6lbtmH355Hx0h7", csr.ID)
+	if err != nil {
+		t.Errorf("IdentityProviders.GetCSR returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, &csr) {
+		t.Errorf("client.IdentityProviders.GetCSR returned \n\t%+v, want \n\t%+v\n", got, &csr)
+	}
+
+	if _, err := client.IdentityProviders.RevokeCSR("0oa4lb6lbtmH355Hx0h7", csr.ID); err != nil {
+		t.Errorf("IdentityProviders.RevokeCSR returned error: %v", err)
+	}
+}
+
+func TestPublishIdpCSR(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cert := testIdpCertificate(t)
+	x5c := base64.StdEncoding.EncodeToString(cert.Raw)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	key := JSONWebKey{Kid: "ZC5C-1gEUwVxiYI8xdmYYDI3Noc4zI24fLNxBpZVR04", Kty: "EC", Use: "sig", X5c: []string{x5c}}
+
+	mux.HandleFunc("/idps/0oa4lb6lbtmH355Hx0h7/credentials/csrs/h9zkutaSe7fZX0SwN1GqDApofgD1OW8g2B5l2azha50/lifecycle/publish", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		body, _ := ioutil.ReadAll(r.Body)
+		switch r.Header.Get("Content-Type") {
+		case "application/pkix-cert":
+			// a DER certificate is sent in base64
+			if got := r.Header.Get("Content-Transfer-Encoding"); got != "base64" {
+				t.Errorf("IdentityProviders.PublishCSR sent Content-Transfer-Encoding %v, want base64", got)
+			}
+			if der, err := base64.StdEncoding.DecodeString(string(body)); err != nil || !reflect.DeepEqual(der, cert.Raw) {
+				t.Errorf("IdentityProviders.PublishCSR sent %s, want the base64 encoded DER certificate", body)
+			}
+		case "application/x-pem-file":
+			if got := r.Header.Get("Content-Transfer-Encoding"); got != "" {
+				t.Errorf("IdentityProviders.PublishCSRPEM sent Content-Transfer-Encoding %v", got)
+			}
+			if !reflect.DeepEqual(body, certPEM) {
+				t.Errorf("IdentityProviders.PublishCSRPEM sent %s, want the PEM encoded certificate", body)
+			}
+		default:
+			t.Errorf("IdentityProviders.PublishCSR sent Content-Type %v", r.Header.Get("Content-Type"))
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(key)
+	})
+
+	published, _, err := client.IdentityProviders.PublishCSR("0oa4lb6lbtmH355Hx0h7", "h9zkutaSe7fZX0SwN1GqDApofgD1OW8g2B5l2azha50", cert)
+	if err != nil {
+		t.Errorf("IdentityProviders.PublishCSR returned error: %v", err)
+	}
+	if !reflect.DeepEqual(published, &key) {
+		t.Errorf("client.IdentityProviders.PublishCSR returned \n\t%+v, want \n\t%+v\n", published, &key)
+	}
+	if _, _, err := client.IdentityProviders.PublishCSR("0oa4lb6lbtmH355Hx0h7", "h9zkutaSe7fZX0SwN1GqDApofgD1OW8g2B5l2azha50", nil); err == nil {
+		t.Errorf("IdentityProviders.PublishCSR without certificate returned no error")
+	}
+
+	published, _, err = client.IdentityProviders.PublishCSRPEM("0oa4lb6lbtmH355Hx0h7", "h9zkutaSe7fZX0SwN1GqDApofgD1OW8g2B5l2azha50", certPEM)
+	if err != nil {
+		t.Errorf("IdentityProviders.PublishCSRPEM returned error: %v", err)
+	}
+	if !reflect.DeepEqual(published, &key) {
+		t.Errorf("client.IdentityProviders.PublishCSRPEM returned \n\t%+v, want \n\t%+v\n", published, &key)
+	}
+	if _, _, err := client.IdentityProviders.PublishCSRPEM("0oa4lb6lbtmH355Hx0h7", "h9zkutaSe7fZX0SwN1GqDApofgD1OW8g2B5l2azha50", []byte("not a certificate")); err == nil {
+		t.Errorf("IdentityProviders.PublishCSRPEM of data without a certificate returned no error")
+	}
+}
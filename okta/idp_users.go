@@ -0,0 +1,97 @@
+package okta
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// IdpUser is the link of an OKTA user to an identity of an Identity Provider. ID is the OKTA user ID,
+// ExternalID the ID of the user at the Identity Provider and Profile its profile from the Identity Provider
+type IdpUser struct {
+	ID          string                            `json:"id,omitempty"`
+	ExternalID  string                            `json:"externalId,omitempty"`
+	Created     *time.Time                        `json:"created,omitempty"`
+	LastUpdated *time.Time                        `json:"lastUpdated,omitempty"`
+	Profile     map[string]interface{}            `json:"profile,omitempty"`
+	Links       map[string]map[string]interface{} `json:"_links,omitempty"`
+}
+
+// SocialAuthToken is a token OKTA received from a social Identity Provider for a linked user
+type SocialAuthToken struct {
+	ID              string     `json:"id,omitempty"`
+	Token           string     `json:"token,omitempty"`
+	TokenType       string     `json:"tokenType,omitempty"`
+	TokenAuthScheme string     `json:"tokenAuthScheme,omitempty"`
+	Scopes          []string   `json:"scopes,omitempty"`
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty"`
+}
+
+// IdpUserListOptions are the optional query parameters of ListUsers. Q searches the linked users by
+// the first name, last name or email of their profile
+type IdpUserListOptions struct {
+	Q             string   `url:"q,omitempty"`
+	Limit         int      `url:"limit,omitempty"`
+	After         string   `url:"after,omitempty"`
+	NextURL       *url.URL `url:"-"`
+	GetAllPages   bool     `url:"-"`
+	NumberOfPages int      `url:"-"`
+}
+
+// ListUsers: List the users linked to an Identity Provider
+// Requires IdentityProvider ID from IdentityProvider object
+func (p *IdentityProvidersService) ListUsers(idpID string, opt *IdpUserListOptions) ([]IdpUser, *Response, error) {
+	if opt == nil {
+		opt = new(IdpUserListOptions)
+	}
+	u := fmt.Sprintf("idps/%v/users", idpID)
+	var users []IdpUser
+	resp, err := p.client.listPages(u, opt, opt.NextURL, opt.GetAllPages, opt.NumberOfPages, &users)
+	return users, resp, err
+}
+
+// GetUser: Get the link of a user to an Identity Provider
+// Requires IdentityProvider ID from IdentityProvider object & User ID from User object
+func (p *IdentityProvidersService) GetUser(idpID string, userID string) (*IdpUser, *Response, error) {
+	u := fmt.Sprintf("idps/%v/users/%v", idpID, userID)
+	user := new(IdpUser)
+	resp, err := p.client.do("GET", u, nil, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// LinkUser: Link an OKTA user to the identity externalID of an Identity Provider, so that the user signs in with it
+// Requires IdentityProvider ID from IdentityProvider object & User ID from User object
+func (p *IdentityProvidersService) LinkUser(idpID string, userID string, externalID string) (*IdpUser, *Response, error) {
+	u := fmt.Sprintf("idps/%v/users/%v", idpID, userID)
+	user := new(IdpUser)
+	resp, err := p.client.do("POST", u, map[string]string{"externalId": externalID}, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// UnlinkUser: Remove the link of an OKTA user to an Identity Provider. The user is not deleted
+// Requires IdentityProvider ID from IdentityProvider object & User ID from User object
+func (p *IdentityProvidersService) UnlinkUser(idpID string, userID string) (*Response, error) {
+	u := fmt.Sprintf("idps/%v/users/%v", idpID, userID)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// ListSocialAuthTokens: List the tokens OKTA received from a social Identity Provider when the linked user signed in
+// Requires IdentityProvider ID from IdentityProvider object & User ID from User object
+func (p *IdentityProvidersService) ListSocialAuthTokens(idpID string, userID string) ([]SocialAuthToken, *Response, error) {
+	u := fmt.Sprintf("idps/%v/users/%v/credentials/tokens", idpID, userID)
+	var tokens []SocialAuthToken
+	resp, err := p.client.do("GET", u, nil, &tokens)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tokens, resp, err
+}
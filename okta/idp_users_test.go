@@ -0,0 +1,118 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListIdpUsers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/idps/0oa4lb6lbtmH355Hx0h7/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		switch r.URL.Query().Get("after") {
+		case "":
+			if q := r.URL.Query(); q.Get("q") != "john" || q.Get("limit") != "1" {
+				t.Errorf("IdentityProviders.ListUsers sent %v", r.URL.RawQuery)
+			}
+			w.Header().Add("Link", fmt.Sprintf(`<%v/idps/0oa4lb6lbtmH355Hx0h7/users?after=00u5t60iloOHN9pBi0h7&limit=1&q=john>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id": "00u5t60iloOHN9pBi0h7", "externalId": "externalId", "created": "2017-12-19T17:30:16.000Z", "profile": {"email": "john.doe@example.com"}}]`)
+		default:
+			fmt.Fprint(w, `[{"id": "00ub0oNGTSWTBKOLGLNR", "externalId": "121749775026145"}]`)
+		}
+	})
+
+	created, _ := time.Parse(time.RFC3339, "2017-12-19T17:30:16.000Z")
+	opt := &IdpUserListOptions{Q: "john", Limit: 1}
+	users, _, err := client.IdentityProviders.ListUsers("0oa4lb6lbtmH355Hx0h7", opt)
+	if err != nil {
+		t.Fatalf("IdentityProviders.ListUsers returned error: %v", err)
+	}
+	want := []IdpUser{{ID: "00u5t60iloOHN9pBi0h7", ExternalID: "externalId", Created: &created, Profile: map[string]interface{}{"email": "john.doe@example.com"}}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("client.IdentityProviders.ListUsers returned \n\t%+v, want \n\t%+v\n", users, want)
+	}
+
+	opt.GetAllPages = true
+	users, _, err = client.IdentityProviders.ListUsers("0oa4lb6lbtmH355Hx0h7", opt)
+	if err != nil {
+		t.Fatalf("IdentityProviders.ListUsers returned error: %v", err)
+	}
+	if len(users) != 2 || users[1].ExternalID != "121749775026145" {
+		t.Errorf("client.IdentityProviders.ListUsers of all pages returned %+v", users)
+	}
+}
+
+func TestLinkIdpUser(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/idps/0oa4lb6lbtmH355Hx0h7/users/00ub0oNGTSWTBKOLGLNR", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		switch r.Method {
+		case "POST":
+			testBody(t, r, map[string]string{"externalId": "121749775026145"})
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, `{"id": "00ub0oNGTSWTBKOLGLNR", "externalId": "121749775026145"}`)
+	})
+
+	want := &IdpUser{ID: "00ub0oNGTSWTBKOLGLNR", ExternalID: "121749775026145"}
+	linked, _, err := client.IdentityProviders.LinkUser("0oa4lb6lbtmH355Hx0h7", "00ub0oNGTSWTBKOLGLNR", "121749775026145")
+	if err != nil {
+		t.Errorf("IdentityProviders.LinkUser returned error: %v", err)
+	}
+	if !reflect.DeepEqual(linked, want) {
+		t.Errorf("client.IdentityProviders.LinkUser returned \n\t%+v, want \n\t%+v\n", linked, want)
+	}
+
+	user, _, err := client.IdentityProviders.GetUser("0oa4lb6lbtmH355Hx0h7", "00ub0oNGTSWTBKOLGLNR")
+	if err != nil {
+		t.Errorf("IdentityProviders.GetUser returned error: %v", err)
+	}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("client.IdentityProviders.GetUser returned \n\t%+v, want \n\t%+v\n", user, want)
+	}
+
+	if _, err := client.IdentityProviders.UnlinkUser("0oa4lb6lbtmH355Hx0h7", "00ub0oNGTSWTBKOLGLNR"); err != nil {
+		t.Errorf("IdentityProviders.UnlinkUser returned error: %v", err)
+	}
+}
+
+func TestListSocialAuthTokens(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/idps/0oa4lb6lbtmH355Hx0h7/users/00ub0oNGTSWTBKOLGLNR/credentials/tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `[{"id": "NXp9GaX1eOA-XVF_H9fn2Q", "token": "JBTWGV22G4ZGKV3N", "tokenType": "urn:ietf:params:oauth:token-type:access_token",`+
+			`"tokenAuthScheme": "Bearer", "expiresAt": "2014-08-06T16:56:31.000Z", "scopes": ["openid", "foo"]}]`)
+	})
+
+	expiresAt, _ := time.Parse(time.RFC3339, "2014-08-06T16:56:31.000Z")
+	tokens, _, err := client.IdentityProviders.ListSocialAuthTokens("0oa4lb6lbtmH355Hx0h7", "00ub0oNGTSWTBKOLGLNR")
+	if err != nil {
+		t.Errorf("IdentityProviders.ListSocialAuthTokens returned error: %v", err)
+	}
+	want := []SocialAuthToken{{
+		ID:              "NXp9GaX1eOA-XVF_H9fn2Q",
+		Token:           "JBTWGV22G4ZGKV3N",
+		TokenType:       "urn:ietf:params:oauth:token-type:access_token",
+		TokenAuthScheme: "Bearer",
+		Scopes:          []string{"openid", "foo"},
+		ExpiresAt:       &expiresAt,
+	}}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("client.IdentityProviders.ListSocialAuthTokens returned \n\t%+v, want \n\t%+v\n", tokens, want)
+	}
+}
@@ -2,9 +2,60 @@ package okta
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 )
 
+const (
+	// IdpTypeSAML2 is the type of a SAML 2.0 Identity Provider
+	IdpTypeSAML2 = "SAML2"
+	// IdpTypeOIDC is the type of a generic OpenID Connect Identity Provider
+	IdpTypeOIDC = "OIDC"
+	// IdpTypeGoogle is the type of the Google social Identity Provider
+	IdpTypeGoogle = "GOOGLE"
+	// IdpTypeFacebook is the type of the Facebook social Identity Provider
+	IdpTypeFacebook = "FACEBOOK"
+	// IdpTypeMicrosoft is the type of the Microsoft social Identity Provider
+	IdpTypeMicrosoft = "MICROSOFT"
+	// IdpTypeLinkedIn is the type of the LinkedIn social Identity Provider
+	IdpTypeLinkedIn = "LINKEDIN"
+	// IdpTypeApple is the type of the Apple social Identity Provider
+	IdpTypeApple = "APPLE"
+
+	// IdpProtocolSAML2 is the protocol of SAML 2.0 Identity Providers
+	IdpProtocolSAML2 = "SAML2"
+	// IdpProtocolOIDC is the protocol of OpenID Connect Identity Providers, including Google, Microsoft and Apple
+	IdpProtocolOIDC = "OIDC"
+	// IdpProtocolOAuth2 is the protocol of the Facebook and LinkedIn Identity Providers
+	IdpProtocolOAuth2 = "OAUTH2"
+
+	// SAMLBindingPost is the HTTP-POST binding of a SAML endpoint
+	SAMLBindingPost = "HTTP-POST"
+	// SAMLBindingRedirect is the HTTP-Redirect binding of a SAML endpoint
+	SAMLBindingRedirect = "HTTP-REDIRECT"
+
+	// SAMLNameIDFormatUnspecified lets the SAML Identity Provider choose the NameID format
+	SAMLNameIDFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	// SAMLNameIDFormatEmail requests an email address NameID
+	SAMLNameIDFormatEmail = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	// SAMLNameIDFormatPersistent requests a persistent NameID
+	SAMLNameIDFormatPersistent = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	// SAMLNameIDFormatTransient requests a transient NameID
+	SAMLNameIDFormatTransient = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+)
+
+// socialIdps (unexported) are the protocol and required scopes of the social Identity Provider types
+var socialIdps = map[string]struct {
+	protocol string
+	scopes   []string
+}{
+	IdpTypeGoogle:    {IdpProtocolOIDC, []string{"openid", "email", "profile"}},
+	IdpTypeFacebook:  {IdpProtocolOAuth2, []string{"public_profile", "email"}},
+	IdpTypeMicrosoft: {IdpProtocolOIDC, []string{"openid", "email", "profile", "https://graph.microsoft.com/User.Read"}},
+	IdpTypeLinkedIn:  {IdpProtocolOAuth2, []string{"r_emailaddress", "r_liteprofile"}},
+	IdpTypeApple:     {IdpProtocolOIDC, []string{"openid", "email", "name"}},
+}
+
 type IdentityProvidersService service
 
 func (p *IdentityProvidersService) IdentityProvider() IdentityProvider {
@@ -43,7 +94,26 @@ type Conditions struct {
 }
 
 type Credentials struct {
-	Client *IdpClient `json:"client,omitempty"`
+	Client  *IdpClient  `json:"client,omitempty"`
+	Trust   *IdpTrust   `json:"trust,omitempty"`
+	Signing *IdpSigning `json:"signing,omitempty"`
+}
+
+// IdpTrust is the trust of a SAML 2.0 Identity Provider: the issuer and audience of its assertions and the ID of
+// the IdP key that verifies their signature
+type IdpTrust struct {
+	Issuer                  string `json:"issuer,omitempty"`
+	Audience                string `json:"audience,omitempty"`
+	Kid                     string `json:"kid,omitempty"`
+	Revocation              string `json:"revocation,omitempty"`
+	RevocationCacheLifetime int    `json:"revocationCacheLifetime,omitempty"`
+}
+
+// IdpSigning is the signing key of the requests to an Identity Provider. Apple uses TeamID and PrivateKey
+type IdpSigning struct {
+	Kid        string `json:"kid,omitempty"`
+	TeamID     string `json:"teamId,omitempty"`
+	PrivateKey string `json:"privateKey,omitempty"`
 }
 
 type Deprovisioned struct {
@@ -53,6 +123,19 @@ type Deprovisioned struct {
 type Endpoints struct {
 	Authorization *Authorization `json:"authorization,omitempty"`
 	Token         *Token         `json:"token,omitempty"`
+	UserInfo      *IdpEndpoint   `json:"userInfo,omitempty"`
+	Jwks          *IdpEndpoint   `json:"jwks,omitempty"`
+	Sso           *IdpEndpoint   `json:"sso,omitempty"`
+	Acs           *IdpEndpoint   `json:"acs,omitempty"`
+}
+
+// IdpEndpoint is an endpoint of an Identity Provider. Type is the type of the SAML assertion consumer service,
+// "INSTANCE" or "ORG"
+type IdpEndpoint struct {
+	URL         string `json:"url,omitempty"`
+	Binding     string `json:"binding,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Type        string `json:"type,omitempty"`
 }
 
 type IdpGroups struct {
@@ -92,10 +175,53 @@ type IdpPolicy struct {
 }
 
 type Protocol struct {
-	Type        string       `json:"type,omitempty"`
-	Endpoints   *Endpoints   `json:"endpoints,omitempty"`
-	Scopes      []string     `json:"scopes,omitempty"`
-	Credentials *Credentials `json:"credentials,omitempty"`
+	Type        string         `json:"type,omitempty"`
+	Endpoints   *Endpoints     `json:"endpoints,omitempty"`
+	Scopes      []string       `json:"scopes,omitempty"`
+	Credentials *Credentials   `json:"credentials,omitempty"`
+	Issuer      *IdpIssuer     `json:"issuer,omitempty"`
+	Algorithms  *IdpAlgorithms `json:"algorithms,omitempty"`
+	Settings    *IdpSettings   `json:"settings,omitempty"`
+}
+
+// IdpIssuer is the issuer of the tokens of an OpenID Connect Identity Provider
+type IdpIssuer struct {
+	URL string `json:"url,omitempty"`
+}
+
+// IdpAlgorithms are the signature algorithms of the requests to and responses of an Identity Provider
+type IdpAlgorithms struct {
+	Request  *IdpAlgorithm `json:"request,omitempty"`
+	Response *IdpAlgorithm `json:"response,omitempty"`
+}
+
+// IdpAlgorithm is the signature algorithm of requests or responses
+type IdpAlgorithm struct {
+	Signature *IdpSignatureAlgorithm `json:"signature,omitempty"`
+}
+
+// IdpSignatureAlgorithm is a signature algorithm like "SHA-256" and its scope, "REQUEST", "RESPONSE", "ANY" or "NONE"
+type IdpSignatureAlgorithm struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+}
+
+// IdpSettings are the settings of a SAML 2.0 Identity Provider, NameFormat is the requested NameID format
+type IdpSettings struct {
+	NameFormat string `json:"nameFormat,omitempty"`
+}
+
+// IdentityProviderListOptions are the optional query parameters of ListIdentityProviders. Q searches by name,
+// Type is an IdpType. GetAllPages or NumberOfPages follow the next links
+type IdentityProviderListOptions struct {
+	Q     string `url:"q,omitempty"`
+	Type  string `url:"type,omitempty"`
+	Limit int    `url:"limit,omitempty"`
+	After string `url:"after,omitempty"`
+
+	NextURL       *url.URL `url:"-"`
+	GetAllPages   bool     `url:"-"`
+	NumberOfPages int      `url:"-"`
 }
 
 type Provisioning struct {
@@ -195,3 +321,115 @@ func (p *IdentityProvidersService) DeleteIdentityProvider(id string) (*Response,
 
 	return resp, err
 }
+
+// ListIdentityProviders: List the Identity Providers of the org, filtered by name and type
+func (p *IdentityProvidersService) ListIdentityProviders(opt *IdentityProviderListOptions) ([]IdentityProvider, *Response, error) {
+	if opt == nil {
+		opt = new(IdentityProviderListOptions)
+	}
+	var idps []IdentityProvider
+	resp, err := p.client.listPages("idps", opt, opt.NextURL, opt.GetAllPages, opt.NumberOfPages, &idps)
+	return idps, resp, err
+}
+
+// ActivateIdentityProvider: Activate or deactivate an Identity Provider
+// Requires IdentityProvider ID from IdentityProvider object
+func (p *IdentityProvidersService) ActivateIdentityProvider(id string, activate bool) (*IdentityProvider, *Response, error) {
+	u := fmt.Sprintf("idps/%v/lifecycle/%v", id, lifecycleAction(activate))
+	req, err := p.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idp := new(IdentityProvider)
+	resp, err := p.client.Do(req, idp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return idp, resp, err
+}
+
+// IdpRequiredScopes returns the scopes OKTA requires for a social Identity Provider type, nil for other types
+func IdpRequiredScopes(idpType string) []string {
+	social, ok := socialIdps[idpType]
+	if !ok {
+		return nil
+	}
+	return append([]string(nil), social.scopes...)
+}
+
+// NewSocialIdentityProvider returns a Google, Facebook, Microsoft, LinkedIn or Apple Identity Provider to create,
+// which creates and links users by email. The required scopes of the type are added to scopes
+func NewSocialIdentityProvider(idpType string, name string, clientID string, clientSecret string, scopes ...string) (IdentityProvider, error) {
+	social, ok := socialIdps[idpType]
+	if !ok {
+		return IdentityProvider{}, fmt.Errorf("[ERROR] %v is not a social Identity Provider type", idpType)
+	}
+	// copy scopes so the required ones are not appended into the backing array of the caller
+	scopes = append([]string(nil), scopes...)
+	for _, required := range social.scopes {
+		found := false
+		for _, scope := range scopes {
+			found = found || scope == required
+		}
+		if !found {
+			scopes = append(scopes, required)
+		}
+	}
+
+	return IdentityProvider{
+		Type: idpType,
+		Name: name,
+		Protocol: &Protocol{
+			Type:        social.protocol,
+			Scopes:      scopes,
+			Credentials: &Credentials{Client: &IdpClient{ClientID: clientID, ClientSecret: clientSecret}},
+		},
+		Policy: newIdpPolicy("idpuser.email"),
+	}, nil
+}
+
+// NewSAMLIdentityProvider returns a SAML 2.0 Identity Provider to create, with an HTTP-POST SSO endpoint, SHA-256
+// signatures and the unspecified NameID format. issuer and audience are the trust of the assertions, kid the ID of
+// the IdP key that verifies their signature
+func NewSAMLIdentityProvider(name string, ssoURL string, issuer string, audience string, kid string) IdentityProvider {
+	return IdentityProvider{
+		Type: IdpTypeSAML2,
+		Name: name,
+		Protocol: &Protocol{
+			Type: IdpProtocolSAML2,
+			Endpoints: &Endpoints{
+				Sso: &IdpEndpoint{URL: ssoURL, Binding: SAMLBindingPost, Destination: ssoURL},
+				Acs: &IdpEndpoint{Binding: SAMLBindingPost, Type: "INSTANCE"},
+			},
+			Algorithms: &IdpAlgorithms{
+				Request:  &IdpAlgorithm{Signature: &IdpSignatureAlgorithm{Algorithm: "SHA-256", Scope: "REQUEST"}},
+				Response: &IdpAlgorithm{Signature: &IdpSignatureAlgorithm{Algorithm: "SHA-256", Scope: "ANY"}},
+			},
+			Credentials: &Credentials{Trust: &IdpTrust{Issuer: issuer, Audience: audience, Kid: kid}},
+			Settings:    &IdpSettings{NameFormat: SAMLNameIDFormatUnspecified},
+		},
+		Policy: newIdpPolicy("idpuser.subjectNameId"),
+	}
+}
+
+// newIdpPolicy (unexported) returns the policy of a new Identity Provider, which creates users and links them
+// by the username from template
+func newIdpPolicy(template string) *IdpPolicy {
+	return &IdpPolicy{
+		Provisioning: &Provisioning{
+			Action: "AUTO",
+			Groups: &IdpGroups{Action: "NONE"},
+			Conditions: &Conditions{
+				Deprovisioned: &Deprovisioned{Action: "NONE"},
+				Suspended:     &Suspended{Action: "NONE"},
+			},
+		},
+		AccountLink: &AccountLink{Action: "AUTO"},
+		Subject: &Subject{
+			UserNameTemplate: &UserNameTemplate{Template: template},
+			MatchType:        "USERNAME",
+		},
+	}
+}
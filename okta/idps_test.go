@@ -189,3 +189,121 @@ func TestIdentityProviderDelete(t *testing.T) {
 		t.Errorf("IdentityProviders.DeleteIdentityProvider returned error: %v", err)
 	}
 }
+
+func TestListIdentityProviders(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/idps", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		switch r.URL.Query().Get("after") {
+		case "":
+			if q := r.URL.Query(); q.Get("q") != "Corp" || q.Get("type") != IdpTypeSAML2 || q.Get("limit") != "1" {
+				t.Errorf("IdentityProviders.ListIdentityProviders sent %v", r.URL.RawQuery)
+			}
+			w.Header().Add("Link", fmt.Sprintf(`<%v/idps?after=0oa62b57p7c8PaGpU0h7&limit=1&q=Corp&type=SAML2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id": "0oa62bc8wppPw0UGr0h7", "type": "SAML2", "name": "Corp IdP", "status": "ACTIVE"}]`)
+		default:
+			fmt.Fprint(w, `[{"id": "0oa62b57p7c8PaGpU0h7", "type": "SAML2", "name": "Corp Partner IdP", "status": "INACTIVE"}]`)
+		}
+	})
+
+	opt := &IdentityProviderListOptions{Q: "Corp", Type: IdpTypeSAML2, Limit: 1}
+	idps, _, err := client.IdentityProviders.ListIdentityProviders(opt)
+	if err != nil {
+		t.Fatalf("IdentityProviders.ListIdentityProviders returned error: %v", err)
+	}
+	if len(idps) != 1 || idps[0].ID != "0oa62bc8wppPw0UGr0h7" {
+		t.Errorf("client.IdentityProviders.ListIdentityProviders returned %+v", idps)
+	}
+
+	opt.GetAllPages = true
+	idps, _, err = client.IdentityProviders.ListIdentityProviders(opt)
+	if err != nil {
+		t.Fatalf("IdentityProviders.ListIdentityProviders returned error: %v", err)
+	}
+	if len(idps) != 2 || idps[1].Status != "INACTIVE" {
+		t.Errorf("client.IdentityProviders.ListIdentityProviders of all pages returned %+v", idps)
+	}
+}
+
+func TestActivateIdentityProvider(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/idps/0oa62bfdiumsUndnZ0h7/lifecycle/deactivate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"id": "0oa62bfdiumsUndnZ0h7", "type": "GOOGLE", "status": "INACTIVE"}`)
+	})
+
+	idp, _, err := client.IdentityProviders.ActivateIdentityProvider("0oa62bfdiumsUndnZ0h7", false)
+	if err != nil {
+		t.Fatalf("IdentityProviders.ActivateIdentityProvider returned error: %v", err)
+	}
+	if idp.Status != "INACTIVE" {
+		t.Errorf("client.IdentityProviders.ActivateIdentityProvider returned %+v", idp)
+	}
+}
+
+func TestNewSocialIdentityProvider(t *testing.T) {
+	idp, err := NewSocialIdentityProvider(IdpTypeMicrosoft, "Microsoft", "your-client-id", "your-client-secret", "email", "offline_access")
+	if err != nil {
+		t.Fatalf("NewSocialIdentityProvider returned error: %v", err)
+	}
+	want := []string{"email", "offline_access", "openid", "profile", "https://graph.microsoft.com/User.Read"}
+	if idp.Protocol.Type != IdpProtocolOIDC || !reflect.DeepEqual(idp.Protocol.Scopes, want) {
+		t.Errorf("NewSocialIdentityProvider returned protocol %+v, want scopes %v", idp.Protocol, want)
+	}
+	if idp.Policy.Subject.UserNameTemplate.Template != "idpuser.email" || idp.Protocol.Credentials.Client.ClientSecret != "your-client-secret" {
+		t.Errorf("NewSocialIdentityProvider returned %+v", idp)
+	}
+
+	scopes := make([]string, 1, 4)
+	scopes[0] = "email"
+	if _, err := NewSocialIdentityProvider(IdpTypeGoogle, "Google", "", "", scopes...); err != nil {
+		t.Fatalf("NewSocialIdentityProvider returned error: %v", err)
+	}
+	if spare := scopes[:4]; spare[1] != "" || spare[2] != "" || spare[3] != "" {
+		t.Errorf("NewSocialIdentityProvider wrote the required scopes into the array of the caller: %v", spare)
+	}
+
+	if scopes := IdpRequiredScopes(IdpTypeLinkedIn); !reflect.DeepEqual(scopes, []string{"r_emailaddress", "r_liteprofile"}) {
+		t.Errorf("IdpRequiredScopes returned %v", scopes)
+	}
+	if _, err := NewSocialIdentityProvider(IdpTypeSAML2, "Corp", "", ""); err == nil {
+		t.Errorf("NewSocialIdentityProvider of a SAML2 type returned no error")
+	}
+}
+
+func TestCreateSAMLIdentityProvider(t *testing.T) {
+	setup()
+	defer teardown()
+
+	idp := NewSAMLIdentityProvider("Corp IdP", "https://idp.example.com/saml2/sso", "https://idp.example.com", "https://www.okta.com/saml2/service-provider/spgv32vOnpdyeGSaiUpL", "your-key-id")
+	idp.Protocol.Settings.NameFormat = SAMLNameIDFormatEmail
+
+	mux.HandleFunc("/idps", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, json.RawMessage(`{"type":"SAML2","name":"Corp IdP","protocol":{"type":"SAML2",`+
+			`"endpoints":{"sso":{"url":"https://idp.example.com/saml2/sso","binding":"HTTP-POST","destination":"https://idp.example.com/saml2/sso"},"acs":{"binding":"HTTP-POST","type":"INSTANCE"}},`+
+			`"credentials":{"trust":{"issuer":"https://idp.example.com","audience":"https://www.okta.com/saml2/service-provider/spgv32vOnpdyeGSaiUpL","kid":"your-key-id"}},`+
+			`"algorithms":{"request":{"signature":{"algorithm":"SHA-256","scope":"REQUEST"}},"response":{"signature":{"algorithm":"SHA-256","scope":"ANY"}}},`+
+			`"settings":{"nameFormat":"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"}},`+
+			`"policy":{"provisioning":{"action":"AUTO","groups":{"action":"NONE"},"conditions":{"deprovisioned":{"action":"NONE"},"suspended":{"action":"NONE"}}},`+
+			`"accountLink":{"action":"AUTO"},"subject":{"userNameTemplate":{"template":"idpuser.subjectNameId"},"matchType":"USERNAME"}}}`))
+		created := idp
+		created.ID = "0oa62bc8wppPw0UGr0h7"
+		json.NewEncoder(w).Encode(created)
+	})
+
+	created, _, err := client.IdentityProviders.CreateIdentityProvider(idp)
+	if err != nil {
+		t.Fatalf("IdentityProviders.CreateIdentityProvider returned error: %v", err)
+	}
+	if created.ID != "0oa62bc8wppPw0UGr0h7" || created.Protocol.Credentials.Trust.Kid != "your-key-id" || created.Protocol.Endpoints.Sso.Binding != SAMLBindingPost {
+		t.Errorf("client.IdentityProviders.CreateIdentityProvider returned %+v", created)
+	}
+}
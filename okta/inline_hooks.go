@@ -0,0 +1,646 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Types of inline hooks
+const (
+	InlineHookTypeToken          = "com.okta.oauth2.tokens.transform"
+	InlineHookTypeSAML           = "com.okta.saml.tokens.transform"
+	InlineHookTypeRegistration   = "com.okta.user.pre-registration"
+	InlineHookTypePasswordImport = "com.okta.user.credential.password.import"
+	InlineHookTypeUserImport     = "com.okta.import.transform"
+
+	// InlineHookVersion is the version of the inline hook object and channel
+	InlineHookVersion = "1.0.0"
+	// InlineHookMaxRequestBytes is the largest request body an InlineHookHandler reads
+	InlineHookMaxRequestBytes = 1 << 20
+)
+
+// Types of the commands of an InlineHookResponse
+const (
+	InlineHookCommandIdentityPatch        = "com.okta.identity.patch"
+	InlineHookCommandAccessPatch          = "com.okta.access.patch"
+	InlineHookCommandAssertionPatch       = "com.okta.assertion.patch"
+	InlineHookCommandActionUpdate         = "com.okta.action.update"
+	InlineHookCommandUserProfileUpdate    = "com.okta.user.profile.update"
+	InlineHookCommandAppUserProfileUpdate = "com.okta.appUser.profile.update"
+	InlineHookCommandUserUpdate           = "com.okta.user.update"
+	inlineHookActionRegistrationAllow     = "ALLOW"
+	inlineHookActionRegistrationDeny      = "DENY"
+	inlineHookActionCredentialVerified    = "VERIFIED"
+	inlineHookActionCredentialUnverified  = "UNVERIFIED"
+	inlineHookActionImportCreateUser      = "CREATE_USER"
+	inlineHookActionImportLinkUser        = "LINK_USER"
+)
+
+// InlineHooksService handles communication with the Inline Hooks related
+// methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/inline-hooks/
+type InlineHooksService service
+
+// InlineHook is an endpoint OKTA calls during a process, for example token minting, to let it change the outcome
+type InlineHook struct {
+	ID          string             `json:"id,omitempty"`
+	Status      string             `json:"status,omitempty"`
+	Name        string             `json:"name,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Version     string             `json:"version,omitempty"`
+	Channel     *InlineHookChannel `json:"channel,omitempty"`
+	Created     *time.Time         `json:"created,omitempty"`
+	LastUpdated *time.Time         `json:"lastUpdated,omitempty"`
+}
+
+// InlineHookChannel is how OKTA calls an InlineHook
+type InlineHookChannel struct {
+	Type    string                   `json:"type,omitempty"`
+	Version string                   `json:"version,omitempty"`
+	Config  *InlineHookChannelConfig `json:"config,omitempty"`
+}
+
+// InlineHookChannelConfig is the endpoint and headers of an InlineHook, authenticated like an EventHook
+type InlineHookChannelConfig struct {
+	URI        string               `json:"uri,omitempty"`
+	Method     string               `json:"method,omitempty"`
+	Headers    []EventHookHeader    `json:"headers,omitempty"`
+	AuthScheme *EventHookAuthScheme `json:"authScheme,omitempty"`
+}
+
+// NewInlineHook returns an InlineHook of hookType calling uri, authenticated with secret in the authHeader header
+func NewInlineHook(name string, hookType string, uri string, authHeader string, secret string) InlineHook {
+	return InlineHook{
+		Name:    name,
+		Type:    hookType,
+		Version: InlineHookVersion,
+		Channel: &InlineHookChannel{
+			Type:    EventHookChannelTypeHTTP,
+			Version: InlineHookVersion,
+			Config: &InlineHookChannelConfig{
+				URI:        uri,
+				Method:     "POST",
+				AuthScheme: &EventHookAuthScheme{Type: EventHookAuthSchemeHeader, Key: authHeader, Value: secret},
+			},
+		},
+	}
+}
+
+// ListInlineHooks: List the Inline Hooks, of hookType or of every type when hookType is ""
+func (p *InlineHooksService) ListInlineHooks(hookType string) ([]InlineHook, *Response, error) {
+	u, err := addOptions("inlineHooks", &struct {
+		Type string `url:"type,omitempty"`
+	}{hookType})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var hooks []InlineHook
+	resp, err := p.client.do("GET", u, nil, &hooks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hooks, resp, err
+}
+
+// GetInlineHook: Get an Inline Hook
+// Requires InlineHook ID from InlineHook object
+func (p *InlineHooksService) GetInlineHook(id string) (*InlineHook, *Response, error) {
+	u := fmt.Sprintf("inlineHooks/%v", id)
+	return p.doHook("GET", u, nil)
+}
+
+// CreateInlineHook: Create an Inline Hook
+func (p *InlineHooksService) CreateInlineHook(hook InlineHook) (*InlineHook, *Response, error) {
+	return p.doHook("POST", "inlineHooks", hook)
+}
+
+// UpdateInlineHook: Replace an Inline Hook
+// Requires InlineHook ID from InlineHook object
+func (p *InlineHooksService) UpdateInlineHook(id string, hook InlineHook) (*InlineHook, *Response, error) {
+	u := fmt.Sprintf("inlineHooks/%v", id)
+	return p.doHook("PUT", u, hook)
+}
+
+// ActivateInlineHook: Activate/Deactivate an Inline Hook
+// Requires InlineHook ID from InlineHook object and a boolean to activate or deactivate
+func (p *InlineHooksService) ActivateInlineHook(id string, activate bool) (*InlineHook, *Response, error) {
+	u := fmt.Sprintf("inlineHooks/%v/lifecycle/%v", id, lifecycleAction(activate))
+	return p.doHook("POST", u, nil)
+}
+
+// DeleteInlineHook: Delete an Inline Hook. Only inactive hooks can be deleted
+// Requires InlineHook ID from InlineHook object
+func (p *InlineHooksService) DeleteInlineHook(id string) (*Response, error) {
+	u := fmt.Sprintf("inlineHooks/%v", id)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+// ExecuteInlineHook: Preview an Inline Hook by having OKTA call it with a sample request and return its response.
+// request is the payload of the hook, for example a TokenInlineHookRequest
+// Requires InlineHook ID from InlineHook object
+func (p *InlineHooksService) ExecuteInlineHook(id string, request interface{}) (*InlineHookResponse, *Response, error) {
+	u := fmt.Sprintf("inlineHooks/%v/execute", id)
+	response := new(InlineHookResponse)
+	resp, err := p.client.do("POST", u, request, response)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return response, resp, err
+}
+
+func (p *InlineHooksService) doHook(method string, u string, body interface{}) (*InlineHook, *Response, error) {
+	hook := new(InlineHook)
+	resp, err := p.client.do(method, u, body, hook)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hook, resp, err
+}
+
+// InlineHookRequest is the envelope of every request OKTA sends to an inline hook
+type InlineHookRequest struct {
+	Source            string     `json:"source,omitempty"`
+	EventID           string     `json:"eventId,omitempty"`
+	EventTime         *time.Time `json:"eventTime,omitempty"`
+	EventTypeVersion  string     `json:"eventTypeVersion,omitempty"`
+	CloudEventVersion string     `json:"cloudEventVersion,omitempty"`
+	ContentType       string     `json:"contentType,omitempty"`
+	EventType         string     `json:"eventType,omitempty"`
+}
+
+// InlineHookRequestContext is the request that triggered an inline hook
+type InlineHookRequestContext struct {
+	ID     string `json:"id,omitempty"`
+	Method string `json:"method,omitempty"`
+	URL    *struct {
+		Value string `json:"value,omitempty"`
+	} `json:"url,omitempty"`
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// InlineHookUser is the user an inline hook is called for
+type InlineHookUser struct {
+	ID              string                 `json:"id,omitempty"`
+	PasswordChanged *time.Time             `json:"passwordChanged,omitempty"`
+	Profile         map[string]interface{} `json:"profile,omitempty"`
+}
+
+// InlineHookTokenLifetime is the lifetime of a token or SAML assertion
+type InlineHookTokenLifetime struct {
+	Lifetime struct {
+		Expiration int `json:"expiration,omitempty"`
+	} `json:"lifetime"`
+}
+
+// TokenInlineHookRequest is the request of a token inline hook, called before OKTA mints ID and access tokens
+type TokenInlineHookRequest struct {
+	InlineHookRequest
+	Data struct {
+		Context struct {
+			Request  *InlineHookRequestContext `json:"request,omitempty"`
+			Protocol struct {
+				Type    string            `json:"type,omitempty"`
+				Request map[string]string `json:"request,omitempty"`
+				Issuer  struct {
+					URI string `json:"uri,omitempty"`
+				} `json:"issuer"`
+				Client struct {
+					ID   string `json:"id,omitempty"`
+					Name string `json:"name,omitempty"`
+					Type string `json:"type,omitempty"`
+				} `json:"client"`
+			} `json:"protocol"`
+			Session map[string]interface{} `json:"session,omitempty"`
+			User    *InlineHookUser        `json:"user,omitempty"`
+			Policy  *struct {
+				ID   string `json:"id,omitempty"`
+				Rule struct {
+					ID string `json:"id,omitempty"`
+				} `json:"rule"`
+			} `json:"policy,omitempty"`
+		} `json:"context"`
+		Identity *struct {
+			Claims map[string]interface{}  `json:"claims,omitempty"`
+			Token  InlineHookTokenLifetime `json:"token"`
+		} `json:"identity,omitempty"`
+		Access *struct {
+			Claims map[string]interface{}  `json:"claims,omitempty"`
+			Token  InlineHookTokenLifetime `json:"token"`
+			Scopes map[string]struct {
+				ID     string `json:"id,omitempty"`
+				Action string `json:"action,omitempty"`
+			} `json:"scopes,omitempty"`
+		} `json:"access,omitempty"`
+	} `json:"data"`
+}
+
+// SAMLInlineHookRequest is the request of a SAML assertion inline hook, called before OKTA signs an assertion
+type SAMLInlineHookRequest struct {
+	InlineHookRequest
+	Data struct {
+		Context struct {
+			Request  *InlineHookRequestContext `json:"request,omitempty"`
+			Protocol struct {
+				Type   string `json:"type,omitempty"`
+				Issuer struct {
+					ID   string `json:"id,omitempty"`
+					Name string `json:"name,omitempty"`
+					URI  string `json:"uri,omitempty"`
+				} `json:"issuer"`
+			} `json:"protocol"`
+			Session map[string]interface{} `json:"session,omitempty"`
+			User    *InlineHookUser        `json:"user,omitempty"`
+		} `json:"context"`
+		Assertion struct {
+			Subject struct {
+				NameID       string `json:"nameId,omitempty"`
+				NameFormat   string `json:"nameFormat,omitempty"`
+				Confirmation struct {
+					Method string            `json:"method,omitempty"`
+					Data   map[string]string `json:"data,omitempty"`
+				} `json:"confirmation"`
+			} `json:"subject"`
+			Authentication struct {
+				SessionIndex string `json:"sessionIndex,omitempty"`
+				AuthnContext struct {
+					AuthnContextClassRef string `json:"authnContextClassRef,omitempty"`
+				} `json:"authnContext"`
+			} `json:"authentication"`
+			Conditions struct {
+				AudienceRestriction []string `json:"audienceRestriction,omitempty"`
+			} `json:"conditions"`
+			Claims   map[string]SAMLAttribute `json:"claims,omitempty"`
+			Lifetime struct {
+				Expiration int `json:"expiration,omitempty"`
+			} `json:"lifetime"`
+		} `json:"assertion"`
+	} `json:"data"`
+}
+
+// SAMLAttribute is an attribute statement of a SAML assertion, as read and patched by a SAML inline hook
+type SAMLAttribute struct {
+	Attributes      map[string]string    `json:"attributes,omitempty"`
+	AttributeValues []SAMLAttributeValue `json:"attributeValues"`
+}
+
+// SAMLAttributeValue is a value of a SAMLAttribute
+type SAMLAttributeValue struct {
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Value      string            `json:"value"`
+}
+
+// RegistrationInlineHookRequest is the request of a registration inline hook, called before a user self-registers
+type RegistrationInlineHookRequest struct {
+	InlineHookRequest
+	Data struct {
+		Context struct {
+			Request *InlineHookRequestContext `json:"request,omitempty"`
+		} `json:"context"`
+		Action      string                 `json:"action,omitempty"`
+		UserProfile map[string]interface{} `json:"userProfile,omitempty"`
+	} `json:"data"`
+}
+
+// PasswordImportInlineHookRequest is the request of a password import inline hook, called when a user with an
+// imported password hook credential signs in
+type PasswordImportInlineHookRequest struct {
+	InlineHookRequest
+	Data struct {
+		Context struct {
+			Request    *InlineHookRequestContext `json:"request,omitempty"`
+			Credential struct {
+				Username string `json:"username"`
+				Password string `json:"password"`
+			} `json:"credential"`
+		} `json:"context"`
+		Action struct {
+			Credential string `json:"credential,omitempty"`
+		} `json:"action"`
+	} `json:"data"`
+}
+
+// UserImportInlineHookRequest is the request of a user import inline hook, called for every user an app import
+// brings in
+type UserImportInlineHookRequest struct {
+	InlineHookRequest
+	Data struct {
+		Context struct {
+			Conflicts   []string `json:"conflicts,omitempty"`
+			Application struct {
+				ID     string `json:"id,omitempty"`
+				Name   string `json:"name,omitempty"`
+				Label  string `json:"label,omitempty"`
+				Status string `json:"status,omitempty"`
+			} `json:"application"`
+			Job struct {
+				ID   string `json:"id,omitempty"`
+				Type string `json:"type,omitempty"`
+			} `json:"job"`
+			Matches []map[string]interface{} `json:"matches,omitempty"`
+			Policy  []string                 `json:"policy,omitempty"`
+		} `json:"context"`
+		Action struct {
+			Result string `json:"result,omitempty"`
+		} `json:"action"`
+		AppUser struct {
+			Profile map[string]interface{} `json:"profile,omitempty"`
+		} `json:"appUser"`
+		User struct {
+			Profile map[string]interface{} `json:"profile,omitempty"`
+		} `json:"user"`
+	} `json:"data"`
+}
+
+// InlineHookResponse is the response of an inline hook: the commands OKTA applies and an optional error
+type InlineHookResponse struct {
+	Commands     []InlineHookCommand    `json:"commands,omitempty"`
+	Error        *InlineHookError       `json:"error,omitempty"`
+	DebugContext map[string]interface{} `json:"debugContext,omitempty"`
+}
+
+// InlineHookCommand is a command of an InlineHookResponse. Value is a list of InlineHookPatch for the patch
+// commands, an object for the update commands
+type InlineHookCommand struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// InlineHookPatch is a JSON Patch operation of a patch command. Op is add, replace or remove
+type InlineHookPatch struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON leaves out the value of a remove operation only, keeping false, 0 and "" values
+func (p InlineHookPatch) MarshalJSON() ([]byte, error) {
+	if p.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{p.Op, p.Path})
+	}
+	type inlineHookPatch InlineHookPatch
+	return json.Marshal(inlineHookPatch(p))
+}
+
+// InlineHookError is the error of an InlineHookResponse. OKTA fails the process, for example a
+// registration shows ErrorSummary and ErrorCauses to the user
+type InlineHookError struct {
+	ErrorSummary string                 `json:"errorSummary"`
+	ErrorCauses  []InlineHookErrorCause `json:"errorCauses,omitempty"`
+}
+
+// InlineHookErrorCause is a cause of an InlineHookError. Location is the profile attribute of a registration
+// error, for example data.userProfile.login
+type InlineHookErrorCause struct {
+	ErrorSummary string `json:"errorSummary"`
+	Reason       string `json:"reason,omitempty"`
+	LocationType string `json:"locationType,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Domain       string `json:"domain,omitempty"`
+}
+
+// Patch adds operations to the patch command commandType, for example InlineHookCommandAccessPatch
+func (r *InlineHookResponse) Patch(commandType string, ops ...InlineHookPatch) {
+	for i, command := range r.Commands {
+		if patches, ok := command.Value.([]InlineHookPatch); ok && command.Type == commandType {
+			r.Commands[i].Value = append(patches, ops...)
+			return
+		}
+	}
+	r.Commands = append(r.Commands, InlineHookCommand{Type: commandType, Value: ops})
+}
+
+// update merges values into the update command commandType
+func (r *InlineHookResponse) update(commandType string, values map[string]interface{}) {
+	for _, command := range r.Commands {
+		if existing, ok := command.Value.(map[string]interface{}); ok && command.Type == commandType {
+			for k, v := range values {
+				existing[k] = v
+			}
+			return
+		}
+	}
+	merged := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		merged[k] = v
+	}
+	r.Commands = append(r.Commands, InlineHookCommand{Type: commandType, Value: merged})
+}
+
+// AddIDTokenClaim adds or replaces a claim of the ID token, for a token inline hook
+func (r *InlineHookResponse) AddIDTokenClaim(name string, value interface{}) {
+	r.Patch(InlineHookCommandIdentityPatch, InlineHookPatch{Op: "add", Path: "/claims/" + name, Value: value})
+}
+
+// AddAccessTokenClaim adds or replaces a claim of the access token, for a token inline hook
+func (r *InlineHookResponse) AddAccessTokenClaim(name string, value interface{}) {
+	r.Patch(InlineHookCommandAccessPatch, InlineHookPatch{Op: "add", Path: "/claims/" + name, Value: value})
+}
+
+// RemoveAccessTokenClaim removes a claim, added by a policy rule, of the access token, for a token inline hook
+func (r *InlineHookResponse) RemoveAccessTokenClaim(name string) {
+	r.Patch(InlineHookCommandAccessPatch, InlineHookPatch{Op: "remove", Path: "/claims/" + name})
+}
+
+// SetAccessTokenLifetime replaces the lifetime of the access token, for a token inline hook
+func (r *InlineHookResponse) SetAccessTokenLifetime(lifetime time.Duration) {
+	r.Patch(InlineHookCommandAccessPatch, InlineHookPatch{Op: "replace", Path: "/token/lifetime/expiration", Value: int(lifetime / time.Second)})
+}
+
+// AddAssertionAttribute adds an attribute statement to the assertion, for a SAML inline hook
+func (r *InlineHookResponse) AddAssertionAttribute(name string, attribute SAMLAttribute) {
+	r.Patch(InlineHookCommandAssertionPatch, InlineHookPatch{Op: "add", Path: "/claims/" + name, Value: attribute})
+}
+
+// ReplaceAssertion replaces the value at path of the assertion, for example "/subject/nameId", for a SAML
+// inline hook
+func (r *InlineHookResponse) ReplaceAssertion(path string, value interface{}) {
+	r.Patch(InlineHookCommandAssertionPatch, InlineHookPatch{Op: "replace", Path: path, Value: value})
+}
+
+// UpdateUserProfile sets attributes of the profile of the user, for a registration or user import inline hook
+func (r *InlineHookResponse) UpdateUserProfile(attributes map[string]interface{}) {
+	r.update(InlineHookCommandUserProfileUpdate, attributes)
+}
+
+// AllowRegistration lets the user register, for a registration inline hook
+func (r *InlineHookResponse) AllowRegistration() {
+	r.update(InlineHookCommandActionUpdate, map[string]interface{}{"registration": inlineHookActionRegistrationAllow})
+}
+
+// DenyRegistration rejects the registration of the user, showing summary and causes
+func (r *InlineHookResponse) DenyRegistration(summary string, causes ...InlineHookErrorCause) {
+	r.update(InlineHookCommandActionUpdate, map[string]interface{}{"registration": inlineHookActionRegistrationDeny})
+	r.Error = &InlineHookError{ErrorSummary: summary, ErrorCauses: causes}
+}
+
+// SetCredentialVerified reports if the password of the user was verified, for a password import inline hook.
+// OKTA stores a verified password and stops calling the hook for the user
+func (r *InlineHookResponse) SetCredentialVerified(verified bool) {
+	credential := inlineHookActionCredentialUnverified
+	if verified {
+		credential = inlineHookActionCredentialVerified
+	}
+	r.update(InlineHookCommandActionUpdate, map[string]interface{}{"credential": credential})
+}
+
+// UpdateAppUserProfile sets attributes of the imported app user profile, for a user import inline hook
+func (r *InlineHookResponse) UpdateAppUserProfile(attributes map[string]interface{}) {
+	r.update(InlineHookCommandAppUserProfileUpdate, attributes)
+}
+
+// CreateImportUser creates a new user for the imported user, for a user import inline hook
+func (r *InlineHookResponse) CreateImportUser() {
+	r.update(InlineHookCommandActionUpdate, map[string]interface{}{"result": inlineHookActionImportCreateUser})
+}
+
+// LinkImportUser links the imported user to the existing user userID, for a user import inline hook
+func (r *InlineHookResponse) LinkImportUser(userID string) {
+	r.update(InlineHookCommandActionUpdate, map[string]interface{}{"result": inlineHookActionImportLinkUser})
+	r.update(InlineHookCommandUserUpdate, map[string]interface{}{"id": userID})
+}
+
+// TokenInlineHookFunc handles a token inline hook request, adding commands to resp. A returned error fails the
+// call, OKTA then mints the tokens unchanged or fails depending on the hook
+type TokenInlineHookFunc func(req *TokenInlineHookRequest, resp *InlineHookResponse) error
+
+// SAMLInlineHookFunc handles a SAML inline hook request, adding commands to resp
+type SAMLInlineHookFunc func(req *SAMLInlineHookRequest, resp *InlineHookResponse) error
+
+// RegistrationInlineHookFunc handles a registration inline hook request, adding commands to resp
+type RegistrationInlineHookFunc func(req *RegistrationInlineHookRequest, resp *InlineHookResponse) error
+
+// PasswordImportInlineHookFunc handles a password import inline hook request, adding commands to resp
+type PasswordImportInlineHookFunc func(req *PasswordImportInlineHookRequest, resp *InlineHookResponse) error
+
+// UserImportInlineHookFunc handles a user import inline hook request, adding commands to resp
+type UserImportInlineHookFunc func(req *UserImportInlineHookRequest, resp *InlineHookResponse) error
+
+// NewTokenInlineHookHandler returns an InlineHookHandler for a token inline hook authenticated with secret in the
+// authHeader header, the Key and Value of the EventHookAuthScheme of the hook. A handler with an empty secret
+// rejects every request, set AllowUnauthenticated to accept them
+func NewTokenInlineHookHandler(authHeader string, secret string, fn TokenInlineHookFunc) *InlineHookHandler {
+	return inlineHookHandler(authHeader, secret, func(decode func(interface{}) error, resp *InlineHookResponse) error {
+		req := new(TokenInlineHookRequest)
+		if err := decode(req); err != nil {
+			return err
+		}
+		return fn(req, resp)
+	})
+}
+
+// NewSAMLInlineHookHandler returns an InlineHookHandler for a SAML assertion inline hook
+func NewSAMLInlineHookHandler(authHeader string, secret string, fn SAMLInlineHookFunc) *InlineHookHandler {
+	return inlineHookHandler(authHeader, secret, func(decode func(interface{}) error, resp *InlineHookResponse) error {
+		req := new(SAMLInlineHookRequest)
+		if err := decode(req); err != nil {
+			return err
+		}
+		return fn(req, resp)
+	})
+}
+
+// NewRegistrationInlineHookHandler returns an InlineHookHandler for a registration inline hook
+func NewRegistrationInlineHookHandler(authHeader string, secret string, fn RegistrationInlineHookFunc) *InlineHookHandler {
+	return inlineHookHandler(authHeader, secret, func(decode func(interface{}) error, resp *InlineHookResponse) error {
+		req := new(RegistrationInlineHookRequest)
+		if err := decode(req); err != nil {
+			return err
+		}
+		return fn(req, resp)
+	})
+}
+
+// NewPasswordImportInlineHookHandler returns an InlineHookHandler for a password import inline hook
+func NewPasswordImportInlineHookHandler(authHeader string, secret string, fn PasswordImportInlineHookFunc) *InlineHookHandler {
+	return inlineHookHandler(authHeader, secret, func(decode func(interface{}) error, resp *InlineHookResponse) error {
+		req := new(PasswordImportInlineHookRequest)
+		if err := decode(req); err != nil {
+			return err
+		}
+		return fn(req, resp)
+	})
+}
+
+// NewUserImportInlineHookHandler returns an InlineHookHandler for a user import inline hook
+func NewUserImportInlineHookHandler(authHeader string, secret string, fn UserImportInlineHookFunc) *InlineHookHandler {
+	return inlineHookHandler(authHeader, secret, func(decode func(interface{}) error, resp *InlineHookResponse) error {
+		req := new(UserImportInlineHookRequest)
+		if err := decode(req); err != nil {
+			return err
+		}
+		return fn(req, resp)
+	})
+}
+
+// errInlineHookRequest marks an error decoding the request of an inline hook
+type errInlineHookRequest struct{ err error }
+
+func (e errInlineHookRequest) Error() string {
+	return fmt.Sprintf("invalid inline hook request: %v", e.err)
+}
+
+// InlineHookHandler is an http.Handler answering the calls of an inline hook. It rejects requests without the
+// shared secret in the auth scheme header and writes the commands added by the InlineHookFunc of the hook. Without
+// a Secret every request is rejected, unless AllowUnauthenticated is set for a hook that has no auth scheme
+type InlineHookHandler struct {
+	AuthHeader           string
+	Secret               string
+	AllowUnauthenticated bool
+
+	handle func(decode func(interface{}) error, resp *InlineHookResponse) error
+}
+
+// inlineHookHandler returns an InlineHookHandler writing the response built by handle
+func inlineHookHandler(authHeader string, secret string, handle func(decode func(interface{}) error, resp *InlineHookResponse) error) *InlineHookHandler {
+	return &InlineHookHandler{AuthHeader: authHeader, Secret: secret, handle: handle}
+}
+
+func (h *InlineHookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authenticated(r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, InlineHookMaxRequestBytes)
+	decode := func(v interface{}) error {
+		if err := json.NewDecoder(body).Decode(v); err != nil {
+			return errInlineHookRequest{err}
+		}
+		return nil
+	}
+	resp := new(InlineHookResponse)
+	if err := h.handle(decode, resp); err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := err.(errInlineHookRequest); ok {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaTypeJSON)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// authenticated reports if r holds the secret of the handler. Requests to a handler without a secret are only
+// authenticated with AllowUnauthenticated
+func (h *InlineHookHandler) authenticated(r *http.Request) bool {
+	if h.Secret == "" {
+		return h.AllowUnauthenticated
+	}
+	return hookAuthenticated(r, h.AuthHeader, h.Secret)
+}
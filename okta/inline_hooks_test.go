@@ -0,0 +1,275 @@
+package okta
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in test_data")
+
+// testInlineHookGolden posts the golden request test_data/inline_hooks/<name>_request.json to handler and
+// compares its response to test_data/inline_hooks/<name>_response.json
+func testInlineHookGolden(t *testing.T, name string, handler http.Handler) {
+	dir, err := filepath.Abs("../test_data/inline_hooks")
+	if err != nil {
+		t.Fatalf("failed to resolve path, error %v", err)
+	}
+	request, err := ioutil.ReadFile(filepath.Join(dir, name+"_request.json"))
+	if err != nil {
+		t.Fatalf("failed to load %s request, error %v", name, err)
+	}
+
+	r := httptest.NewRequest("POST", "/okta/hooks/"+name, bytes.NewReader(request))
+	r.Header.Set("Authorization", "my-shared-secret")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("%v inline hook handler returned %v %v", name, w.Code, w.Body.String())
+	}
+
+	golden := filepath.Join(dir, name+"_response.json")
+	if *updateGolden {
+		var indented bytes.Buffer
+		json.Indent(&indented, w.Body.Bytes(), "", "    ")
+		if err := ioutil.WriteFile(golden, indented.Bytes(), 0644); err != nil {
+			t.Fatalf("failed to update %s, error %v", golden, err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to load %s, error %v", golden, err)
+	}
+
+	var got, wantJSON interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("%v inline hook handler returned invalid JSON %v", name, w.Body.String())
+	}
+	json.Unmarshal(want, &wantJSON)
+	if !reflect.DeepEqual(got, wantJSON) {
+		t.Errorf("%v inline hook handler returned \n\t%v, want \n\t%v\n", name, w.Body.String(), string(want))
+	}
+}
+
+func TestTokenInlineHookHandler(t *testing.T) {
+	handler := NewTokenInlineHookHandler("", "my-shared-secret", func(req *TokenInlineHookRequest, resp *InlineHookResponse) error {
+		if req.Data.Context.Protocol.Client.ID != "0oabskvc6442nkvQO0h7" || req.Data.Access.Scopes["openid"].Action != "GRANT" || req.Data.Identity.Token.Lifetime.Expiration != 3600 {
+			t.Errorf("TokenInlineHookRequest decoded %+v", req.Data)
+		}
+		resp.AddIDTokenClaim("extPatientId", "1234")
+		resp.AddAccessTokenClaim("external_guid", "F0384685-F87D-474B-848D-2058AC5655A7")
+		resp.AddAccessTokenClaim("admin", false)
+		resp.RemoveAccessTokenClaim("firstName")
+		resp.SetAccessTokenLifetime(10 * time.Hour)
+		return nil
+	})
+	testInlineHookGolden(t, "token", handler)
+}
+
+func TestSAMLInlineHookHandler(t *testing.T) {
+	handler := NewSAMLInlineHookHandler("", "my-shared-secret", func(req *SAMLInlineHookRequest, resp *InlineHookResponse) error {
+		if req.Data.Assertion.Claims["extPatientId"].AttributeValues[0].Value != "4321" || req.Data.Assertion.Subject.Confirmation.Data["recipient"] == "" {
+			t.Errorf("SAMLInlineHookRequest decoded %+v", req.Data.Assertion)
+		}
+		resp.ReplaceAssertion("/subject/nameId", "jane.doe@example.com")
+		resp.AddAssertionAttribute("department", SAMLAttribute{
+			Attributes:      map[string]string{"NameFormat": "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"},
+			AttributeValues: []SAMLAttributeValue{{Attributes: map[string]string{"xsi:type": "xs:string"}, Value: "Engineering"}},
+		})
+		return nil
+	})
+	testInlineHookGolden(t, "saml", handler)
+}
+
+func TestRegistrationInlineHookHandler(t *testing.T) {
+	handler := NewRegistrationInlineHookHandler("", "my-shared-secret", func(req *RegistrationInlineHookRequest, resp *InlineHookResponse) error {
+		if req.Data.UserProfile["email"] != "rosario.jones@example.com" {
+			t.Errorf("RegistrationInlineHookRequest decoded %+v", req.Data)
+		}
+		resp.DenyRegistration("Incorrect email address. Please contact your admin.", InlineHookErrorCause{
+			ErrorSummary: "Only example.org emails can register.",
+			Reason:       "INVALID_EMAIL_DOMAIN",
+			LocationType: "body",
+			Location:     "data.userProfile.email",
+			Domain:       "end-user",
+		})
+		return nil
+	})
+	testInlineHookGolden(t, "registration", handler)
+}
+
+func TestPasswordImportInlineHookHandler(t *testing.T) {
+	handler := NewPasswordImportInlineHookHandler("", "my-shared-secret", func(req *PasswordImportInlineHookRequest, resp *InlineHookResponse) error {
+		resp.SetCredentialVerified(req.Data.Context.Credential.Username == "isaac.brock@example.com" && req.Data.Context.Credential.Password == "Pa$$w0rd")
+		return nil
+	})
+	testInlineHookGolden(t, "password_import", handler)
+}
+
+func TestUserImportInlineHookHandler(t *testing.T) {
+	handler := NewUserImportInlineHookHandler("", "my-shared-secret", func(req *UserImportInlineHookRequest, resp *InlineHookResponse) error {
+		if req.Data.Context.Conflicts[0] != "login" || req.Data.AppUser.Profile["accountType"] != "PRO" {
+			t.Errorf("UserImportInlineHookRequest decoded %+v", req.Data)
+		}
+		resp.UpdateUserProfile(map[string]interface{}{"firstName": "Sally"})
+		resp.UpdateUserProfile(map[string]interface{}{"nickName": "Sal"})
+		resp.LinkImportUser("00garwpuyxHaWOkdV0g4")
+		return nil
+	})
+	testInlineHookGolden(t, "user_import", handler)
+}
+
+func TestInlineHookHandlerErrors(t *testing.T) {
+	handler := NewPasswordImportInlineHookHandler("X-Hook-Secret", "my-shared-secret", func(req *PasswordImportInlineHookRequest, resp *InlineHookResponse) error {
+		return errors.New("legacy directory unavailable")
+	})
+
+	tests := []struct {
+		method string
+		secret string
+		body   string
+		want   int
+	}{
+		{"POST", "wrong-secret", `{}`, http.StatusUnauthorized},
+		{"GET", "my-shared-secret", ``, http.StatusMethodNotAllowed},
+		{"POST", "my-shared-secret", `{"data":`, http.StatusBadRequest},
+		{"POST", "my-shared-secret", `{}`, http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, "/okta/hooks/password", bytes.NewReader([]byte(test.body)))
+		r.Header.Set("X-Hook-Secret", test.secret)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != test.want {
+			t.Errorf("inline hook handler %v with %v returned %v, want %v", test.method, test.body, w.Code, test.want)
+		}
+	}
+}
+
+func TestInlineHookLifecycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hook := NewInlineHook("Token hook", InlineHookTypeToken, "https://example.com/okta/hooks/token", "Authorization", "my-shared-secret")
+	want := hook
+	want.ID = "calr0dvWvbMQJHZCM0g3"
+	want.Status = "ACTIVE"
+
+	mux.HandleFunc("/inlineHooks", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		switch r.Method {
+		case "POST":
+			testBody(t, r, hook)
+			json.NewEncoder(w).Encode(want)
+		default:
+			testMethod(t, r, "GET")
+			if got := r.URL.Query().Get("type"); got != InlineHookTypeToken {
+				t.Errorf("InlineHooks.ListInlineHooks sent type=%v, want %v", got, InlineHookTypeToken)
+			}
+			json.NewEncoder(w).Encode([]InlineHook{want})
+		}
+	})
+	mux.HandleFunc("/inlineHooks/calr0dvWvbMQJHZCM0g3/execute", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		w.Write([]byte(`{"commands":[{"type":"com.okta.access.patch","value":[{"op":"add","path":"/claims/extPatientId","value":"1234"}]}]}`))
+	})
+	mux.HandleFunc("/inlineHooks/calr0dvWvbMQJHZCM0g3/lifecycle/deactivate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		inactive := want
+		inactive.Status = "INACTIVE"
+		json.NewEncoder(w).Encode(inactive)
+	})
+	mux.HandleFunc("/inlineHooks/calr0dvWvbMQJHZCM0g3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	created, _, err := client.InlineHooks.CreateInlineHook(hook)
+	if err != nil {
+		t.Fatalf("InlineHooks.CreateInlineHook returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*created, want) {
+		t.Errorf("client.InlineHooks.CreateInlineHook returned \n\t%+v, want \n\t%+v\n", created, want)
+	}
+
+	hooks, _, err := client.InlineHooks.ListInlineHooks(InlineHookTypeToken)
+	if err != nil {
+		t.Errorf("InlineHooks.ListInlineHooks returned error: %v", err)
+	}
+	if len(hooks) != 1 || hooks[0].Channel.Config.Method != "POST" {
+		t.Errorf("client.InlineHooks.ListInlineHooks returned %+v", hooks)
+	}
+
+	preview, _, err := client.InlineHooks.ExecuteInlineHook(created.ID, &TokenInlineHookRequest{})
+	if err != nil {
+		t.Errorf("InlineHooks.ExecuteInlineHook returned error: %v", err)
+	}
+	if len(preview.Commands) != 1 || preview.Commands[0].Type != InlineHookCommandAccessPatch {
+		t.Errorf("client.InlineHooks.ExecuteInlineHook returned %+v", preview)
+	}
+
+	inactive, _, err := client.InlineHooks.ActivateInlineHook(created.ID, false)
+	if err != nil {
+		t.Errorf("InlineHooks.ActivateInlineHook returned error: %v", err)
+	}
+	if inactive.Status != "INACTIVE" {
+		t.Errorf("client.InlineHooks.ActivateInlineHook returned %+v", inactive)
+	}
+
+	if _, err := client.InlineHooks.DeleteInlineHook(created.ID); err != nil {
+		t.Errorf("InlineHooks.DeleteInlineHook returned error: %v", err)
+	}
+}
+
+func TestInlineHookHandlerWithoutSecret(t *testing.T) {
+	var called int
+	handler := NewPasswordImportInlineHookHandler("", "", func(req *PasswordImportInlineHookRequest, resp *InlineHookResponse) error {
+		called++
+		resp.SetCredentialVerified(true)
+		return nil
+	})
+
+	call := func() int {
+		r := httptest.NewRequest("POST", "/okta/hooks/password", bytes.NewReader([]byte(`{}`)))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := call(); code != http.StatusUnauthorized || called != 0 {
+		t.Errorf("inline hook handler without secret returned %v and called the hook %v times, want %v", code, called, http.StatusUnauthorized)
+	}
+	handler.AllowUnauthenticated = true
+	if code := call(); code != http.StatusOK || called != 1 {
+		t.Errorf("inline hook handler with AllowUnauthenticated returned %v and called the hook %v times", code, called)
+	}
+}
+
+func TestInlineHookHandlerLargeRequest(t *testing.T) {
+	var called int
+	handler := NewPasswordImportInlineHookHandler("", "my-shared-secret", func(req *PasswordImportInlineHookRequest, resp *InlineHookResponse) error {
+		called++
+		return nil
+	})
+
+	body := strings.Repeat(" ", InlineHookMaxRequestBytes) + `{}`
+	r := httptest.NewRequest("POST", "/okta/hooks/password", strings.NewReader(body))
+	r.Header.Set("Authorization", "my-shared-secret")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest || called != 0 {
+		t.Errorf("inline hook handler request larger than %v bytes returned %v and called the hook %v times", InlineHookMaxRequestBytes, w.Code, called)
+	}
+}
@@ -0,0 +1,409 @@
+package okta
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // hashes used by the RS256 & ES256 families
+	_ "crypto/sha512" // hashes used by the RS384, RS512, ES384 & ES512 families
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJWTClockSkew = 2 * time.Minute
+	// minimum time between two refreshes of the keys for an unknown kid, so tokens with random
+	// kids can not be used to hammer the keys endpoint
+	minJWKSRefreshInterval = 30 * time.Second
+)
+
+var (
+	// ErrJWTExpired is returned for a token whose exp is in the past, allowing for the clock skew
+	ErrJWTExpired = errors.New("[ERROR] jwt is expired")
+	// ErrJWTNotValidYet is returned for a token whose nbf or iat is in the future, allowing for the clock skew
+	ErrJWTNotValidYet = errors.New("[ERROR] jwt is not valid yet")
+	// ErrJWTInvalidSignature is returned when the signature of a token does not verify
+	ErrJWTInvalidSignature = errors.New("[ERROR] jwt signature is invalid")
+)
+
+// JWTVerifier verifies the signature and claims of access and ID tokens issued by an OKTA authorization
+// server without calling OKTA for every token. The metadata and the signing keys (JWKS) of the issuer
+// are fetched on first use and cached. The keys are fetched again when a token is signed with an unknown
+// kid, for example after a key rotation. A JWTVerifier is safe for concurrent use
+type JWTVerifier struct {
+	// Issuer of the tokens, for example https://your-domain.okta.com/oauth2/default
+	Issuer string
+	// Audience expected in the aud claim of access tokens, for example api://default
+	Audience string
+	// ClientID expected in the cid claim of access tokens and the aud claim of ID tokens. Not checked for access tokens when empty
+	ClientID string
+	// ClockSkew allowed when checking exp, nbf and iat. A zero ClockSkew allows 2 minutes
+	ClockSkew time.Duration
+	// HTTPClient used to fetch the metadata and keys. Defaults to http.DefaultClient
+	HTTPClient *http.Client
+
+	now func() time.Time
+
+	mu          sync.Mutex
+	jwksURI     string
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+// NewJWTVerifier returns a JWTVerifier for the tokens of issuer with the audience and client ID
+func NewJWTVerifier(issuer string, audience string, clientID string) *JWTVerifier {
+	return &JWTVerifier{
+		Issuer:    strings.TrimSuffix(issuer, "/"),
+		Audience:  audience,
+		ClientID:  clientID,
+		ClockSkew: defaultJWTClockSkew,
+	}
+}
+
+// NumericDate is a JWT date, the number of seconds since the Unix epoch
+type NumericDate int64
+
+// Time returns the date as a time.Time
+func (d NumericDate) Time() time.Time {
+	return time.Unix(int64(d), 0)
+}
+
+// JWTAudience is the aud claim, which is either a string or a list of strings
+type JWTAudience []string
+
+// UnmarshalJSON decodes a single audience or a list of audiences
+func (a *JWTAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = JWTAudience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = JWTAudience(list)
+	return nil
+}
+
+// Contains reports whether aud is one of the audiences
+func (a JWTAudience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// JWTClaims are the registered claims of a JWT. Raw holds every claim of the token, including custom
+// claims, with numbers decoded as json.Number
+type JWTClaims struct {
+	Issuer    string                 `json:"iss,omitempty"`
+	Subject   string                 `json:"sub,omitempty"`
+	Audience  JWTAudience            `json:"aud,omitempty"`
+	ExpiresAt NumericDate            `json:"exp,omitempty"`
+	NotBefore NumericDate            `json:"nbf,omitempty"`
+	IssuedAt  NumericDate            `json:"iat,omitempty"`
+	ID        string                 `json:"jti,omitempty"`
+	Version   int                    `json:"ver,omitempty"`
+	Raw       map[string]interface{} `json:"-"`
+}
+
+// AccessTokenClaims are the claims of an OKTA access token
+type AccessTokenClaims struct {
+	JWTClaims
+	ClientID string   `json:"cid,omitempty"`
+	UserID   string   `json:"uid,omitempty"`
+	Scopes   []string `json:"scp,omitempty"`
+}
+
+// IDTokenClaims are the claims of an OKTA ID token
+type IDTokenClaims struct {
+	JWTClaims
+	Nonce             string      `json:"nonce,omitempty"`
+	AuthTime          NumericDate `json:"auth_time,omitempty"`
+	AMR               []string    `json:"amr,omitempty"`
+	IDP               string      `json:"idp,omitempty"`
+	Name              string      `json:"name,omitempty"`
+	Email             string      `json:"email,omitempty"`
+	PreferredUsername string      `json:"preferred_username,omitempty"`
+	AtHash            string      `json:"at_hash,omitempty"`
+}
+
+// jwtHeader is the JOSE header of a JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// VerifyAccessToken verifies the signature, issuer, audience, lifetime and client ID of an access token
+func (v *JWTVerifier) VerifyAccessToken(token string) (*AccessTokenClaims, error) {
+	claims := new(AccessTokenClaims)
+	if err := v.verify(token, claims, &claims.JWTClaims); err != nil {
+		return nil, err
+	}
+	if !claims.Audience.Contains(v.Audience) {
+		return nil, fmt.Errorf("[ERROR] jwt aud %v does not contain %v", claims.Audience, v.Audience)
+	}
+	if v.ClientID != "" && claims.ClientID != v.ClientID {
+		return nil, fmt.Errorf("[ERROR] jwt cid %v is not %v", claims.ClientID, v.ClientID)
+	}
+	return claims, nil
+}
+
+// VerifyIDToken verifies the signature, issuer, audience (the client ID), lifetime and nonce of an ID token.
+// The nonce is not checked when empty
+func (v *JWTVerifier) VerifyIDToken(token string, nonce string) (*IDTokenClaims, error) {
+	claims := new(IDTokenClaims)
+	if err := v.verify(token, claims, &claims.JWTClaims); err != nil {
+		return nil, err
+	}
+	if !claims.Audience.Contains(v.ClientID) {
+		return nil, fmt.Errorf("[ERROR] jwt aud %v does not contain %v", claims.Audience, v.ClientID)
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, errors.New("[ERROR] jwt nonce does not match")
+	}
+	return claims, nil
+}
+
+// verify checks the signature, issuer and lifetime of token and decodes its claims into claims
+func (v *JWTVerifier) verify(token string, claims interface{}, registered *JWTClaims) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errors.New("[ERROR] jwt must have 3 parts")
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return fmt.Errorf("[ERROR] jwt header is invalid: %v", err)
+	}
+	hash, err := jwtHash(header.Alg)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("[ERROR] jwt signature is invalid: %v", err)
+	}
+
+	key, err := v.key(header.Kid)
+	if err != nil {
+		return err
+	}
+	if err := verifyJWTSignature(header.Alg, hash, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return err
+	}
+
+	if err := decodeJWTSegment(parts[1], claims); err != nil {
+		return fmt.Errorf("[ERROR] jwt claims are invalid: %v", err)
+	}
+	if err := decodeJWTSegment(parts[1], &registered.Raw); err != nil {
+		return fmt.Errorf("[ERROR] jwt claims are invalid: %v", err)
+	}
+
+	if registered.Issuer != v.Issuer {
+		return fmt.Errorf("[ERROR] jwt iss %v is not %v", registered.Issuer, v.Issuer)
+	}
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+	skew := v.ClockSkew
+	if skew == 0 {
+		skew = defaultJWTClockSkew
+	}
+	if registered.ExpiresAt == 0 || now.Add(-skew).After(registered.ExpiresAt.Time()) {
+		return ErrJWTExpired
+	}
+	if registered.NotBefore != 0 && now.Add(skew).Before(registered.NotBefore.Time()) {
+		return ErrJWTNotValidYet
+	}
+	if registered.IssuedAt != 0 && now.Add(skew).Before(registered.IssuedAt.Time()) {
+		return ErrJWTNotValidYet
+	}
+	return nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// jwtHash returns the hash of a supported signing algorithm. Symmetric algorithms and "none" are rejected
+func jwtHash(alg string) (crypto.Hash, error) {
+	switch alg {
+	case "RS256", "ES256":
+		return crypto.SHA256, nil
+	case "RS384", "ES384":
+		return crypto.SHA384, nil
+	case "RS512", "ES512":
+		return crypto.SHA512, nil
+	}
+	return 0, fmt.Errorf("[ERROR] jwt alg %v is not supported", alg)
+}
+
+func verifyJWTSignature(alg string, hash crypto.Hash, key crypto.PublicKey, signed []byte, signature []byte) error {
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("[ERROR] jwt alg %v does not match an RSA key", alg)
+		}
+		if rsa.VerifyPKCS1v15(k, hash, digest, signature) != nil {
+			return ErrJWTInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("[ERROR] jwt alg %v does not match an EC key", alg)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrJWTInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return ErrJWTInvalidSignature
+		}
+	default:
+		return fmt.Errorf("[ERROR] jwt key type %T is not supported", key)
+	}
+	return nil
+}
+
+// key returns the signing key with kid, fetching the keys of the issuer when kid is not cached
+func (v *JWTVerifier) key(kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	if v.keys != nil && time.Since(v.lastRefresh) < minJWKSRefreshInterval {
+		return nil, fmt.Errorf("[ERROR] jwt kid %v is not a signing key of %v", kid, v.Issuer)
+	}
+	if err := v.refreshKeys(); err != nil {
+		return nil, err
+	}
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("[ERROR] jwt kid %v is not a signing key of %v", kid, v.Issuer)
+}
+
+// refreshKeys fetches the keys of the issuer, discovering the jwks_uri from the issuer metadata
+// the first time. v.mu must be held
+func (v *JWTVerifier) refreshKeys() error {
+	if v.jwksURI == "" {
+		metadata := struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}{}
+		if err := v.getJSON(v.Issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+			return err
+		}
+		if metadata.Issuer != v.Issuer {
+			return fmt.Errorf("[ERROR] metadata issuer %v is not %v", metadata.Issuer, v.Issuer)
+		}
+		if metadata.JWKSURI == "" {
+			return fmt.Errorf("[ERROR] metadata of %v has no jwks_uri", v.Issuer)
+		}
+		v.jwksURI = metadata.JWKSURI
+	}
+
+	var jwks struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+	if err := v.getJSON(v.jwksURI, &jwks); err != nil {
+		return err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	v.keys = keys
+	v.lastRefresh = time.Now()
+	return nil
+}
+
+func (v *JWTVerifier) getJSON(u string, out interface{}) error {
+	httpClient := v.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("[ERROR] GET %v returned HTTP Status Code: %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// PublicKey returns the *rsa.PublicKey or *ecdsa.PublicKey of a JSONWebKey
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("[ERROR] jwk curve %v is not supported", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("[ERROR] jwk kty %v is not supported", k.Kty)
+}
@@ -0,0 +1,284 @@
+package okta
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testIssuer is a local authorization server publishing its metadata and keys
+type testIssuer struct {
+	server     *httptest.Server
+	issuer     string
+	mu         sync.Mutex
+	keys       []JSONWebKey
+	keyFetches int
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	ti := &testIssuer{}
+	mux := http.NewServeMux()
+	ti.server = httptest.NewServer(mux)
+	ti.issuer = ti.server.URL + "/oauth2/default"
+
+	mux.HandleFunc("/oauth2/default/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"issuer":"%v","jwks_uri":"%v/v1/keys"}`, ti.issuer, ti.issuer)
+	})
+	mux.HandleFunc("/oauth2/default/v1/keys", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		ti.mu.Lock()
+		defer ti.mu.Unlock()
+		ti.keyFetches++
+		json.NewEncoder(w).Encode(map[string][]JSONWebKey{"keys": ti.keys})
+	})
+	return ti
+}
+
+func (ti *testIssuer) publish(keys ...JSONWebKey) {
+	ti.mu.Lock()
+	ti.keys = keys
+	ti.mu.Unlock()
+}
+
+func testRSAJWK(kid string, key *rsa.PrivateKey) JSONWebKey {
+	return JSONWebKey{
+		Kid: kid,
+		Kty: "RSA",
+		Alg: "RS256",
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func testECJWK(kid string, key *ecdsa.PrivateKey) JSONWebKey {
+	return JSONWebKey{
+		Kid: kid,
+		Kty: "EC",
+		Alg: "ES256",
+		Use: "sig",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.Bytes()),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.Bytes()),
+	}
+}
+
+func testSignJWT(t *testing.T, alg string, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest.Sum(nil))
+		if err != nil {
+			t.Fatalf("rsa.SignPKCS1v15 returned error: %v", err)
+		}
+		signature = sig
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest.Sum(nil))
+		if err != nil {
+			t.Fatalf("ecdsa.Sign returned error: %v", err)
+		}
+		signature = make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(signature[32-len(rb):32], rb)
+		copy(signature[64-len(sb):], sb)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTVerifier(t *testing.T) {
+	ti := newTestIssuer(t)
+	defer ti.server.Close()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey returned error: %v", err)
+	}
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ti.publish(testRSAJWK("rsa1", rsaKey), testECJWK("ec1", ecKey))
+
+	now := time.Unix(1500000000, 0)
+	verifier := NewJWTVerifier(ti.issuer+"/", "api://default", "0oa1d9f7k5B9tW0kT0h7")
+	verifier.now = func() time.Time { return now }
+
+	accessClaims := func() map[string]interface{} {
+		return map[string]interface{}{
+			"ver":    1,
+			"jti":    "AT.0mP4JKAZX1iACIT4vbEDF7LpvDVjxypPMf0D7uX39RE",
+			"iss":    ti.issuer,
+			"aud":    "api://default",
+			"sub":    "dade.murphy@example.com",
+			"iat":    now.Unix() - 60,
+			"exp":    now.Unix() + 3600,
+			"cid":    "0oa1d9f7k5B9tW0kT0h7",
+			"uid":    "00ub0oNGTSWTBKOLGLNR",
+			"scp":    []string{"openid", "email"},
+			"groups": []string{"Everyone"},
+		}
+	}
+
+	claims, err := verifier.VerifyAccessToken(testSignJWT(t, "RS256", "rsa1", rsaKey, accessClaims()))
+	if err != nil {
+		t.Fatalf("JWTVerifier.VerifyAccessToken returned error: %v", err)
+	}
+	if claims.UserID != "00ub0oNGTSWTBKOLGLNR" || claims.Subject != "dade.murphy@example.com" || len(claims.Scopes) != 2 || claims.ExpiresAt.Time() != now.Add(time.Hour) {
+		t.Errorf("JWTVerifier.VerifyAccessToken returned %+v", claims)
+	}
+	if groups, ok := claims.Raw["groups"].([]interface{}); !ok || groups[0] != "Everyone" {
+		t.Errorf("JWTVerifier.VerifyAccessToken returned raw claims %+v", claims.Raw)
+	}
+
+	if _, err := verifier.VerifyAccessToken(testSignJWT(t, "ES256", "ec1", ecKey, accessClaims())); err != nil {
+		t.Errorf("JWTVerifier.VerifyAccessToken returned error for an ES256 token: %v", err)
+	}
+
+	idClaims := map[string]interface{}{
+		"iss":                ti.issuer,
+		"aud":                []string{"0oa1d9f7k5B9tW0kT0h7"},
+		"sub":                "00ub0oNGTSWTBKOLGLNR",
+		"iat":                now.Unix(),
+		"exp":                now.Unix() + 3600,
+		"auth_time":          now.Unix() - 10,
+		"nonce":              "n-0S6_WzA2Mj",
+		"amr":                []string{"pwd", "mfa"},
+		"preferred_username": "dade.murphy@example.com",
+	}
+	idToken := testSignJWT(t, "RS256", "rsa1", rsaKey, idClaims)
+	id, err := verifier.VerifyIDToken(idToken, "n-0S6_WzA2Mj")
+	if err != nil {
+		t.Fatalf("JWTVerifier.VerifyIDToken returned error: %v", err)
+	}
+	if id.PreferredUsername != "dade.murphy@example.com" || len(id.AMR) != 2 || id.AuthTime.Time() != now.Add(-10*time.Second) {
+		t.Errorf("JWTVerifier.VerifyIDToken returned %+v", id)
+	}
+	if _, err := verifier.VerifyIDToken(idToken, "another-nonce"); err == nil {
+		t.Errorf("JWTVerifier.VerifyIDToken expected an error for a wrong nonce")
+	}
+
+	if ti.keyFetches != 1 {
+		t.Errorf("JWTVerifier fetched the keys %v times, want 1", ti.keyFetches)
+	}
+}
+
+func TestJWTVerifierRejects(t *testing.T) {
+	ti := newTestIssuer(t)
+	defer ti.server.Close()
+
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ti.publish(testRSAJWK("rsa1", rsaKey))
+
+	now := time.Unix(1500000000, 0)
+	verifier := NewJWTVerifier(ti.issuer, "api://default", "0oa1d9f7k5B9tW0kT0h7")
+	verifier.now = func() time.Time { return now }
+
+	valid := func(changes map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss": ti.issuer,
+			"aud": "api://default",
+			"iat": now.Unix(),
+			"exp": now.Unix() + 3600,
+			"cid": "0oa1d9f7k5B9tW0kT0h7",
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(claims, k)
+			} else {
+				claims[k] = v
+			}
+		}
+		return claims
+	}
+
+	payload, _ := json.Marshal(valid(nil))
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"rsa1"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"wrong issuer", testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"iss": "https://evil.example.com"})), nil},
+		{"wrong audience", testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"aud": "api://other"})), nil},
+		{"wrong cid", testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"cid": "0oaother"})), nil},
+		{"expired", testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"exp": now.Unix() - 121})), ErrJWTExpired},
+		{"no exp", testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"exp": nil})), ErrJWTExpired},
+		{"not before", testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"nbf": now.Unix() + 121})), ErrJWTNotValidYet},
+		{"signed by another key", testSignJWT(t, "RS256", "rsa1", otherKey, valid(nil)), ErrJWTInvalidSignature},
+		{"alg none", unsigned, nil},
+		{"malformed", "not-a-jwt", nil},
+	}
+
+	for _, test := range tests {
+		_, err := verifier.VerifyAccessToken(test.token)
+		if err == nil {
+			t.Errorf("JWTVerifier.VerifyAccessToken accepted a token with %v", test.name)
+		} else if test.want != nil && err != test.want {
+			t.Errorf("JWTVerifier.VerifyAccessToken returned %v for a token with %v, want %v", err, test.name, test.want)
+		}
+	}
+
+	// exp and nbf within the clock skew are accepted
+	skewed := testSignJWT(t, "RS256", "rsa1", rsaKey, valid(map[string]interface{}{"exp": now.Unix() - 60, "nbf": now.Unix() + 60}))
+	if _, err := verifier.VerifyAccessToken(skewed); err != nil {
+		t.Errorf("JWTVerifier.VerifyAccessToken returned error within the clock skew: %v", err)
+	}
+
+	// a JWTVerifier literal allows the default clock skew
+	literal := &JWTVerifier{Issuer: ti.issuer, Audience: "api://default", now: verifier.now}
+	if _, err := literal.VerifyAccessToken(skewed); err != nil {
+		t.Errorf("JWTVerifier without ClockSkew returned error within the default clock skew: %v", err)
+	}
+}
+
+func TestJWTVerifierKeyRotation(t *testing.T) {
+	ti := newTestIssuer(t)
+	defer ti.server.Close()
+
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ti.publish(testRSAJWK("old", oldKey))
+
+	verifier := NewJWTVerifier(ti.issuer, "api://default", "")
+	claims := map[string]interface{}{"iss": ti.issuer, "aud": "api://default", "exp": time.Now().Unix() + 3600}
+
+	if _, err := verifier.VerifyAccessToken(testSignJWT(t, "RS256", "old", oldKey, claims)); err != nil {
+		t.Fatalf("JWTVerifier.VerifyAccessToken returned error: %v", err)
+	}
+
+	// the issuer rotates its key. A token with the new kid refreshes the cached keys
+	ti.publish(testRSAJWK("old", oldKey), testRSAJWK("new", newKey))
+	verifier.lastRefresh = time.Now().Add(-minJWKSRefreshInterval)
+	if _, err := verifier.VerifyAccessToken(testSignJWT(t, "RS256", "new", newKey, claims)); err != nil {
+		t.Fatalf("JWTVerifier.VerifyAccessToken returned error after a key rotation: %v", err)
+	}
+	if ti.keyFetches != 2 {
+		t.Errorf("JWTVerifier fetched the keys %v times, want 2", ti.keyFetches)
+	}
+
+	// unknown kids do not refresh the keys again before the minimum refresh interval
+	if _, err := verifier.VerifyAccessToken(testSignJWT(t, "RS256", "unknown", newKey, claims)); err == nil {
+		t.Errorf("JWTVerifier.VerifyAccessToken accepted a token with an unknown kid")
+	}
+	if ti.keyFetches != 2 {
+		t.Errorf("JWTVerifier fetched the keys %v times for an unknown kid, want 2", ti.keyFetches)
+	}
+}
@@ -0,0 +1,126 @@
+package okta
+
+import (
+	"time"
+)
+
+// Severities of a LogEvent
+const (
+	LogSeverityDebug = "DEBUG"
+	LogSeverityInfo  = "INFO"
+	LogSeverityWarn  = "WARN"
+	LogSeverityError = "ERROR"
+)
+
+// LogEvent is an event of the OKTA System Log, also delivered to event hooks
+// https://developer.okta.com/docs/reference/api/system-log/#logevent-object
+type LogEvent struct {
+	UUID                  string                    `json:"uuid,omitempty"`
+	Published             *time.Time                `json:"published,omitempty"`
+	EventType             string                    `json:"eventType,omitempty"`
+	Version               string                    `json:"version,omitempty"`
+	Severity              string                    `json:"severity,omitempty"`
+	LegacyEventType       string                    `json:"legacyEventType,omitempty"`
+	DisplayMessage        string                    `json:"displayMessage,omitempty"`
+	Actor                 *LogActor                 `json:"actor,omitempty"`
+	Client                *LogClient                `json:"client,omitempty"`
+	Outcome               *LogOutcome               `json:"outcome,omitempty"`
+	Target                []LogActor                `json:"target,omitempty"`
+	Transaction           *LogTransaction           `json:"transaction,omitempty"`
+	DebugContext          *LogDebugContext          `json:"debugContext,omitempty"`
+	AuthenticationContext *LogAuthenticationContext `json:"authenticationContext,omitempty"`
+	SecurityContext       *LogSecurityContext       `json:"securityContext,omitempty"`
+	Request               *LogRequest               `json:"request,omitempty"`
+}
+
+// LogActor is the actor or a target of a LogEvent
+type LogActor struct {
+	ID          string                 `json:"id,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	AlternateID string                 `json:"alternateId,omitempty"`
+	DisplayName string                 `json:"displayName,omitempty"`
+	DetailEntry map[string]interface{} `json:"detailEntry,omitempty"`
+}
+
+// LogClient is the client that made the request of a LogEvent
+type LogClient struct {
+	ID                  string                  `json:"id,omitempty"`
+	UserAgent           *LogUserAgent           `json:"userAgent,omitempty"`
+	Zone                string                  `json:"zone,omitempty"`
+	Device              string                  `json:"device,omitempty"`
+	IPAddress           string                  `json:"ipAddress,omitempty"`
+	GeographicalContext *LogGeographicalContext `json:"geographicalContext,omitempty"`
+}
+
+// LogUserAgent is the user agent of a LogClient
+type LogUserAgent struct {
+	RawUserAgent string `json:"rawUserAgent,omitempty"`
+	OS           string `json:"os,omitempty"`
+	Browser      string `json:"browser,omitempty"`
+}
+
+// LogGeographicalContext is the location of an IP address
+type LogGeographicalContext struct {
+	City        string `json:"city,omitempty"`
+	State       string `json:"state,omitempty"`
+	Country     string `json:"country,omitempty"`
+	PostalCode  string `json:"postalCode,omitempty"`
+	Geolocation *struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"geolocation,omitempty"`
+}
+
+// LogOutcome is the outcome of a LogEvent. Result is SUCCESS, FAILURE, SKIPPED, ALLOW, DENY, CHALLENGE or UNKNOWN
+type LogOutcome struct {
+	Result string `json:"result,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// LogTransaction is the request or job a LogEvent was part of
+type LogTransaction struct {
+	ID     string                 `json:"id,omitempty"`
+	Type   string                 `json:"type,omitempty"`
+	Detail map[string]interface{} `json:"detail,omitempty"`
+}
+
+// LogDebugContext holds the event type specific debug data of a LogEvent
+type LogDebugContext struct {
+	DebugData map[string]interface{} `json:"debugData,omitempty"`
+}
+
+// LogAuthenticationContext is how the actor of a LogEvent authenticated
+type LogAuthenticationContext struct {
+	AuthenticationProvider string `json:"authenticationProvider,omitempty"`
+	CredentialProvider     string `json:"credentialProvider,omitempty"`
+	CredentialType         string `json:"credentialType,omitempty"`
+	Issuer                 *struct {
+		ID   string `json:"id,omitempty"`
+		Type string `json:"type,omitempty"`
+	} `json:"issuer,omitempty"`
+	ExternalSessionID  string `json:"externalSessionId,omitempty"`
+	Interface          string `json:"interface,omitempty"`
+	AuthenticationStep int    `json:"authenticationStep,omitempty"`
+}
+
+// LogSecurityContext is the network the request of a LogEvent came from
+type LogSecurityContext struct {
+	AsNumber int    `json:"asNumber,omitempty"`
+	AsOrg    string `json:"asOrg,omitempty"`
+	ISP      string `json:"isp,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	IsProxy  bool   `json:"isProxy,omitempty"`
+}
+
+// LogRequest is the chain of IP addresses the request of a LogEvent went through
+type LogRequest struct {
+	IPChain []LogIPAddress `json:"ipChain,omitempty"`
+}
+
+// LogIPAddress is an IP address of a LogRequest
+type LogIPAddress struct {
+	IP                  string                  `json:"ip,omitempty"`
+	GeographicalContext *LogGeographicalContext `json:"geographicalContext,omitempty"`
+	Version             string                  `json:"version,omitempty"`
+	Source              string                  `json:"source,omitempty"`
+}
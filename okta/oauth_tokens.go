@@ -0,0 +1,233 @@
+package okta
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client authentication methods of the OAuth endpoints of an authorization server
+const (
+	ClientAuthSecretBasic   = "client_secret_basic"
+	ClientAuthSecretPost    = "client_secret_post"
+	ClientAuthPrivateKeyJWT = "private_key_jwt"
+
+	// TokenTypeHintAccessToken is the token_type_hint of an access token
+	TokenTypeHintAccessToken = "access_token"
+	// TokenTypeHintRefreshToken is the token_type_hint of a refresh token
+	TokenTypeHintRefreshToken = "refresh_token"
+	// TokenTypeHintIDToken is the token_type_hint of an ID token
+	TokenTypeHintIDToken = "id_token"
+
+	clientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime = 5 * time.Minute
+	mediaTypeForm           = "application/x-www-form-urlencoded"
+)
+
+// tokenRetryWait is the base wait before retrying an introspection or revocation that failed with a transient error
+var tokenRetryWait = defaultBatchRetryWait
+
+// OAuthClientAuth are the credentials an OAuth client authenticates with to the introspect and revoke
+// endpoints. ClientSecret is used by ClientAuthSecretBasic and ClientAuthSecretPost. PrivateKey, an
+// *rsa.PrivateKey or a P-256 *ecdsa.PrivateKey, and KeyID are used by ClientAuthPrivateKeyJWT
+type OAuthClientAuth struct {
+	Method       string
+	ClientID     string
+	ClientSecret string
+	PrivateKey   crypto.Signer
+	KeyID        string
+}
+
+// TokenIntrospection is the result of a token introspection. Only Active is set for an inactive token
+type TokenIntrospection struct {
+	Active    bool                   `json:"active"`
+	Scope     string                 `json:"scope,omitempty"`
+	ClientID  string                 `json:"client_id,omitempty"`
+	Username  string                 `json:"username,omitempty"`
+	TokenType string                 `json:"token_type,omitempty"`
+	ExpiresAt NumericDate            `json:"exp,omitempty"`
+	IssuedAt  NumericDate            `json:"iat,omitempty"`
+	NotBefore NumericDate            `json:"nbf,omitempty"`
+	Subject   string                 `json:"sub,omitempty"`
+	Audience  JWTAudience            `json:"aud,omitempty"`
+	Issuer    string                 `json:"iss,omitempty"`
+	ID        string                 `json:"jti,omitempty"`
+	UserID    string                 `json:"uid,omitempty"`
+	DeviceID  string                 `json:"device_id,omitempty"`
+	Raw       map[string]interface{} `json:"-"`
+}
+
+// UnmarshalJSON decodes the introspection and keeps every member, including custom claims, in Raw
+func (t *TokenIntrospection) UnmarshalJSON(data []byte) error {
+	type tokenIntrospection TokenIntrospection
+	if err := json.Unmarshal(data, (*tokenIntrospection)(t)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &t.Raw)
+}
+
+// IntrospectToken: Get the state and claims of an access, ID or refresh token. serverID is the ID of a custom
+// authorization server, or "" for the org authorization server. tokenTypeHint can be "". Requests failing with a
+// rate limit, network or 5xx error are retried like Batch operations
+func (p *AuthorizationServersService) IntrospectToken(serverID string, token string, tokenTypeHint string, auth OAuthClientAuth) (*TokenIntrospection, *Response, error) {
+	introspection := new(TokenIntrospection)
+	resp, err := p.tokenRequest(serverID, "introspect", token, tokenTypeHint, auth, introspection)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return introspection, resp, err
+}
+
+// RevokeToken: Revoke an access or refresh token. serverID is the ID of a custom authorization server, or ""
+// for the org authorization server. Revoking an invalid or already revoked token succeeds
+func (p *AuthorizationServersService) RevokeToken(serverID string, token string, tokenTypeHint string, auth OAuthClientAuth) (*Response, error) {
+	return p.tokenRequest(serverID, "revoke", token, tokenTypeHint, auth, nil)
+}
+
+// OAuthEndpoint returns the URL of an OAuth endpoint, for example "introspect" or "keys", of a custom
+// authorization server, or of the org authorization server when serverID is ""
+func (p *AuthorizationServersService) OAuthEndpoint(serverID string, endpoint string) string {
+	path := "/oauth2/v1/" + endpoint
+	if serverID != "" {
+		path = fmt.Sprintf("/oauth2/%v/v1/%v", serverID, endpoint)
+	}
+	return p.client.BaseURL.ResolveReference(&url.URL{Path: path}).String()
+}
+
+func (p *AuthorizationServersService) tokenRequest(serverID string, endpoint string, token string, tokenTypeHint string, auth OAuthClientAuth, v interface{}) (*Response, error) {
+	if auth.ClientID == "" {
+		return nil, errors.New("[ERROR] OAuth client authentication requires a ClientID")
+	}
+	u := p.OAuthEndpoint(serverID, endpoint)
+
+	var resp *Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		form := url.Values{}
+		form.Set("token", token)
+		if tokenTypeHint != "" {
+			form.Set("token_type_hint", tokenTypeHint)
+		}
+
+		resp, err = p.postForm(u, form, auth, v)
+		if err == nil || attempt > defaultBatchMaxRetries || !isTransientError(resp, err) {
+			return resp, err
+		}
+		time.Sleep(batchRetryDelay(err, attempt, tokenRetryWait))
+	}
+}
+
+// postForm posts form to an OAuth endpoint, authenticated as the OAuth client instead of with the API token
+func (p *AuthorizationServersService) postForm(u string, form url.Values, auth OAuthClientAuth, v interface{}) (*Response, error) {
+	switch auth.Method {
+	case ClientAuthSecretBasic, "":
+	case ClientAuthSecretPost:
+		form.Set("client_id", auth.ClientID)
+		form.Set("client_secret", auth.ClientSecret)
+	case ClientAuthPrivateKeyJWT:
+		assertion, err := clientAssertion(auth, u)
+		if err != nil {
+			return nil, err
+		}
+		form.Set("client_id", auth.ClientID)
+		form.Set("client_assertion_type", clientAssertionType)
+		form.Set("client_assertion", assertion)
+	default:
+		return nil, fmt.Errorf("[ERROR] OAuth client authentication method %v is not supported", auth.Method)
+	}
+
+	req, err := p.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	body := form.Encode()
+	req.Body = ioutil.NopCloser(strings.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Type", mediaTypeForm)
+	req.Header.Set("Accept", mediaTypeJSON)
+	req.Header.Del(headerAuthorization)
+	if auth.Method == ClientAuthSecretBasic || auth.Method == "" {
+		req.SetBasicAuth(url.QueryEscape(auth.ClientID), url.QueryEscape(auth.ClientSecret))
+	}
+
+	return p.client.Do(req, v)
+}
+
+// clientAssertion returns a JWT signed with the private key of the client, valid for the endpoint aud
+func clientAssertion(auth OAuthClientAuth, aud string) (string, error) {
+	var alg string
+	switch key := auth.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		alg = "RS256"
+	case *ecdsa.PrivateKey:
+		if key.Curve.Params().BitSize != 256 {
+			return "", errors.New("[ERROR] private_key_jwt supports P-256 ECDSA keys only")
+		}
+		alg = "ES256"
+	case nil:
+		return "", errors.New("[ERROR] private_key_jwt requires a PrivateKey")
+	default:
+		return "", fmt.Errorf("[ERROR] private_key_jwt key type %T is not supported", key)
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if auth.KeyID != "" {
+		header["kid"] = auth.KeyID
+	}
+	claims := map[string]interface{}{
+		"iss": auth.ClientID,
+		"sub": auth.ClientID,
+		"aud": aud,
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+		"jti": hex.EncodeToString(jti),
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	h := crypto.SHA256.New()
+	h.Write([]byte(signed))
+	signature, err := auth.PrivateKey.Sign(rand.Reader, h.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	if alg == "ES256" {
+		// crypto.Signer returns an ASN.1 signature, JWS uses the fixed size R || S
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(signature, &rs); err != nil {
+			return "", err
+		}
+		signature = make([]byte, 64)
+		r, s := rs.R.Bytes(), rs.S.Bytes()
+		copy(signature[32-len(r):32], r)
+		copy(signature[64-len(s):], s)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,123 @@
+package okta
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	// RateLimitModeEnforce enforces the per-client rate limits and logs the requests over them
+	RateLimitModeEnforce = "ENFORCE"
+	// RateLimitModePreview logs the requests over the per-client rate limits without enforcing them
+	RateLimitModePreview = "PREVIEW"
+	// RateLimitModeDisable disables the per-client rate limits
+	RateLimitModeDisable = "DISABLE"
+)
+
+// tokenWindowPattern (unexported) matches the ISO 8601 durations of APIToken.TokenWindow, like P30D or PT12H
+var tokenWindowPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// APIToken is an API token of the org, without its value. UserID is the user that owns the token and
+// the token is only accepted from the Network. The token expires after TokenWindow, an ISO 8601 duration
+// like P30D, without requests, so OKTA moves ExpiresAt forward every time the token is used
+// Note - time.Time fields are pointers due to the issue described at link below
+// https://stackoverflow.com/questions/32643815/golang-json-omitempty-with-time-time-field
+type APIToken struct {
+	ID          string                            `json:"id,omitempty"`
+	Name        string                            `json:"name,omitempty"`
+	UserID      string                            `json:"userId,omitempty"`
+	ClientName  string                            `json:"clientName,omitempty"`
+	TokenWindow string                            `json:"tokenWindow,omitempty"`
+	Network     *Network                          `json:"network,omitempty"`
+	Created     *time.Time                        `json:"created,omitempty"`
+	LastUpdated *time.Time                        `json:"lastUpdated,omitempty"`
+	ExpiresAt   *time.Time                        `json:"expiresAt,omitempty"`
+	Links       map[string]map[string]interface{} `json:"_links,omitempty"`
+}
+
+// APITokenListOptions are the optional query parameters of ListAPITokens. Q searches the tokens by name
+type APITokenListOptions struct {
+	Q             string   `url:"q,omitempty"`
+	Limit         int      `url:"limit,omitempty"`
+	After         string   `url:"after,omitempty"`
+	NextURL       *url.URL `url:"-"`
+	GetAllPages   bool     `url:"-"`
+	NumberOfPages int      `url:"-"`
+}
+
+// PerClientRateLimitSettings are the per-client rate limit settings of the org. UseCaseModeOverrides are the
+// modes of the use cases, like LOGIN_PAGE or OAUTH2_AUTHORIZE, that do not use DefaultMode
+type PerClientRateLimitSettings struct {
+	DefaultMode          string            `json:"defaultMode,omitempty"`
+	UseCaseModeOverrides map[string]string `json:"useCaseModeOverrides,omitempty"`
+}
+
+// ListAPITokens: List the API tokens of the org
+func (s *OrgService) ListAPITokens(opt *APITokenListOptions) ([]APIToken, *Response, error) {
+	if opt == nil {
+		opt = new(APITokenListOptions)
+	}
+	var tokens []APIToken
+	resp, err := s.client.listPages("api-tokens", opt, opt.NextURL, opt.GetAllPages, opt.NumberOfPages, &tokens)
+	return tokens, resp, err
+}
+
+// GetAPIToken: Get an API token of the org
+// Requires APIToken ID from APIToken object
+func (s *OrgService) GetAPIToken(id string) (*APIToken, *Response, error) {
+	u := fmt.Sprintf("api-tokens/%v", id)
+	token := new(APIToken)
+	resp, err := s.client.do("GET", u, nil, token)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return token, resp, err
+}
+
+// RevokeAPIToken: Revoke an API token of the org
+// Requires APIToken ID from APIToken object
+func (s *OrgService) RevokeAPIToken(id string) (*Response, error) {
+	u := fmt.Sprintf("api-tokens/%v", id)
+	return s.client.do("DELETE", u, nil, nil)
+}
+
+// RevokeCurrentAPIToken: Revoke the API token the client is configured with. The client can not send
+// requests afterwards
+func (s *OrgService) RevokeCurrentAPIToken() (*Response, error) {
+	return s.client.do("DELETE", "api-tokens/current", nil, nil)
+}
+
+// GetPerClientRateLimitSettings: Get the per-client rate limit settings of the org
+func (s *OrgService) GetPerClientRateLimitSettings() (*PerClientRateLimitSettings, *Response, error) {
+	settings := new(PerClientRateLimitSettings)
+	resp, err := s.client.do("GET", "rate-limit-settings/per-client", nil, settings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return settings, resp, err
+}
+
+// LastUsed returns when the API token was last used, ExpiresAt less the TokenWindow. A token that was never used
+// returns its creation time
+func (t APIToken) LastUsed() (time.Time, error) {
+	if t.ExpiresAt == nil {
+		return time.Time{}, fmt.Errorf("[ERROR] api token %v has no expiresAt", t.ID)
+	}
+	m := tokenWindowPattern.FindStringSubmatch(t.TokenWindow)
+	if m == nil || t.TokenWindow == "P" || t.TokenWindow == "PT" {
+		return time.Time{}, fmt.Errorf("[ERROR] token window %q of api token %v is not supported", t.TokenWindow, t.ID)
+	}
+	var window time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if m[i+1] != "" {
+			n, _ := strconv.Atoi(m[i+1])
+			window += time.Duration(n) * unit
+		}
+	}
+	return t.ExpiresAt.Add(-window), nil
+}
@@ -0,0 +1,151 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListAPITokens(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api-tokens", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		switch r.URL.Query().Get("after") {
+		case "":
+			if q := r.URL.Query(); q.Get("q") != "terraform" || q.Get("limit") != "1" {
+				t.Errorf("Org.ListAPITokens sent %v", r.URL.RawQuery)
+			}
+			w.Header().Add("Link", fmt.Sprintf(`<%v/api-tokens?after=00Tabcdefg1234567890&limit=1&q=terraform>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id":"00Tabcdefg1234567890","name":"terraform","userId":"00uabcdefg1234567890","clientName":"Okta API",`+
+				`"tokenWindow":"P30D","network":{"connection":"ZONE","include":["nzowdja2YRaQmOQYp0g3"]},`+
+				`"created":"2021-12-09T20:38:46.000Z","expiresAt":"2022-01-20T20:38:46.000Z"}]`)
+		default:
+			fmt.Fprint(w, `[{"id":"00Tabcdefg0987654321","name":"terraform ci","userId":"00uabcdefg1234567890","tokenWindow":"PT12H"}]`)
+		}
+	})
+
+	created, _ := time.Parse(time.RFC3339, "2021-12-09T20:38:46.000Z")
+	expiresAt, _ := time.Parse(time.RFC3339, "2022-01-20T20:38:46.000Z")
+	opt := &APITokenListOptions{Q: "terraform", Limit: 1}
+	tokens, _, err := client.Org.ListAPITokens(opt)
+	if err != nil {
+		t.Fatalf("Org.ListAPITokens returned error: %v", err)
+	}
+	want := []APIToken{{
+		ID:          "00Tabcdefg1234567890",
+		Name:        "terraform",
+		UserID:      "00uabcdefg1234567890",
+		ClientName:  "Okta API",
+		TokenWindow: "P30D",
+		Network:     &Network{Connection: "ZONE", Include: []string{"nzowdja2YRaQmOQYp0g3"}},
+		Created:     &created,
+		ExpiresAt:   &expiresAt,
+	}}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("client.Org.ListAPITokens returned \n\t%+v, want \n\t%+v\n", tokens, want)
+	}
+
+	opt.GetAllPages = true
+	tokens, _, err = client.Org.ListAPITokens(opt)
+	if err != nil {
+		t.Fatalf("Org.ListAPITokens returned error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[1].TokenWindow != "PT12H" {
+		t.Errorf("client.Org.ListAPITokens of all pages returned %+v", tokens)
+	}
+}
+
+func TestAPIToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/api-tokens/00Tabcdefg1234567890", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"00Tabcdefg1234567890","name":"terraform","userId":"00uabcdefg1234567890","tokenWindow":"P30D"}`)
+	})
+	mux.HandleFunc("/api-tokens/current", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	token, _, err := client.Org.GetAPIToken("00Tabcdefg1234567890")
+	if err != nil {
+		t.Errorf("Org.GetAPIToken returned error: %v", err)
+	}
+	want := &APIToken{ID: "00Tabcdefg1234567890", Name: "terraform", UserID: "00uabcdefg1234567890", TokenWindow: "P30D"}
+	if !reflect.DeepEqual(token, want) {
+		t.Errorf("client.Org.GetAPIToken returned \n\t%+v, want \n\t%+v\n", token, want)
+	}
+
+	if _, err := client.Org.RevokeAPIToken("00Tabcdefg1234567890"); err != nil {
+		t.Errorf("Org.RevokeAPIToken returned error: %v", err)
+	}
+	if _, err := client.Org.RevokeCurrentAPIToken(); err != nil {
+		t.Errorf("Org.RevokeCurrentAPIToken returned error: %v", err)
+	}
+}
+
+func TestAPITokenLastUsed(t *testing.T) {
+	expiresAt, _ := time.Parse(time.RFC3339, "2022-01-20T20:38:46.000Z")
+	tests := []struct {
+		window string
+		want   string
+	}{
+		{"P30D", "2021-12-21T20:38:46Z"},
+		{"PT12H", "2022-01-20T08:38:46Z"},
+		{"P1DT1H30M", "2022-01-19T19:08:46Z"},
+		{"PT90S", "2022-01-20T20:37:16Z"},
+	}
+	for _, test := range tests {
+		got, err := APIToken{TokenWindow: test.window, ExpiresAt: &expiresAt}.LastUsed()
+		if err != nil {
+			t.Errorf("APIToken.LastUsed of window %v returned error: %v", test.window, err)
+		}
+		if got.Format(time.RFC3339) != test.want {
+			t.Errorf("APIToken.LastUsed of window %v returned %v, want %v", test.window, got.Format(time.RFC3339), test.want)
+		}
+	}
+
+	for _, window := range []string{"", "P", "PT", "P1W", "30 days"} {
+		if _, err := (APIToken{TokenWindow: window, ExpiresAt: &expiresAt}).LastUsed(); err == nil {
+			t.Errorf("APIToken.LastUsed of window %q returned no error", window)
+		}
+	}
+	if _, err := (APIToken{TokenWindow: "P30D"}).LastUsed(); err == nil {
+		t.Errorf("APIToken.LastUsed without expiresAt returned no error")
+	}
+}
+
+func TestGetPerClientRateLimitSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/rate-limit-settings/per-client", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"defaultMode":"ENFORCE","useCaseModeOverrides":{"LOGIN_PAGE":"PREVIEW","OAUTH2_AUTHORIZE":"DISABLE"}}`)
+	})
+
+	settings, _, err := client.Org.GetPerClientRateLimitSettings()
+	if err != nil {
+		t.Errorf("Org.GetPerClientRateLimitSettings returned error: %v", err)
+	}
+	want := &PerClientRateLimitSettings{
+		DefaultMode:          RateLimitModeEnforce,
+		UseCaseModeOverrides: map[string]string{"LOGIN_PAGE": RateLimitModePreview, "OAUTH2_AUTHORIZE": RateLimitModeDisable},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("client.Org.GetPerClientRateLimitSettings returned \n\t%+v, want \n\t%+v\n", settings, want)
+	}
+}
@@ -0,0 +1,151 @@
+package okta
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// OrgContactTypeBilling is the contact of the org for billing
+	OrgContactTypeBilling = "BILLING"
+	// OrgContactTypeTechnical is the technical contact of the org
+	OrgContactTypeTechnical = "TECHNICAL"
+)
+
+// OrgSettings are the settings of the org. SupportPhoneNumber and EndUserSupportHelpURL are shown to the end users
+// to get help
+// Note - time.Time fields are pointers due to the issue described at link below
+// https://stackoverflow.com/questions/32643815/golang-json-omitempty-with-time-time-field
+type OrgSettings struct {
+	ID                    string                            `json:"id,omitempty"`
+	Subdomain             string                            `json:"subdomain,omitempty"`
+	Status                string                            `json:"status,omitempty"`
+	CompanyName           string                            `json:"companyName,omitempty"`
+	Website               string                            `json:"website,omitempty"`
+	PhoneNumber           string                            `json:"phoneNumber,omitempty"`
+	SupportPhoneNumber    string                            `json:"supportPhoneNumber,omitempty"`
+	EndUserSupportHelpURL string                            `json:"endUserSupportHelpURL,omitempty"`
+	Address1              string                            `json:"address1,omitempty"`
+	Address2              string                            `json:"address2,omitempty"`
+	City                  string                            `json:"city,omitempty"`
+	State                 string                            `json:"state,omitempty"`
+	Country               string                            `json:"country,omitempty"`
+	PostalCode            string                            `json:"postalCode,omitempty"`
+	ExpiresAt             *time.Time                        `json:"expiresAt,omitempty"`
+	Created               *time.Time                        `json:"created,omitempty"`
+	LastUpdated           *time.Time                        `json:"lastUpdated,omitempty"`
+	Links                 map[string]map[string]interface{} `json:"_links,omitempty"`
+}
+
+// OrgContact is a contact type of the org and, from GetContactUser, the ID of the user that is the contact
+type OrgContact struct {
+	ContactType string                            `json:"contactType,omitempty"`
+	UserID      string                            `json:"userId,omitempty"`
+	Links       map[string]map[string]interface{} `json:"_links,omitempty"`
+}
+
+// OrgPreferences are the preferences of the org
+type OrgPreferences struct {
+	ShowEndUserFooter bool                              `json:"showEndUserFooter"`
+	Links             map[string]map[string]interface{} `json:"_links,omitempty"`
+}
+
+// GetSettings: Get the settings of the org
+func (s *OrgService) GetSettings() (*OrgSettings, *Response, error) {
+	settings := new(OrgSettings)
+	resp, err := s.client.do("GET", "org", nil, settings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return settings, resp, err
+}
+
+// UpdateSettings: Replace the settings of the org. The settings left empty are cleared
+func (s *OrgService) UpdateSettings(settings OrgSettings) (*OrgSettings, *Response, error) {
+	updated := new(OrgSettings)
+	resp, err := s.client.do("PUT", "org", settings, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, err
+}
+
+// PartialUpdateSettings: Update the settings of the org that are set in settings, the others are left unchanged
+func (s *OrgService) PartialUpdateSettings(settings OrgSettings) (*OrgSettings, *Response, error) {
+	updated := new(OrgSettings)
+	resp, err := s.client.do("POST", "org", settings, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated, resp, err
+}
+
+// UpdateEndUserSupport: Update the support phone number and help URL shown to the end users of the org
+func (s *OrgService) UpdateEndUserSupport(phoneNumber string, helpURL string) (*OrgSettings, *Response, error) {
+	return s.PartialUpdateSettings(OrgSettings{SupportPhoneNumber: phoneNumber, EndUserSupportHelpURL: helpURL})
+}
+
+// ListContactTypes: List the contact types of the org, OrgContactTypeBilling and OrgContactTypeTechnical
+func (s *OrgService) ListContactTypes() ([]OrgContact, *Response, error) {
+	var contacts []OrgContact
+	resp, err := s.client.do("GET", "org/contacts", nil, &contacts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contacts, resp, err
+}
+
+// GetContactUser: Get the user that is the contact of the org for a contact type
+func (s *OrgService) GetContactUser(contactType string) (*OrgContact, *Response, error) {
+	u := fmt.Sprintf("org/contacts/%v", contactType)
+	contact := new(OrgContact)
+	resp, err := s.client.do("GET", u, nil, contact)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contact, resp, err
+}
+
+// UpdateContactUser: Make a user the contact of the org for a contact type
+// Requires User ID from User object
+func (s *OrgService) UpdateContactUser(contactType string, userID string) (*OrgContact, *Response, error) {
+	u := fmt.Sprintf("org/contacts/%v", contactType)
+	contact := new(OrgContact)
+	resp, err := s.client.do("PUT", u, map[string]string{"userId": userID}, contact)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contact, resp, err
+}
+
+// GetPreferences: Get the preferences of the org
+func (s *OrgService) GetPreferences() (*OrgPreferences, *Response, error) {
+	preferences := new(OrgPreferences)
+	resp, err := s.client.do("GET", "org/preferences", nil, preferences)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return preferences, resp, err
+}
+
+// ShowEndUserFooter: Show or hide the footer on the end user dashboard of the org
+func (s *OrgService) ShowEndUserFooter(show bool) (*OrgPreferences, *Response, error) {
+	u := "org/preferences/hideEndUserFooter"
+	if show {
+		u = "org/preferences/showEndUserFooter"
+	}
+	preferences := new(OrgPreferences)
+	resp, err := s.client.do("POST", u, nil, preferences)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return preferences, resp, err
+}
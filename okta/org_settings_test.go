@@ -0,0 +1,164 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrgSettings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	created, _ := time.Parse(time.RFC3339, "2018-01-22T19:39:03.000Z")
+	settings := OrgSettings{
+		ID:                    "00o5rb5mt2H3d1TJd0h7",
+		Subdomain:             "okta",
+		Status:                "ACTIVE",
+		CompanyName:           "Okta",
+		Website:               "https://www.okta.com/",
+		PhoneNumber:           "+1-555-415-1337",
+		SupportPhoneNumber:    "+1-555-514-1337",
+		EndUserSupportHelpURL: "https://support.okta.com",
+		Address1:              "301 Brannan St.",
+		City:                  "San Francisco",
+		State:                 "California",
+		Country:               "United States of America",
+		PostalCode:            "94107",
+		Created:               &created,
+	}
+	settingsJSON := `{"id":"00o5rb5mt2H3d1TJd0h7","subdomain":"okta","status":"ACTIVE","companyName":"Okta","website":"https://www.okta.com/",` +
+		`"phoneNumber":"+1-555-415-1337","supportPhoneNumber":"+1-555-514-1337","endUserSupportHelpURL":"https://support.okta.com",` +
+		`"address1":"301 Brannan St.","city":"San Francisco","state":"California","country":"United States of America",` +
+		`"postalCode":"94107","created":"2018-01-22T19:39:03.000Z"}`
+
+	update := OrgSettings{CompanyName: "Okta", Website: "https://www.okta.com/"}
+	mux.HandleFunc("/org", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		switch r.Method {
+		case "PUT":
+			testBody(t, r, update)
+		case "POST":
+			testBody(t, r, OrgSettings{SupportPhoneNumber: "+1-555-514-1337", EndUserSupportHelpURL: "https://support.okta.com"})
+		default:
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, settingsJSON)
+	})
+
+	got, _, err := client.Org.GetSettings()
+	if err != nil {
+		t.Errorf("Org.GetSettings returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, &settings) {
+		t.Errorf("client.Org.GetSettings returned \n\t%+v, want \n\t%+v\n", got, &settings)
+	}
+
+	updated, _, err := client.Org.UpdateSettings(update)
+	if err != nil {
+		t.Errorf("Org.UpdateSettings returned error: %v", err)
+	}
+	if !reflect.DeepEqual(updated, &settings) {
+		t.Errorf("client.Org.UpdateSettings returned \n\t%+v, want \n\t%+v\n", updated, &settings)
+	}
+
+	updated, _, err = client.Org.UpdateEndUserSupport("+1-555-514-1337", "https://support.okta.com")
+	if err != nil {
+		t.Errorf("Org.UpdateEndUserSupport returned error: %v", err)
+	}
+	if !reflect.DeepEqual(updated, &settings) {
+		t.Errorf("client.Org.UpdateEndUserSupport returned \n\t%+v, want \n\t%+v\n", updated, &settings)
+	}
+}
+
+func TestOrgContacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/org/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `[{"contactType":"BILLING"},{"contactType":"TECHNICAL"}]`)
+	})
+	mux.HandleFunc("/org/contacts/TECHNICAL", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "PUT" {
+			testBody(t, r, map[string]string{"userId": "00ux3u0ujW1r5AfZC1d7"})
+		} else {
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, `{"userId":"00ux3u0ujW1r5AfZC1d7"}`)
+	})
+
+	contacts, _, err := client.Org.ListContactTypes()
+	if err != nil {
+		t.Errorf("Org.ListContactTypes returned error: %v", err)
+	}
+	if want := []OrgContact{{ContactType: OrgContactTypeBilling}, {ContactType: OrgContactTypeTechnical}}; !reflect.DeepEqual(contacts, want) {
+		t.Errorf("client.Org.ListContactTypes returned \n\t%+v, want \n\t%+v\n", contacts, want)
+	}
+
+	want := &OrgContact{UserID: "00ux3u0ujW1r5AfZC1d7"}
+	contact, _, err := client.Org.GetContactUser(OrgContactTypeTechnical)
+	if err != nil {
+		t.Errorf("Org.GetContactUser returned error: %v", err)
+	}
+	if !reflect.DeepEqual(contact, want) {
+		t.Errorf("client.Org.GetContactUser returned \n\t%+v, want \n\t%+v\n", contact, want)
+	}
+
+	contact, _, err = client.Org.UpdateContactUser(OrgContactTypeTechnical, "00ux3u0ujW1r5AfZC1d7")
+	if err != nil {
+		t.Errorf("Org.UpdateContactUser returned error: %v", err)
+	}
+	if !reflect.DeepEqual(contact, want) {
+		t.Errorf("client.Org.UpdateContactUser returned \n\t%+v, want \n\t%+v\n", contact, want)
+	}
+}
+
+func TestOrgPreferences(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/org/preferences", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"showEndUserFooter":true}`)
+	})
+	mux.HandleFunc("/org/preferences/hideEndUserFooter", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"showEndUserFooter":false}`)
+	})
+	mux.HandleFunc("/org/preferences/showEndUserFooter", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"showEndUserFooter":true}`)
+	})
+
+	preferences, _, err := client.Org.GetPreferences()
+	if err != nil {
+		t.Errorf("Org.GetPreferences returned error: %v", err)
+	}
+	if want := (&OrgPreferences{ShowEndUserFooter: true}); !reflect.DeepEqual(preferences, want) {
+		t.Errorf("client.Org.GetPreferences returned \n\t%+v, want \n\t%+v\n", preferences, want)
+	}
+
+	preferences, _, err = client.Org.ShowEndUserFooter(false)
+	if err != nil {
+		t.Errorf("Org.ShowEndUserFooter returned error: %v", err)
+	}
+	if preferences.ShowEndUserFooter {
+		t.Errorf("client.Org.ShowEndUserFooter(false) returned %+v", preferences)
+	}
+
+	preferences, _, err = client.Org.ShowEndUserFooter(true)
+	if err != nil {
+		t.Errorf("Org.ShowEndUserFooter returned error: %v", err)
+	}
+	if !preferences.ShowEndUserFooter {
+		t.Errorf("client.Org.ShowEndUserFooter(true) returned %+v", preferences)
+	}
+}
@@ -194,7 +194,7 @@ type PolicyLinks struct {
 		Hints struct {
 			Allow []string `json:"allow,omitempty"`
 		} `json:"hints,omitempty"`
-	} `json:"activate",omitempty`
+	} `json:"activate,omitempty"`
 	Deactivate struct {
 		Href  string `json:"href,omitempty"`
 		Hints struct {
@@ -285,6 +285,9 @@ type PolicyConditions struct {
 	AuthContext  *AuthContext  `json:"authContext,omitempty"`
 	Network      *Network      `json:"network,omitempty"`
 	AuthProvider *AuthProvider `json:"authProvider,omitempty"`
+	// UserIdentifier & App are conditions of IDP_DISCOVERY rules
+	UserIdentifier *UserIdentifier `json:"userIdentifier,omitempty"`
+	App            *AppCondition   `json:"app,omitempty"`
 }
 
 // Rule represents the complete Rule Object from the OKTA API
@@ -302,9 +305,10 @@ type Rule struct {
 	Actions     struct {
 		SignOn                   `json:"signon,omitempty"`
 		Enroll                   `json:"enroll,omitempty"`
-		PasswordChange           PasswordAction `json:"passwordChange,omitempty"`
-		SelfServicePasswordReset PasswordAction `json:"selfServicePasswordReset,omitempty"`
-		SelfServiceUnlock        PasswordAction `json:"selfServiceUnlock,omitempty"`
+		PasswordChange           PasswordAction      `json:"passwordChange,omitempty"`
+		SelfServicePasswordReset PasswordAction      `json:"selfServicePasswordReset,omitempty"`
+		SelfServiceUnlock        PasswordAction      `json:"selfServiceUnlock,omitempty"`
+		IDP                      *IdpDiscoveryAction `json:"idp,omitempty"`
 	} `json:"actions,omitempty"`
 	Links *PolicyLinks `json:"_links,omitempty"`
 }
@@ -444,7 +448,7 @@ func (p *PoliciesService) GetPolicy(id string) (*Policy, *Response, error) {
 }
 
 // GetPoliciesByType: Get all policies by type
-// Allowed types are OKTA_SIGN_ON, PASSWORD, MFA_ENROLL, or OAUTH_AUTHORIZATION_POLICY
+// Allowed types are OKTA_SIGN_ON, PASSWORD, MFA_ENROLL, OAUTH_AUTHORIZATION_POLICY or IDP_DISCOVERY
 func (p *PoliciesService) GetPoliciesByType(policyType string) (*PolicyCollection, *Response, error) {
 	u := fmt.Sprintf("policies?type=%v", policyType)
 	req, err := p.client.NewRequest("GET", u, nil)
@@ -0,0 +1,162 @@
+package okta
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// ProfileMappingPush - the mapping is applied on create and on every update of the user
+	ProfileMappingPush = "PUSH"
+	// ProfileMappingDontPush - the mapping is applied on create only
+	ProfileMappingDontPush = "DONT_PUSH"
+
+	// ProfileMappingSourceUser is the type of the OKTA user profile as source or target of a mapping
+	ProfileMappingSourceUser = "user"
+	// ProfileMappingSourceApp is the type of an app user profile as source or target of a mapping
+	ProfileMappingSourceApp = "appuser"
+)
+
+// ProfileMappingsService handles communication with the Profile Mappings related
+// methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/mappings/
+type ProfileMappingsService service
+
+// ProfileMapping maps the attributes of a source profile, for example of an app, to a target profile.
+// Properties is keyed by the attribute of the target
+type ProfileMapping struct {
+	ID         string                             `json:"id,omitempty"`
+	Source     *ProfileMappingSource              `json:"source,omitempty"`
+	Target     *ProfileMappingSource              `json:"target,omitempty"`
+	Properties map[string]*ProfileMappingProperty `json:"properties,omitempty"`
+}
+
+// ProfileMappingSource is the source or target of a ProfileMapping, a user type or an app instance
+type ProfileMappingSource struct {
+	ID    string      `json:"id,omitempty"`
+	Name  string      `json:"name,omitempty"`
+	Type  string      `json:"type,omitempty"`
+	Links interface{} `json:"_links,omitempty"`
+}
+
+// ProfileMappingProperty is the expression, for example "appuser.firstName", computing an attribute of the target
+type ProfileMappingProperty struct {
+	Expression string `json:"expression"`
+	PushStatus string `json:"pushStatus,omitempty"`
+}
+
+// ProfileMappingListOptions are the optional query parameters of ListProfileMappings
+type ProfileMappingListOptions struct {
+	SourceID string `url:"sourceId,omitempty"`
+	TargetID string `url:"targetId,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	After    string `url:"after,omitempty"`
+}
+
+// ListProfileMappings: List the profile mappings, filtered by the ID of the source or target. The listed
+// mappings have no Properties, use GetProfileMapping for them
+func (p *ProfileMappingsService) ListProfileMappings(opt *ProfileMappingListOptions) ([]ProfileMapping, *Response, error) {
+	u, err := addOptions("mappings", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var mappings []ProfileMapping
+	resp, err := p.client.do("GET", u, nil, &mappings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return mappings, resp, err
+}
+
+// GetProfileMapping: Get a profile mapping with its properties
+// Requires ProfileMapping ID from ProfileMapping object
+func (p *ProfileMappingsService) GetProfileMapping(id string) (*ProfileMapping, *Response, error) {
+	u := fmt.Sprintf("mappings/%v", id)
+	mapping := new(ProfileMapping)
+	resp, err := p.client.do("GET", u, nil, mapping)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return mapping, resp, err
+}
+
+// UpdateProfileMapping: Add, change or remove properties of a profile mapping. A nil property removes the
+// mapping of the attribute, properties left out are kept
+// Requires ProfileMapping ID from ProfileMapping object
+func (p *ProfileMappingsService) UpdateProfileMapping(id string, properties map[string]*ProfileMappingProperty) (*ProfileMapping, *Response, error) {
+	u := fmt.Sprintf("mappings/%v", id)
+	body := struct {
+		Properties map[string]*ProfileMappingProperty `json:"properties"`
+	}{properties}
+
+	mapping := new(ProfileMapping)
+	resp, err := p.client.do("POST", u, body, mapping)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return mapping, resp, err
+}
+
+// ProfileMappingDiff is the difference of the properties of a ProfileMapping to a desired set. Every list is
+// sorted by attribute
+type ProfileMappingDiff struct {
+	Added   []string
+	Changed []string
+	Removed []string
+
+	desired map[string]ProfileMappingProperty
+}
+
+// DiffProfileMapping compares the properties of current, from GetProfileMapping, to desired. A desired property
+// with an empty PushStatus matches any push status. A nil current is a mapping without properties
+func DiffProfileMapping(current *ProfileMapping, desired map[string]ProfileMappingProperty) ProfileMappingDiff {
+	if current == nil {
+		current = new(ProfileMapping)
+	}
+	diff := ProfileMappingDiff{desired: desired}
+	for attribute, want := range desired {
+		got := current.Properties[attribute]
+		switch {
+		case got == nil:
+			diff.Added = append(diff.Added, attribute)
+		case got.Expression != want.Expression || (want.PushStatus != "" && got.PushStatus != want.PushStatus):
+			diff.Changed = append(diff.Changed, attribute)
+		}
+	}
+	for attribute, got := range current.Properties {
+		if _, ok := desired[attribute]; !ok && got != nil {
+			diff.Removed = append(diff.Removed, attribute)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// Empty reports if the mapping already has the desired properties
+func (d ProfileMappingDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Properties returns the properties to pass to UpdateProfileMapping to apply the diff. Removed properties
+// are only removed when remove is true
+func (d ProfileMappingDiff) Properties(remove bool) map[string]*ProfileMappingProperty {
+	properties := make(map[string]*ProfileMappingProperty)
+	for _, attributes := range [][]string{d.Added, d.Changed} {
+		for _, attribute := range attributes {
+			property := d.desired[attribute]
+			properties[attribute] = &property
+		}
+	}
+	if remove {
+		for _, attribute := range d.Removed {
+			properties[attribute] = nil
+		}
+	}
+	return properties
+}
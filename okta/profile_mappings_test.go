@@ -0,0 +1,115 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testProfileMapping = `{
+	"id": "prm1k47ghydIQOTBW0g4",
+	"source": {"id": "0oa1qmn4LZQQEH0wZ0g4", "name": "okta_org2org", "type": "appuser", "_links": {"self": {"href": "https://your-domain.okta.com/api/v1/apps/0oa1qmn4LZQQEH0wZ0g4"}}},
+	"target": {"id": "otysbePhQ3yqt4cVv0g3", "name": "user", "type": "user", "_links": {"self": {"href": "https://your-domain.okta.com/api/v1/meta/types/user/otysbePhQ3yqt4cVv0g3"}}},
+	"properties": {
+		"firstName": {"expression": "appuser.firstName", "pushStatus": "PUSH"},
+		"lastName": {"expression": "appuser.lastName", "pushStatus": "PUSH"},
+		"nickName": {"expression": "appuser.nickName", "pushStatus": "DONT_PUSH"},
+		"title": {"expression": "appuser.title", "pushStatus": "PUSH"}
+	}
+}`
+
+func TestListProfileMappings(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mappings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		if got := r.URL.Query(); got.Get("sourceId") != "0oa1qmn4LZQQEH0wZ0g4" || got.Get("targetId") != "" || got.Get("limit") != "20" {
+			t.Errorf("ProfileMappings.ListProfileMappings sent %v", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"id": "prm1k47ghydIQOTBW0g4", "source": {"id": "0oa1qmn4LZQQEH0wZ0g4", "name": "okta_org2org", "type": "appuser"}, "target": {"id": "otysbePhQ3yqt4cVv0g3", "name": "user", "type": "user"}}]`)
+	})
+
+	mappings, _, err := client.ProfileMappings.ListProfileMappings(&ProfileMappingListOptions{SourceID: "0oa1qmn4LZQQEH0wZ0g4", Limit: 20})
+	if err != nil {
+		t.Fatalf("ProfileMappings.ListProfileMappings returned error: %v", err)
+	}
+	want := []ProfileMapping{{
+		ID:     "prm1k47ghydIQOTBW0g4",
+		Source: &ProfileMappingSource{ID: "0oa1qmn4LZQQEH0wZ0g4", Name: "okta_org2org", Type: ProfileMappingSourceApp},
+		Target: &ProfileMappingSource{ID: "otysbePhQ3yqt4cVv0g3", Name: "user", Type: ProfileMappingSourceUser},
+	}}
+	if !reflect.DeepEqual(mappings, want) {
+		t.Errorf("client.ProfileMappings.ListProfileMappings returned \n\t%+v, want \n\t%+v\n", mappings, want)
+	}
+}
+
+func TestUpdateProfileMappingFromDiff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/mappings/prm1k47ghydIQOTBW0g4", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			testBody(t, r, map[string]interface{}{"properties": map[string]interface{}{
+				"lastName": map[string]string{"expression": "appuser.familyName", "pushStatus": "PUSH"},
+				"nickName": nil,
+				"title":    map[string]string{"expression": "appuser.title", "pushStatus": "DONT_PUSH"},
+				"userType": map[string]string{"expression": "\"Contractor\""},
+			}})
+		} else {
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, testProfileMapping)
+	})
+
+	current, _, err := client.ProfileMappings.GetProfileMapping("prm1k47ghydIQOTBW0g4")
+	if err != nil {
+		t.Fatalf("ProfileMappings.GetProfileMapping returned error: %v", err)
+	}
+	if current.Source.Type != ProfileMappingSourceApp || current.Properties["nickName"].PushStatus != ProfileMappingDontPush {
+		t.Errorf("client.ProfileMappings.GetProfileMapping returned %+v", current)
+	}
+
+	desired := map[string]ProfileMappingProperty{
+		"firstName": {Expression: "appuser.firstName"},
+		"lastName":  {Expression: "appuser.familyName", PushStatus: ProfileMappingPush},
+		"title":     {Expression: "appuser.title", PushStatus: ProfileMappingDontPush},
+		"userType":  {Expression: `"Contractor"`},
+	}
+	diff := DiffProfileMapping(current, desired)
+	want := ProfileMappingDiff{Added: []string{"userType"}, Changed: []string{"lastName", "title"}, Removed: []string{"nickName"}, desired: desired}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("DiffProfileMapping returned \n\t%+v, want \n\t%+v\n", diff, want)
+	}
+	if diff.Empty() || len(diff.Properties(false)) != 3 {
+		t.Errorf("ProfileMappingDiff.Properties(false) returned %+v", diff.Properties(false))
+	}
+
+	if _, _, err := client.ProfileMappings.UpdateProfileMapping(current.ID, diff.Properties(true)); err != nil {
+		t.Errorf("ProfileMappings.UpdateProfileMapping returned error: %v", err)
+	}
+
+	if same := DiffProfileMapping(current, map[string]ProfileMappingProperty{
+		"firstName": {Expression: "appuser.firstName"},
+		"lastName":  {Expression: "appuser.lastName"},
+		"nickName":  {Expression: "appuser.nickName", PushStatus: ProfileMappingDontPush},
+		"title":     {Expression: "appuser.title"},
+	}); !same.Empty() {
+		t.Errorf("DiffProfileMapping of the current properties returned %+v", same)
+	}
+}
+
+func TestDiffProfileMappingNil(t *testing.T) {
+	desired := map[string]ProfileMappingProperty{
+		"firstName": {Expression: "appuser.firstName"},
+		"lastName":  {Expression: "appuser.lastName"},
+	}
+	diff := DiffProfileMapping(nil, desired)
+	want := ProfileMappingDiff{Added: []string{"firstName", "lastName"}, desired: desired}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("DiffProfileMapping of a nil mapping returned \n\t%+v, want \n\t%+v\n", diff, want)
+	}
+}
@@ -0,0 +1,609 @@
+package okta
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// RoleTypeSuperAdmin is the built in Super Administrator role
+	RoleTypeSuperAdmin = "SUPER_ADMIN"
+	// RoleTypeOrgAdmin is the built in Organization Administrator role
+	RoleTypeOrgAdmin = "ORG_ADMIN"
+	// RoleTypeAPIAccessManagementAdmin is the built in API Access Management Administrator role
+	RoleTypeAPIAccessManagementAdmin = "API_ACCESS_MANAGEMENT_ADMIN"
+	// RoleTypeAppAdmin is the built in Application Administrator role. It can be scoped to apps with app targets
+	RoleTypeAppAdmin = "APP_ADMIN"
+	// RoleTypeUserAdmin is the built in Group Administrator role. It can be scoped to groups with group targets
+	RoleTypeUserAdmin = "USER_ADMIN"
+	// RoleTypeGroupMembershipAdmin is the built in Group Membership Administrator role. It can be scoped to groups with group targets
+	RoleTypeGroupMembershipAdmin = "GROUP_MEMBERSHIP_ADMIN"
+	// RoleTypeHelpDeskAdmin is the built in Help Desk Administrator role. It can be scoped to groups with group targets
+	RoleTypeHelpDeskAdmin = "HELP_DESK_ADMIN"
+	// RoleTypeMobileAdmin is the built in Mobile Administrator role
+	RoleTypeMobileAdmin = "MOBILE_ADMIN"
+	// RoleTypeReadOnlyAdmin is the built in Read Only Administrator role
+	RoleTypeReadOnlyAdmin = "READ_ONLY_ADMIN"
+	// RoleTypeReportAdmin is the built in Report Administrator role
+	RoleTypeReportAdmin = "REPORT_ADMIN"
+	// RoleTypeCustom is used to assign a custom role together with a resource set
+	RoleTypeCustom = "CUSTOM"
+
+	// RoleAssignmentTypeUser - the role is assigned directly to the user
+	RoleAssignmentTypeUser = "USER"
+	// RoleAssignmentTypeGroup - the role is assigned to a group the user is a member of
+	RoleAssignmentTypeGroup = "GROUP"
+)
+
+// RolesService handles communication with the Administrator Role related
+// methods of the OKTA API. Roles can be assigned to users and groups, scoped with
+// group and app targets, and built from custom permissions and resource sets
+// https://developer.okta.com/docs/reference/api/roles/
+type RolesService service
+
+// Role represents a role assigned to a user or group
+type Role struct {
+	ID             string     `json:"id,omitempty"`
+	Label          string     `json:"label,omitempty"`
+	Type           string     `json:"type,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	AssignmentType string     `json:"assignmentType,omitempty"`
+	Created        time.Time  `json:"created,omitempty"`
+	LastUpdated    time.Time  `json:"lastUpdated,omitempty"`
+	Links          *RoleLinks `json:"_links,omitempty"`
+}
+
+// RoleLinks holds the links returned with a role assignment
+type RoleLinks struct {
+	Assignee    *RoleLink `json:"assignee,omitempty"`
+	Member      *RoleLink `json:"member,omitempty"`
+	Role        *RoleLink `json:"role,omitempty"`
+	ResourceSet *RoleLink `json:"resource-set,omitempty"`
+	Permissions *RoleLink `json:"permissions,omitempty"`
+}
+
+// RoleLink is a single link of a role related object
+type RoleLink struct {
+	Href string `json:"href,omitempty"`
+}
+
+// RoleAssignment is the request body used to assign a role to a user or group.
+// Built in roles only need Type. Custom roles need Type set to RoleTypeCustom
+// plus the custom Role ID and the ResourceSet ID it applies to
+type RoleAssignment struct {
+	Type        string `json:"type"`
+	Role        string `json:"role,omitempty"`
+	ResourceSet string `json:"resource-set,omitempty"`
+}
+
+// RoleAppTarget represents an app target of an APP_ADMIN role. ID is only set when the
+// target is a specific app instance rather than every instance of a catalog app
+type RoleAppTarget struct {
+	ID          string    `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Category    string    `json:"category,omitempty"`
+	SignOnModes []string  `json:"signOnModes,omitempty"`
+	Features    []string  `json:"features,omitempty"`
+	LastUpdated time.Time `json:"lastUpdated,omitempty"`
+}
+
+// CustomRole is a role built from a set of permissions
+type CustomRole struct {
+	ID          string     `json:"id,omitempty"`
+	Label       string     `json:"label,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Permissions []string   `json:"permissions,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+	Links       *RoleLinks `json:"_links,omitempty"`
+}
+
+// CustomRolePermission is a single permission granted by a custom role, such as "okta.users.read"
+type CustomRolePermission struct {
+	Label       string     `json:"label,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// ResourceSet is a collection of resources (identified by their ORN or REST URL) that a custom role applies to
+type ResourceSet struct {
+	ID          string     `json:"id,omitempty"`
+	Label       string     `json:"label,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Resources   []string   `json:"resources,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// ResourceSetResource is a single resource in a resource set
+type ResourceSetResource struct {
+	ID          string     `json:"id,omitempty"`
+	ORN         string     `json:"orn,omitempty"`
+	Created     *time.Time `json:"created,omitempty"`
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+	Links       struct {
+		Self *RoleLink `json:"self,omitempty"`
+	} `json:"_links,omitempty"`
+}
+
+// ROLE ASSIGNMENTS
+
+// ListUserRoles: List the roles assigned to a user
+// Requires User ID from User object
+func (p *RolesService) ListUserRoles(userID string) ([]Role, *Response, error) {
+	return p.listRoles(fmt.Sprintf("users/%v", userID))
+}
+
+// AssignUserRole: Assign a built in or custom role to a user
+// Requires User ID from User object
+func (p *RolesService) AssignUserRole(userID string, assignment RoleAssignment) (*Role, *Response, error) {
+	return p.assignRole(fmt.Sprintf("users/%v", userID), assignment)
+}
+
+// UnassignUserRole: Unassign a role from a user
+// Requires User ID from User object and Role ID from Role object
+func (p *RolesService) UnassignUserRole(userID string, roleID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("users/%v/roles/%v", userID, roleID))
+}
+
+// ListGroupRoles: List the roles assigned to a group
+// Requires Group ID from Group object
+func (p *RolesService) ListGroupRoles(groupID string) ([]Role, *Response, error) {
+	return p.listRoles(fmt.Sprintf("groups/%v", groupID))
+}
+
+// AssignGroupRole: Assign a built in or custom role to a group. Every member of the group is granted the role
+// Requires Group ID from Group object
+func (p *RolesService) AssignGroupRole(groupID string, assignment RoleAssignment) (*Role, *Response, error) {
+	return p.assignRole(fmt.Sprintf("groups/%v", groupID), assignment)
+}
+
+// UnassignGroupRole: Unassign a role from a group
+// Requires Group ID from Group object and Role ID from Role object
+func (p *RolesService) UnassignGroupRole(groupID string, roleID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("groups/%v/roles/%v", groupID, roleID))
+}
+
+func (p *RolesService) listRoles(principal string) ([]Role, *Response, error) {
+	u := fmt.Sprintf("%v/roles", principal)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roles := make([]Role, 0)
+	resp, err := p.client.Do(req, &roles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return roles, resp, err
+}
+
+func (p *RolesService) assignRole(principal string, assignment RoleAssignment) (*Role, *Response, error) {
+	if assignment.Type == "" {
+		return nil, nil, errors.New("[ERROR] RoleAssignment Type is required")
+	}
+	if assignment.Type == RoleTypeCustom && (assignment.Role == "" || assignment.ResourceSet == "") {
+		return nil, nil, errors.New("[ERROR] RoleAssignment for a CUSTOM role requires Role and ResourceSet")
+	}
+
+	u := fmt.Sprintf("%v/roles", principal)
+	req, err := p.client.NewRequest("POST", u, assignment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(Role)
+	resp, err := p.client.Do(req, role)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return role, resp, err
+}
+
+// GROUP TARGETS
+
+// ListUserRoleGroupTargets: List the groups a user's role is scoped to
+// Requires User ID from User object and Role ID from Role object
+func (p *RolesService) ListUserRoleGroupTargets(userID string, roleID string) ([]Group, *Response, error) {
+	return p.listGroupTargets(fmt.Sprintf("users/%v/roles/%v", userID, roleID))
+}
+
+// AddUserRoleGroupTarget: Scope a user's role to a group
+// Requires User ID from User object, Role ID from Role object and Group ID from Group object
+func (p *RolesService) AddUserRoleGroupTarget(userID string, roleID string, groupID string) (*Response, error) {
+	return p.put(fmt.Sprintf("users/%v/roles/%v/targets/groups/%v", userID, roleID, groupID))
+}
+
+// RemoveUserRoleGroupTarget: Remove a group from the scope of a user's role
+// Requires User ID from User object, Role ID from Role object and Group ID from Group object
+func (p *RolesService) RemoveUserRoleGroupTarget(userID string, roleID string, groupID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("users/%v/roles/%v/targets/groups/%v", userID, roleID, groupID))
+}
+
+// ListGroupRoleGroupTargets: List the groups a group's role is scoped to
+// Requires Group ID from Group object and Role ID from Role object
+func (p *RolesService) ListGroupRoleGroupTargets(groupID string, roleID string) ([]Group, *Response, error) {
+	return p.listGroupTargets(fmt.Sprintf("groups/%v/roles/%v", groupID, roleID))
+}
+
+// AddGroupRoleGroupTarget: Scope a group's role to a target group
+// Requires Group ID from Group object, Role ID from Role object and the target Group ID
+func (p *RolesService) AddGroupRoleGroupTarget(groupID string, roleID string, targetGroupID string) (*Response, error) {
+	return p.put(fmt.Sprintf("groups/%v/roles/%v/targets/groups/%v", groupID, roleID, targetGroupID))
+}
+
+// RemoveGroupRoleGroupTarget: Remove a target group from the scope of a group's role
+// Requires Group ID from Group object, Role ID from Role object and the target Group ID
+func (p *RolesService) RemoveGroupRoleGroupTarget(groupID string, roleID string, targetGroupID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("groups/%v/roles/%v/targets/groups/%v", groupID, roleID, targetGroupID))
+}
+
+func (p *RolesService) listGroupTargets(role string) ([]Group, *Response, error) {
+	u := fmt.Sprintf("%v/targets/groups", role)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets := make([]Group, 0)
+	resp, err := p.client.Do(req, &targets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return targets, resp, err
+}
+
+// APP TARGETS
+
+// ListUserRoleAppTargets: List the apps a user's role is scoped to
+// Requires User ID from User object and Role ID from Role object
+func (p *RolesService) ListUserRoleAppTargets(userID string, roleID string) ([]RoleAppTarget, *Response, error) {
+	return p.listAppTargets(fmt.Sprintf("users/%v/roles/%v", userID, roleID))
+}
+
+// AddUserRoleAppTarget: Scope a user's role to every instance of a catalog app, for example "salesforce"
+// Requires User ID from User object, Role ID from Role object and the app name from App object
+func (p *RolesService) AddUserRoleAppTarget(userID string, roleID string, appName string) (*Response, error) {
+	return p.put(fmt.Sprintf("users/%v/roles/%v/targets/catalog/apps/%v", userID, roleID, appName))
+}
+
+// AddUserRoleAppInstanceTarget: Scope a user's role to a single app instance
+// Requires User ID from User object, Role ID from Role object, and the name and ID from App object
+func (p *RolesService) AddUserRoleAppInstanceTarget(userID string, roleID string, appName string, appID string) (*Response, error) {
+	return p.put(fmt.Sprintf("users/%v/roles/%v/targets/catalog/apps/%v/%v", userID, roleID, appName, appID))
+}
+
+// RemoveUserRoleAppTarget: Remove a catalog app from the scope of a user's role
+// Requires User ID from User object, Role ID from Role object and the app name from App object
+func (p *RolesService) RemoveUserRoleAppTarget(userID string, roleID string, appName string) (*Response, error) {
+	return p.delete(fmt.Sprintf("users/%v/roles/%v/targets/catalog/apps/%v", userID, roleID, appName))
+}
+
+// RemoveUserRoleAppInstanceTarget: Remove a single app instance from the scope of a user's role
+// Requires User ID from User object, Role ID from Role object, and the name and ID from App object
+func (p *RolesService) RemoveUserRoleAppInstanceTarget(userID string, roleID string, appName string, appID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("users/%v/roles/%v/targets/catalog/apps/%v/%v", userID, roleID, appName, appID))
+}
+
+// ListGroupRoleAppTargets: List the apps a group's role is scoped to
+// Requires Group ID from Group object and Role ID from Role object
+func (p *RolesService) ListGroupRoleAppTargets(groupID string, roleID string) ([]RoleAppTarget, *Response, error) {
+	return p.listAppTargets(fmt.Sprintf("groups/%v/roles/%v", groupID, roleID))
+}
+
+// AddGroupRoleAppTarget: Scope a group's role to every instance of a catalog app
+// Requires Group ID from Group object, Role ID from Role object and the app name from App object
+func (p *RolesService) AddGroupRoleAppTarget(groupID string, roleID string, appName string) (*Response, error) {
+	return p.put(fmt.Sprintf("groups/%v/roles/%v/targets/catalog/apps/%v", groupID, roleID, appName))
+}
+
+// AddGroupRoleAppInstanceTarget: Scope a group's role to a single app instance
+// Requires Group ID from Group object, Role ID from Role object, and the name and ID from App object
+func (p *RolesService) AddGroupRoleAppInstanceTarget(groupID string, roleID string, appName string, appID string) (*Response, error) {
+	return p.put(fmt.Sprintf("groups/%v/roles/%v/targets/catalog/apps/%v/%v", groupID, roleID, appName, appID))
+}
+
+// RemoveGroupRoleAppTarget: Remove a catalog app from the scope of a group's role
+// Requires Group ID from Group object, Role ID from Role object and the app name from App object
+func (p *RolesService) RemoveGroupRoleAppTarget(groupID string, roleID string, appName string) (*Response, error) {
+	return p.delete(fmt.Sprintf("groups/%v/roles/%v/targets/catalog/apps/%v", groupID, roleID, appName))
+}
+
+// RemoveGroupRoleAppInstanceTarget: Remove a single app instance from the scope of a group's role
+// Requires Group ID from Group object, Role ID from Role object, and the name and ID from App object
+func (p *RolesService) RemoveGroupRoleAppInstanceTarget(groupID string, roleID string, appName string, appID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("groups/%v/roles/%v/targets/catalog/apps/%v/%v", groupID, roleID, appName, appID))
+}
+
+func (p *RolesService) listAppTargets(role string) ([]RoleAppTarget, *Response, error) {
+	u := fmt.Sprintf("%v/targets/catalog/apps", role)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets := make([]RoleAppTarget, 0)
+	resp, err := p.client.Do(req, &targets)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return targets, resp, err
+}
+
+// CUSTOM ROLES
+
+// ListCustomRoles: List the custom roles of the org
+func (p *RolesService) ListCustomRoles() ([]CustomRole, *Response, error) {
+	req, err := p.client.NewRequest("GET", "iam/roles", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list struct {
+		Roles []CustomRole `json:"roles"`
+	}
+	resp, err := p.client.Do(req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list.Roles, resp, err
+}
+
+// GetCustomRole: Get a custom role
+// Requires the ID or label of the CustomRole
+func (p *RolesService) GetCustomRole(id string) (*CustomRole, *Response, error) {
+	u := fmt.Sprintf("iam/roles/%v", id)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role := new(CustomRole)
+	resp, err := p.client.Do(req, role)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return role, resp, err
+}
+
+// CreateCustomRole: Create a custom role with a label, description and the list of permissions it grants
+func (p *RolesService) CreateCustomRole(role CustomRole) (*CustomRole, *Response, error) {
+	req, err := p.client.NewRequest("POST", "iam/roles", role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newRole := new(CustomRole)
+	resp, err := p.client.Do(req, newRole)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newRole, resp, err
+}
+
+// UpdateCustomRole: Update the label and description of a custom role
+// Requires the ID of the CustomRole
+func (p *RolesService) UpdateCustomRole(id string, role CustomRole) (*CustomRole, *Response, error) {
+	u := fmt.Sprintf("iam/roles/%v", id)
+	req, err := p.client.NewRequest("PUT", u, role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateRole := new(CustomRole)
+	resp, err := p.client.Do(req, updateRole)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updateRole, resp, err
+}
+
+// DeleteCustomRole: Delete a custom role
+// Requires the ID of the CustomRole
+func (p *RolesService) DeleteCustomRole(id string) (*Response, error) {
+	return p.delete(fmt.Sprintf("iam/roles/%v", id))
+}
+
+// ListCustomRolePermissions: List the permissions granted by a custom role
+// Requires the ID of the CustomRole
+func (p *RolesService) ListCustomRolePermissions(id string) ([]CustomRolePermission, *Response, error) {
+	u := fmt.Sprintf("iam/roles/%v/permissions", id)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list struct {
+		Permissions []CustomRolePermission `json:"permissions"`
+	}
+	resp, err := p.client.Do(req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list.Permissions, resp, err
+}
+
+// AddCustomRolePermission: Grant a permission, such as "okta.users.manage", with a custom role
+// Requires the ID of the CustomRole
+func (p *RolesService) AddCustomRolePermission(id string, permission string) (*Response, error) {
+	u := fmt.Sprintf("iam/roles/%v/permissions/%v", id, permission)
+	req, err := p.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.Do(req, nil)
+}
+
+// DeleteCustomRolePermission: Remove a permission from a custom role
+// Requires the ID of the CustomRole
+func (p *RolesService) DeleteCustomRolePermission(id string, permission string) (*Response, error) {
+	return p.delete(fmt.Sprintf("iam/roles/%v/permissions/%v", id, permission))
+}
+
+// RESOURCE SETS
+
+// ListResourceSets: List the resource sets of the org
+func (p *RolesService) ListResourceSets() ([]ResourceSet, *Response, error) {
+	req, err := p.client.NewRequest("GET", "iam/resource-sets", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list struct {
+		ResourceSets []ResourceSet `json:"resource-sets"`
+	}
+	resp, err := p.client.Do(req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list.ResourceSets, resp, err
+}
+
+// GetResourceSet: Get a resource set
+// Requires the ID of the ResourceSet
+func (p *RolesService) GetResourceSet(id string) (*ResourceSet, *Response, error) {
+	u := fmt.Sprintf("iam/resource-sets/%v", id)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set := new(ResourceSet)
+	resp, err := p.client.Do(req, set)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return set, resp, err
+}
+
+// CreateResourceSet: Create a resource set with a label, description and its initial resources
+func (p *RolesService) CreateResourceSet(set ResourceSet) (*ResourceSet, *Response, error) {
+	req, err := p.client.NewRequest("POST", "iam/resource-sets", set)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newSet := new(ResourceSet)
+	resp, err := p.client.Do(req, newSet)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newSet, resp, err
+}
+
+// UpdateResourceSet: Update the label and description of a resource set
+// Requires the ID of the ResourceSet
+func (p *RolesService) UpdateResourceSet(id string, set ResourceSet) (*ResourceSet, *Response, error) {
+	u := fmt.Sprintf("iam/resource-sets/%v", id)
+	req, err := p.client.NewRequest("PUT", u, set)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updateSet := new(ResourceSet)
+	resp, err := p.client.Do(req, updateSet)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updateSet, resp, err
+}
+
+// DeleteResourceSet: Delete a resource set
+// Requires the ID of the ResourceSet
+func (p *RolesService) DeleteResourceSet(id string) (*Response, error) {
+	return p.delete(fmt.Sprintf("iam/resource-sets/%v", id))
+}
+
+// ListResourceSetResources: List the resources in a resource set
+// Requires the ID of the ResourceSet
+func (p *RolesService) ListResourceSetResources(id string) ([]ResourceSetResource, *Response, error) {
+	u := fmt.Sprintf("iam/resource-sets/%v/resources", id)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var list struct {
+		Resources []ResourceSetResource `json:"resources"`
+	}
+	resp, err := p.client.Do(req, &list)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return list.Resources, resp, err
+}
+
+// AddResourceSetResources: Add resources, identified by their ORN or REST URL, to a resource set
+// Requires the ID of the ResourceSet
+func (p *RolesService) AddResourceSetResources(id string, resources []string) (*ResourceSet, *Response, error) {
+	u := fmt.Sprintf("iam/resource-sets/%v/resources", id)
+	body := struct {
+		Additions []string `json:"additions"`
+	}{
+		Additions: resources,
+	}
+	req, err := p.client.NewRequest("PATCH", u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set := new(ResourceSet)
+	resp, err := p.client.Do(req, set)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return set, resp, err
+}
+
+// DeleteResourceSetResource: Remove a resource from a resource set
+// Requires the ID of the ResourceSet and the ID of the ResourceSetResource
+func (p *RolesService) DeleteResourceSetResource(id string, resourceID string) (*Response, error) {
+	return p.delete(fmt.Sprintf("iam/resource-sets/%v/resources/%v", id, resourceID))
+}
+
+func (p *RolesService) put(u string) (*Response, error) {
+	req, err := p.client.NewRequest("PUT", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+func (p *RolesService) delete(u string) (*Response, error) {
+	req, err := p.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
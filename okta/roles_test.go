@@ -0,0 +1,220 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+var testCustomRole *CustomRole
+var testResourceSet *ResourceSet
+
+func setupTestCustomRoles() {
+	testCustomRole = &CustomRole{
+		ID:          "cr0Yq6IJxGIr0ouum0g3",
+		Label:       "UserCreator",
+		Description: "Create users",
+		Permissions: []string{"okta.users.create", "okta.users.read"},
+	}
+
+	testResourceSet = &ResourceSet{
+		ID:          "iamoJDFKaJxGIr0oamd9g",
+		Label:       "SF-IT-People",
+		Description: "People in the IT department of San Francisco",
+	}
+}
+
+func TestAssignUserCustomRole(t *testing.T) {
+	setup()
+	defer teardown()
+	setupTestCustomRoles()
+
+	assignment := RoleAssignment{
+		Type:        RoleTypeCustom,
+		Role:        testCustomRole.ID,
+		ResourceSet: testResourceSet.ID,
+	}
+	want := &Role{ID: "irb1qe6PGuMc7Oh8N0g4", Label: "UserCreator", Type: RoleTypeCustom, Status: "ACTIVE", AssignmentType: RoleAssignmentTypeUser}
+
+	mux.HandleFunc("/users/00ub0oNGTSWTBKOLGLNR/roles", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, assignment)
+		json.NewEncoder(w).Encode(want)
+	})
+
+	role, _, err := client.Roles.AssignUserRole("00ub0oNGTSWTBKOLGLNR", assignment)
+	if err != nil {
+		t.Errorf("Roles.AssignUserRole returned error: %v", err)
+	}
+	if !reflect.DeepEqual(role, want) {
+		t.Errorf("client.Roles.AssignUserRole returned \n\t%+v, want \n\t%+v\n", role, want)
+	}
+
+	if _, _, err := client.Roles.AssignUserRole("00ub0oNGTSWTBKOLGLNR", RoleAssignment{Type: RoleTypeCustom}); err == nil {
+		t.Errorf("Roles.AssignUserRole expected an error for a custom role without a resource set")
+	}
+}
+
+func TestListGroupRoles(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []Role{{ID: "IFIFAX2BIRGUSTQ", Label: "Application Administrator", Type: RoleTypeAppAdmin, Status: "ACTIVE", AssignmentType: RoleAssignmentTypeGroup}}
+
+	mux.HandleFunc("/groups/00g1emaKYZTWRYYRRTSK/roles", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		json.NewEncoder(w).Encode(want)
+	})
+
+	roles, _, err := client.Roles.ListGroupRoles("00g1emaKYZTWRYYRRTSK")
+	if err != nil {
+		t.Errorf("Roles.ListGroupRoles returned error: %v", err)
+	}
+	if !reflect.DeepEqual(roles, want) {
+		t.Errorf("client.Roles.ListGroupRoles returned \n\t%+v, want \n\t%+v\n", roles, want)
+	}
+}
+
+func TestUserRoleGroupTargets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/00u1/roles/KVJUKUS7IFCE2SKO/targets/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `[{"id":"00g1emaKYZTWRYYRRTSK","type":"OKTA_GROUP","profile":{"name":"Engineering","description":"All engineers"}}]`)
+	})
+	mux.HandleFunc("/users/00u1/roles/KVJUKUS7IFCE2SKO/targets/groups/00g1emaKYZTWRYYRRTSK", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" && r.Method != "DELETE" {
+			t.Errorf("Request method: %v, want PUT or DELETE", r.Method)
+		}
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	targets, _, err := client.Roles.ListUserRoleGroupTargets("00u1", "KVJUKUS7IFCE2SKO")
+	if err != nil {
+		t.Errorf("Roles.ListUserRoleGroupTargets returned error: %v", err)
+	}
+	if len(targets) != 1 || targets[0].GroupProfile.Name != "Engineering" {
+		t.Errorf("client.Roles.ListUserRoleGroupTargets returned %+v", targets)
+	}
+	if _, err := client.Roles.AddUserRoleGroupTarget("00u1", "KVJUKUS7IFCE2SKO", "00g1emaKYZTWRYYRRTSK"); err != nil {
+		t.Errorf("Roles.AddUserRoleGroupTarget returned error: %v", err)
+	}
+	if _, err := client.Roles.RemoveUserRoleGroupTarget("00u1", "KVJUKUS7IFCE2SKO", "00g1emaKYZTWRYYRRTSK"); err != nil {
+		t.Errorf("Roles.RemoveUserRoleGroupTarget returned error: %v", err)
+	}
+}
+
+func TestGroupRoleAppTargets(t *testing.T) {
+	setup()
+	defer teardown()
+
+	want := []RoleAppTarget{
+		{Name: "salesforce", DisplayName: "Salesforce.com", Status: "ACTIVE"},
+		{ID: "0oa1j1g6iXQPLd3wr0g4", Name: "zendesk", DisplayName: "Zendesk", Status: "ACTIVE"},
+	}
+
+	mux.HandleFunc("/groups/00g1/roles/IFIFAX2BIRGUSTQ/targets/catalog/apps", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		json.NewEncoder(w).Encode(want)
+	})
+	mux.HandleFunc("/groups/00g1/roles/IFIFAX2BIRGUSTQ/targets/catalog/apps/zendesk/0oa1j1g6iXQPLd3wr0g4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	targets, _, err := client.Roles.ListGroupRoleAppTargets("00g1", "IFIFAX2BIRGUSTQ")
+	if err != nil {
+		t.Errorf("Roles.ListGroupRoleAppTargets returned error: %v", err)
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Errorf("client.Roles.ListGroupRoleAppTargets returned \n\t%+v, want \n\t%+v\n", targets, want)
+	}
+	if _, err := client.Roles.AddGroupRoleAppInstanceTarget("00g1", "IFIFAX2BIRGUSTQ", "zendesk", "0oa1j1g6iXQPLd3wr0g4"); err != nil {
+		t.Errorf("Roles.AddGroupRoleAppInstanceTarget returned error: %v", err)
+	}
+}
+
+func TestCreateCustomRole(t *testing.T) {
+	setup()
+	defer teardown()
+	setupTestCustomRoles()
+
+	input := *testCustomRole
+	input.ID = ""
+
+	mux.HandleFunc("/iam/roles", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, input)
+		json.NewEncoder(w).Encode(testCustomRole)
+	})
+
+	role, _, err := client.Roles.CreateCustomRole(input)
+	if err != nil {
+		t.Errorf("Roles.CreateCustomRole returned error: %v", err)
+	}
+	if !reflect.DeepEqual(role, testCustomRole) {
+		t.Errorf("client.Roles.CreateCustomRole returned \n\t%+v, want \n\t%+v\n", role, testCustomRole)
+	}
+}
+
+func TestListCustomRolePermissions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/iam/roles/cr0Yq6IJxGIr0ouum0g3/permissions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"permissions":[{"label":"okta.users.create"},{"label":"okta.users.read"}]}`)
+	})
+
+	permissions, _, err := client.Roles.ListCustomRolePermissions("cr0Yq6IJxGIr0ouum0g3")
+	if err != nil {
+		t.Errorf("Roles.ListCustomRolePermissions returned error: %v", err)
+	}
+	want := []CustomRolePermission{{Label: "okta.users.create"}, {Label: "okta.users.read"}}
+	if !reflect.DeepEqual(permissions, want) {
+		t.Errorf("client.Roles.ListCustomRolePermissions returned \n\t%+v, want \n\t%+v\n", permissions, want)
+	}
+}
+
+func TestResourceSets(t *testing.T) {
+	setup()
+	defer teardown()
+	setupTestCustomRoles()
+
+	resources := []string{"https://your-domain.okta.com/api/v1/groups/00guaxWZ0AOa5NFAj0g3"}
+
+	mux.HandleFunc("/iam/resource-sets", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprintf(w, `{"resource-sets":[{"id":"%v","label":"%v","description":"%v"}]}`, testResourceSet.ID, testResourceSet.Label, testResourceSet.Description)
+	})
+	mux.HandleFunc("/iam/resource-sets/iamoJDFKaJxGIr0oamd9g/resources", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		testAuthHeader(t, r)
+		testBody(t, r, map[string][]string{"additions": resources})
+		json.NewEncoder(w).Encode(testResourceSet)
+	})
+
+	sets, _, err := client.Roles.ListResourceSets()
+	if err != nil {
+		t.Errorf("Roles.ListResourceSets returned error: %v", err)
+	}
+	if !reflect.DeepEqual(sets, []ResourceSet{*testResourceSet}) {
+		t.Errorf("client.Roles.ListResourceSets returned \n\t%+v, want \n\t%+v\n", sets, testResourceSet)
+	}
+
+	if _, _, err := client.Roles.AddResourceSetResources(testResourceSet.ID, resources); err != nil {
+		t.Errorf("Roles.AddResourceSetResources returned error: %v", err)
+	}
+}
@@ -0,0 +1,418 @@
+package okta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// SchemaChangeAdd - the custom attribute is added
+	SchemaChangeAdd = "ADD"
+	// SchemaChangeUpdate - the custom attribute is updated in place
+	SchemaChangeUpdate = "CHANGE"
+	// SchemaChangeRemove - the custom attribute and its values are removed
+	SchemaChangeRemove = "REMOVE"
+)
+
+// SchemaChange is a change of a custom attribute in a SchemaPlan. Current is nil for an added attribute, Desired
+// for a removed one. Fields are the changed JSON attributes of the SubSchema, sorted
+type SchemaChange struct {
+	Action      string
+	Index       string
+	Current     *CustomSubSchema
+	Desired     *CustomSubSchema
+	Fields      []string
+	Destructive bool
+	Reasons     []string
+}
+
+// SchemaPlan are the changes of the custom attributes of a live schema to a desired set, removals first, each
+// group sorted by index
+type SchemaPlan struct {
+	Changes []SchemaChange
+}
+
+// SchemaApplyOptions are the options of ApplyUserSchemaPlan
+type SchemaApplyOptions struct {
+	// DryRun checks the plan without changing the schema
+	DryRun bool
+	// AllowDestructive applies destructive changes, the plan is refused when it has any and this is false
+	AllowDestructive bool
+}
+
+// ParseSchemaManifest returns the desired custom attributes of a JSON manifest, keyed by index like the
+// definitions of a schema:
+//
+//	{"properties": {"costCenter": {"title": "Cost center", "type": "string", ...}}}
+//
+// A schema JSON, such as from GetRawUserSchema of another org, is read from its custom definition
+func ParseSchemaManifest(data []byte) ([]CustomSubSchema, error) {
+	var manifest struct {
+		Properties  json.RawMessage `json:"properties"`
+		Definitions *struct {
+			Custom CustomSchemaDefinition `json:"custom"`
+		} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("[ERROR] invalid schema manifest: %v", err)
+	}
+	definition := new(CustomSchemaDefinition)
+	properties := manifest.Properties
+	if manifest.Definitions != nil {
+		definition = &manifest.Definitions.Custom
+		var custom struct {
+			Definitions struct {
+				Custom struct {
+					Properties json.RawMessage `json:"properties"`
+				} `json:"custom"`
+			} `json:"definitions"`
+		}
+		if err := json.Unmarshal(data, &custom); err != nil {
+			return nil, fmt.Errorf("[ERROR] invalid schema manifest: %v", err)
+		}
+		properties = custom.Definitions.Custom.Properties
+	} else if manifest.Properties == nil {
+		return nil, fmt.Errorf("[ERROR] schema manifest has no properties")
+	} else if err := json.Unmarshal(data, definition); err != nil {
+		return nil, fmt.Errorf("[ERROR] invalid schema manifest: %v", err)
+	}
+
+	// the attributes set by the manifest are kept, so false, 0 and "" values are planned like the others
+	var members map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(properties, &members); err != nil {
+		return nil, fmt.Errorf("[ERROR] invalid schema manifest: %v", err)
+	}
+	for i := range definition.Properties {
+		sub := &definition.Properties[i]
+		sub.manifestKeys = sortedSchemaKeys(members[sub.Index])
+	}
+	return definition.Properties, nil
+}
+
+// PlanCustomSchema returns the changes of the custom attributes of current to desired. Attributes of desired
+// are compared on the JSON attributes they set: the attributes held by the manifest for the ones returned by
+// ParseSchemaManifest, including false, 0 and "" values, otherwise the attributes that are not null or empty.
+// Attributes left out keep their live value. Custom attributes missing from desired are removed only when prune
+// is true
+func PlanCustomSchema(current *Schema, desired []CustomSubSchema, prune bool) (*SchemaPlan, error) {
+	live := make(map[string]*CustomSubSchema)
+	for i := range current.Definitions.Custom.Properties {
+		sub := &current.Definitions.Custom.Properties[i]
+		live[sub.Index] = sub
+	}
+
+	plan := new(SchemaPlan)
+	var removes, adds, changes []SchemaChange
+	wanted := make(map[string]bool)
+	for i := range desired {
+		want := &desired[i]
+		if want.Index == "" {
+			return nil, fmt.Errorf("[ERROR] desired custom subschema %v has no index", i)
+		}
+		if wanted[want.Index] {
+			return nil, fmt.Errorf("[ERROR] desired custom subschema %v is defined twice", want.Index)
+		}
+		wanted[want.Index] = true
+
+		got, ok := live[want.Index]
+		if !ok {
+			adds = append(adds, SchemaChange{Action: SchemaChangeAdd, Index: want.Index, Desired: want})
+			continue
+		}
+		fields, err := changedSubSchemaFields(got, want)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			change := SchemaChange{Action: SchemaChangeUpdate, Index: want.Index, Current: got, Desired: want, Fields: fields}
+			change.Reasons = destructiveReasons(got, want)
+			change.Destructive = len(change.Reasons) > 0
+			changes = append(changes, change)
+		}
+	}
+	if prune {
+		for index, got := range live {
+			if !wanted[index] {
+				removes = append(removes, SchemaChange{
+					Action:      SchemaChangeRemove,
+					Index:       index,
+					Current:     got,
+					Destructive: true,
+					Reasons:     []string{"removes the attribute and its values from every profile"},
+				})
+			}
+		}
+	}
+
+	for _, group := range [][]SchemaChange{removes, adds, changes} {
+		sort.Slice(group, func(i, j int) bool { return group[i].Index < group[j].Index })
+		plan.Changes = append(plan.Changes, group...)
+	}
+	return plan, nil
+}
+
+// changedSubSchemaFields (unexported) returns the JSON attributes set by want with a different value in got
+func changedSubSchemaFields(got *CustomSubSchema, want *CustomSubSchema) ([]string, error) {
+	gotFields, err := subSchemaFields(got)
+	if err != nil {
+		return nil, err
+	}
+	wantFields, err := desiredSubSchemaFields(want)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	for key, value := range wantFields {
+		if !reflect.DeepEqual(value, gotFields[key]) {
+			fields = append(fields, key)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// subSchemaFields (unexported) returns the JSON attributes of sub
+func subSchemaFields(sub *CustomSubSchema) (map[string]interface{}, error) {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] custom subschema %v: %v", sub.Index, err)
+	}
+	var fields map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// desiredSubSchemaFields (unexported) returns the JSON attributes set by want. The attributes of a manifest
+// with a zero value are nil, like the attributes a live SubSchema leaves out
+func desiredSubSchemaFields(want *CustomSubSchema) (map[string]interface{}, error) {
+	fields, err := subSchemaFields(want)
+	if err != nil {
+		return nil, err
+	}
+	if want.manifestKeys == nil {
+		for key, value := range fields {
+			if value == nil || value == "" {
+				delete(fields, key)
+			}
+		}
+		return fields, nil
+	}
+	set := make(map[string]interface{}, len(want.manifestKeys))
+	for _, key := range want.manifestKeys {
+		set[key] = fields[key]
+	}
+	return set, nil
+}
+
+// destructiveReasons (unexported) returns why updating got to want may lose or invalidate existing values
+func destructiveReasons(got *CustomSubSchema, want *CustomSubSchema) []string {
+	var reasons []string
+	if want.Type != "" && want.Type != got.Type {
+		reasons = append(reasons, fmt.Sprintf("type changes from %v to %v, the attribute is removed and added again", got.Type, want.Type))
+	}
+	if want.Items.Type != "" && want.Items.Type != got.Items.Type {
+		reasons = append(reasons, fmt.Sprintf("item type changes from %v to %v, the attribute is removed and added again", got.Items.Type, want.Items.Type))
+	}
+	if removed := removedValues(got.Enum, got.OneOf, want.Enum, want.OneOf); len(removed) > 0 {
+		reasons = append(reasons, fmt.Sprintf("allowed values %v are removed", strings.Join(removed, ", ")))
+	}
+	if removed := removedValues(got.Items.Enum, got.Items.OneOf, want.Items.Enum, want.Items.OneOf); len(removed) > 0 {
+		reasons = append(reasons, fmt.Sprintf("allowed item values %v are removed", strings.Join(removed, ", ")))
+	}
+	if want.Required && !got.Required {
+		reasons = append(reasons, "the attribute becomes required")
+	}
+	if want.MinLength > got.MinLength {
+		reasons = append(reasons, fmt.Sprintf("minLength increases from %v to %v", got.MinLength, want.MinLength))
+	}
+	if want.MaxLength > 0 && (got.MaxLength == 0 || want.MaxLength < got.MaxLength) {
+		reasons = append(reasons, fmt.Sprintf("maxLength decreases to %v", want.MaxLength))
+	}
+	if want.Unique != "" && want.Unique != "NOT_UNIQUE" && (got.Unique == "" || got.Unique == "NOT_UNIQUE") {
+		reasons = append(reasons, "the attribute becomes unique")
+	}
+	return reasons
+}
+
+// removedValues (unexported) returns the enum and oneOf values of got missing from want, nil when want sets none
+func removedValues(gotEnum SchemaEnum, gotOneOf []OneOf, wantEnum SchemaEnum, wantOneOf []OneOf) []string {
+	if wantEnum == nil && wantOneOf == nil {
+		return nil
+	}
+	wanted := make(map[string]bool)
+	for _, v := range wantEnum {
+		wanted[v] = true
+	}
+	for _, o := range wantOneOf {
+		wanted[o.Const] = true
+	}
+	var removed []string
+	seen := make(map[string]bool)
+	for _, v := range gotEnum {
+		if !wanted[v] && !seen[v] {
+			removed = append(removed, v)
+			seen[v] = true
+		}
+	}
+	for _, o := range gotOneOf {
+		if !wanted[o.Const] && !seen[o.Const] {
+			removed = append(removed, o.Const)
+			seen[o.Const] = true
+		}
+	}
+	return removed
+}
+
+// Empty reports if the schema already has the desired custom attributes
+func (p *SchemaPlan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Destructive reports if the plan has changes that may lose or invalidate existing profile values
+func (p *SchemaPlan) Destructive() bool {
+	for _, change := range p.Changes {
+		if change.Destructive {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the plan in a human readable form, one line per change with the destructive changes flagged
+func (p *SchemaPlan) String() string {
+	counts := make(map[string]int)
+	for _, change := range p.Changes {
+		counts[change.Action]++
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Schema plan: %v to add, %v to change, %v to remove\n", counts[SchemaChangeAdd], counts[SchemaChangeUpdate], counts[SchemaChangeRemove])
+	for _, change := range p.Changes {
+		switch change.Action {
+		case SchemaChangeAdd:
+			fmt.Fprintf(&b, "  + %v (%v)", change.Index, change.Desired.Type)
+		case SchemaChangeUpdate:
+			fmt.Fprintf(&b, "  ~ %v: %v", change.Index, strings.Join(change.Fields, ", "))
+		case SchemaChangeRemove:
+			fmt.Fprintf(&b, "  - %v", change.Index)
+		}
+		if change.Destructive {
+			fmt.Fprintf(&b, " [DESTRUCTIVE: %v]", strings.Join(change.Reasons, "; "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// PlanUserSchema returns the changes of the custom attributes of the User Profile Schema to desired, see
+// PlanCustomSchema
+func (s *SchemasService) PlanUserSchema(desired []CustomSubSchema, prune bool) (*SchemaPlan, *Response, error) {
+	return s.PlanUserSchemaForType("", desired, prune)
+}
+
+// PlanUserSchemaForType returns the changes of the custom attributes of the User Profile Schema of a user type
+// to desired, see PlanCustomSchema. An empty schemaID plans the schema of the default user type
+func (s *SchemasService) PlanUserSchemaForType(schemaID string, desired []CustomSubSchema, prune bool) (*SchemaPlan, *Response, error) {
+	current, resp, err := s.GetUserSchemaForType(schemaID)
+	if err != nil {
+		return nil, resp, err
+	}
+	plan, err := PlanCustomSchema(current, desired, prune)
+	return plan, resp, err
+}
+
+// ApplyUserSchemaPlan applies plan to the User Profile Schema with UpdateUserCustomSubSchema and
+// DeleteUserCustomSubSchema, and returns the updated Schema. A changed attribute is sent with its live JSON
+// attributes and the ones set by the desired attribute, a change of its type removes it and adds it again. It returns a nil Schema for a dry run or an empty plan, and an error without changing
+// the schema when the plan is destructive and opt.AllowDestructive is false
+func (s *SchemasService) ApplyUserSchemaPlan(plan *SchemaPlan, opt SchemaApplyOptions) (*Schema, error) {
+	return s.ApplyUserSchemaPlanForType("", plan, opt)
+}
+
+// ApplyUserSchemaPlanForType applies plan to the User Profile Schema of a user type, see ApplyUserSchemaPlan.
+// An empty schemaID applies it to the schema of the default user type
+func (s *SchemasService) ApplyUserSchemaPlanForType(schemaID string, plan *SchemaPlan, opt SchemaApplyOptions) (*Schema, error) {
+	if plan.Destructive() && !opt.AllowDestructive {
+		var indexes []string
+		for _, change := range plan.Changes {
+			if change.Destructive {
+				indexes = append(indexes, change.Index)
+			}
+		}
+		return nil, fmt.Errorf("[ERROR] schema plan has destructive changes of %v", strings.Join(indexes, ", "))
+	}
+	if opt.DryRun {
+		return nil, nil
+	}
+
+	var schema *Schema
+	var err error
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case SchemaChangeRemove:
+			schema, _, err = s.DeleteUserCustomSubSchemaForType(schemaID, change.Index)
+		case SchemaChangeAdd:
+			schema, _, err = s.UpdateUserCustomSubSchemaForType(schemaID, *change.Desired)
+		case SchemaChangeUpdate:
+			if (change.Desired.Type != "" && change.Desired.Type != change.Current.Type) ||
+				(change.Desired.Items.Type != "" && change.Desired.Items.Type != change.Current.Items.Type) {
+				if _, _, err = s.DeleteUserCustomSubSchemaForType(schemaID, change.Index); err == nil {
+					schema, _, err = s.UpdateUserCustomSubSchemaForType(schemaID, *change.Desired)
+				}
+				break
+			}
+			var update *CustomSubSchema
+			if update, err = mergeSubSchema(change.Current, change.Desired); err == nil {
+				schema, _, err = s.UpdateUserCustomSubSchemaForType(schemaID, *update)
+			}
+		}
+		if err != nil {
+			return schema, fmt.Errorf("[ERROR] applying %v of %v: %v", change.Action, change.Index, err)
+		}
+	}
+	return schema, nil
+}
+
+// mergeSubSchema (unexported) returns current with the JSON attributes set by desired, the ones set to a zero
+// value by a manifest are cleared
+func mergeSubSchema(current *CustomSubSchema, desired *CustomSubSchema) (*CustomSubSchema, error) {
+	fields, err := subSchemaFields(current)
+	if err != nil {
+		return nil, err
+	}
+	wantFields, err := desiredSubSchemaFields(desired)
+	if err != nil {
+		return nil, err
+	}
+	// the allowed values are replaced together, the enum and oneOf of an attribute must match
+	_, enum := wantFields["enum"]
+	_, oneOf := wantFields["oneOf"]
+	if enum || oneOf {
+		delete(fields, "enum")
+		delete(fields, "oneOf")
+	}
+	for key, value := range wantFields {
+		if value == nil {
+			delete(fields, key)
+			continue
+		}
+		fields[key] = value
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	merged := new(CustomSubSchema)
+	if err := json.Unmarshal(data, merged); err != nil {
+		return nil, fmt.Errorf("[ERROR] custom subschema %v: %v", desired.Index, err)
+	}
+	merged.Index = desired.Index
+	return merged, nil
+}
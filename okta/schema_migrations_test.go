@@ -0,0 +1,185 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const testSchemaManifest = `{
+	"properties": {
+		"clearance": {"title": "Clearance", "type": "integer", "enum": [0, 1, 2]},
+		"costCenter": {"title": "Cost center", "type": "string", "maxLength": 20, "permissions": [{"principal": "SELF", "action": "READ_ONLY"}]},
+		"shirtSizes": {"title": "Shirt size"}
+	}
+}`
+
+func TestPlanCustomSchema(t *testing.T) {
+	current := new(Schema)
+	if err := json.Unmarshal([]byte(schemaLosslessTestJSONString), current); err != nil {
+		t.Fatalf("failed to unmarshal the schema, error %v", err)
+	}
+	desired, err := ParseSchemaManifest([]byte(testSchemaManifest))
+	if err != nil {
+		t.Fatalf("ParseSchemaManifest returned error: %v", err)
+	}
+
+	plan, err := PlanCustomSchema(current, desired, false)
+	if err != nil {
+		t.Fatalf("PlanCustomSchema returned error: %v", err)
+	}
+	if len(plan.Changes) != 3 || plan.Changes[0].Action != SchemaChangeAdd {
+		t.Errorf("PlanCustomSchema without prune returned %v", plan)
+	}
+
+	plan, err = PlanCustomSchema(current, desired, true)
+	if err != nil {
+		t.Fatalf("PlanCustomSchema returned error: %v", err)
+	}
+	want := `Schema plan: 1 to add, 2 to change, 1 to remove
+  - employeeNumber [DESTRUCTIVE: removes the attribute and its values from every profile]
+  + costCenter (string)
+  ~ clearance: enum [DESTRUCTIVE: allowed values 3 are removed]
+  ~ shirtSizes: title
+`
+	if plan.String() != want {
+		t.Errorf("SchemaPlan.String returned \n%v, want \n%v", plan, want)
+	}
+	if !plan.Destructive() || plan.Empty() {
+		t.Errorf("SchemaPlan.Destructive returned %v", plan.Destructive())
+	}
+
+	same, err := PlanCustomSchema(current, current.Definitions.Custom.Properties, true)
+	if err != nil || !same.Empty() {
+		t.Errorf("PlanCustomSchema of the live attributes returned %v, %v", same, err)
+	}
+
+	if _, err := PlanCustomSchema(current, append(desired, desired[0]), false); err == nil {
+		t.Errorf("PlanCustomSchema of a duplicate attribute returned no error")
+	}
+	if _, err := ParseSchemaManifest([]byte(`{"costCenter": {"type": "string"}}`)); err == nil {
+		t.Errorf("ParseSchemaManifest without properties returned no error")
+	}
+	fromSchema, err := ParseSchemaManifest([]byte(schemaLosslessTestJSONString))
+	if err != nil || len(fromSchema) != 3 {
+		t.Errorf("ParseSchemaManifest of a schema returned %+v, %v", fromSchema, err)
+	}
+}
+
+func TestApplyUserSchemaPlanForType(t *testing.T) {
+
+	setup()
+	defer teardown()
+
+	var posted []string
+	mux.HandleFunc("/meta/schemas/user/osc1g6ttimJeW8Zxg0g4", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			var update struct {
+				Definitions struct {
+					Custom struct {
+						Properties map[string]json.RawMessage `json:"properties"`
+					} `json:"custom"`
+				} `json:"definitions"`
+			}
+			json.Unmarshal(body, &update)
+			for index, property := range update.Definitions.Custom.Properties {
+				posted = append(posted, fmt.Sprintf("%v=%s", index, property))
+			}
+		} else {
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, schemaLosslessTestJSONString)
+	})
+
+	desired, _ := ParseSchemaManifest([]byte(testSchemaManifest))
+	plan, _, err := client.Schemas.PlanUserSchemaForType("osc1g6ttimJeW8Zxg0g4", desired, true)
+	if err != nil {
+		t.Fatalf("Schemas.PlanUserSchemaForType returned error: %v", err)
+	}
+
+	if _, err := client.Schemas.ApplyUserSchemaPlanForType("osc1g6ttimJeW8Zxg0g4", plan, SchemaApplyOptions{}); err == nil {
+		t.Errorf("Schemas.ApplyUserSchemaPlanForType of a destructive plan returned no error")
+	}
+	if schema, err := client.Schemas.ApplyUserSchemaPlanForType("osc1g6ttimJeW8Zxg0g4", plan, SchemaApplyOptions{DryRun: true, AllowDestructive: true}); schema != nil || err != nil {
+		t.Errorf("Schemas.ApplyUserSchemaPlanForType dry run returned %v, %v", schema, err)
+	}
+	if len(posted) != 0 {
+		t.Fatalf("Schemas.ApplyUserSchemaPlanForType changed the schema without AllowDestructive or with DryRun: %v", posted)
+	}
+
+	schema, err := client.Schemas.ApplyUserSchemaPlanForType("osc1g6ttimJeW8Zxg0g4", plan, SchemaApplyOptions{AllowDestructive: true})
+	if err != nil {
+		t.Fatalf("Schemas.ApplyUserSchemaPlanForType returned error: %v", err)
+	}
+	if schema == nil || schema.Name != "contractor" {
+		t.Errorf("Schemas.ApplyUserSchemaPlanForType returned %+v", schema)
+	}
+	want := []string{
+		`employeeNumber=null`,
+		`costCenter={"title":"Cost center","type":"string","maxLength":20,"permissions":[{"principal":"SELF","action":"READ_ONLY"}]}`,
+		`clearance={"title":"Clearance","type":"integer","minimum":0,"maximum":3,"permissions":[{"principal":"SELF","action":"HIDE"}],"enum":[0,1,2]}`,
+		`shirtSizes={"title":"Shirt size","type":"array","permissions":[{"principal":"SELF","action":"READ_WRITE"}],"items":{"type":"string","enum":["S","M","L"],"oneOf":[{"const":"S","title":"Small"},{"const":"M","title":"Medium"},{"const":"L","title":"Large"}]}}`,
+	}
+	if !reflect.DeepEqual(posted, want) {
+		t.Errorf("Schemas.ApplyUserSchemaPlanForType posted \n\t%v, want \n\t%v\n", posted, want)
+	}
+
+	posted = nil
+	plan, _, err = client.Schemas.PlanUserSchemaForType("osc1g6ttimJeW8Zxg0g4", []CustomSubSchema{{Index: "clearance", Title: "Clearance", Type: "string", Permissions: []Permissions{{Principal: "SELF", Action: "HIDE"}}}}, false)
+	if err != nil || !plan.Destructive() {
+		t.Fatalf("Schemas.PlanUserSchemaForType of a type change returned %v, %v", plan, err)
+	}
+	if _, err := client.Schemas.ApplyUserSchemaPlanForType("osc1g6ttimJeW8Zxg0g4", plan, SchemaApplyOptions{AllowDestructive: true}); err != nil {
+		t.Fatalf("Schemas.ApplyUserSchemaPlanForType returned error: %v", err)
+	}
+	want = []string{`clearance=null`, `clearance={"title":"Clearance","type":"string","permissions":[{"principal":"SELF","action":"HIDE"}]}`}
+	if !reflect.DeepEqual(posted, want) {
+		t.Errorf("Schemas.ApplyUserSchemaPlanForType of a type change posted \n\t%v, want \n\t%v\n", posted, want)
+	}
+}
+
+func TestPlanCustomSchemaZeroValues(t *testing.T) {
+	current := new(Schema)
+	if err := json.Unmarshal([]byte(`{"definitions": {"custom": {"id": "#custom", "type": "object", "properties": {
+		"badgeId": {"title": "Badge", "type": "string", "description": "Badge number", "required": true, "minLength": 4, "maxLength": 10, "permissions": [{"principal": "SELF", "action": "READ_ONLY"}]}
+	}}}}`), current); err != nil {
+		t.Fatalf("failed to unmarshal the schema, error %v", err)
+	}
+
+	desired, err := ParseSchemaManifest([]byte(`{"properties": {"badgeId": {"required": false, "minLength": 0, "description": ""}}}`))
+	if err != nil {
+		t.Fatalf("ParseSchemaManifest returned error: %v", err)
+	}
+	plan, err := PlanCustomSchema(current, desired, false)
+	if err != nil {
+		t.Fatalf("PlanCustomSchema returned error: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Destructive() {
+		t.Fatalf("PlanCustomSchema of zero values returned %v", plan)
+	}
+	if want := []string{"description", "minLength", "required"}; !reflect.DeepEqual(plan.Changes[0].Fields, want) {
+		t.Errorf("PlanCustomSchema of zero values changed %v, want %v", plan.Changes[0].Fields, want)
+	}
+
+	merged, err := mergeSubSchema(plan.Changes[0].Current, plan.Changes[0].Desired)
+	if err != nil {
+		t.Fatalf("mergeSubSchema returned error: %v", err)
+	}
+	data, _ := json.Marshal(merged)
+	if want := `{"title":"Badge","type":"string","maxLength":10,"permissions":[{"principal":"SELF","action":"READ_ONLY"}]}`; string(data) != want {
+		t.Errorf("mergeSubSchema of zero values returned \n\t%s, want \n\t%v\n", data, want)
+	}
+
+	same, err := ParseSchemaManifest([]byte(`{"properties": {"badgeId": {"title": "Badge", "required": true, "minLength": 4, "pattern": "", "nullable": null}}}`))
+	if err != nil {
+		t.Fatalf("ParseSchemaManifest returned error: %v", err)
+	}
+	if plan, err := PlanCustomSchema(current, same, false); err != nil || !plan.Empty() {
+		t.Errorf("PlanCustomSchema of the live values returned %v, %v", plan, err)
+	}
+}
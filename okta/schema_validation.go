@@ -0,0 +1,341 @@
+package okta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// ProfileViolationRequired - a required attribute is missing or empty
+	ProfileViolationRequired = "REQUIRED"
+	// ProfileViolationUnknown - the attribute is not defined in the schema
+	ProfileViolationUnknown = "UNKNOWN"
+	// ProfileViolationType - the value does not have the type of the attribute
+	ProfileViolationType = "TYPE"
+	// ProfileViolationNull - the value is null and the attribute is not nullable
+	ProfileViolationNull = "NULL"
+	// ProfileViolationFormat - the value does not have the format of the attribute, like email or locale
+	ProfileViolationFormat = "FORMAT"
+	// ProfileViolationLength - the value is shorter than minLength or longer than maxLength
+	ProfileViolationLength = "LENGTH"
+	// ProfileViolationRange - the value is lower than minimum or greater than maximum
+	ProfileViolationRange = "RANGE"
+	// ProfileViolationPattern - the value does not match the pattern of the attribute
+	ProfileViolationPattern = "PATTERN"
+	// ProfileViolationEnum - the value is not one of the enum or oneOf values of the attribute
+	ProfileViolationEnum = "ENUM"
+	// ProfileViolationReadOnly - the attribute is read only and can not be set
+	ProfileViolationReadOnly = "READ_ONLY"
+)
+
+var (
+	countryCodePattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+	localePattern       = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?$`)
+)
+
+// ProfileViolation is an attribute of a profile that OKTA would reject
+type ProfileViolation struct {
+	Attribute string
+	Reason    string
+	Message   string
+}
+
+// ProfileValidationError lists the violations found by Schema.ValidateProfile
+type ProfileValidationError struct {
+	Violations []ProfileViolation
+}
+
+func (e *ProfileValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = fmt.Sprintf("%v: %v", v.Attribute, v.Message)
+	}
+	return fmt.Sprintf("[ERROR] invalid profile: %v", strings.Join(messages, "; "))
+}
+
+// profileAttribute (unexported) are the constraints of a base or custom attribute of a Schema
+type profileAttribute struct {
+	index      string
+	schemaType string
+	format     string
+	required   bool
+	mutability string
+	nullable   *bool
+	minLength  int
+	maxLength  int
+	minimum    *float64
+	maximum    *float64
+	pattern    string
+	items      SubSchemaItems
+	enum       SchemaEnum
+	oneOf      []OneOf
+}
+
+// ValidateProfile checks a profile to create a user with against the Schema, from GetUserSchema, before it is sent
+// to OKTA. profile is a NewUser, a typed profile struct like the ones generated by okta-schemagen or a
+// map[string]interface{}. It returns a *ProfileValidationError listing every violation, or nil
+func (s *Schema) ValidateProfile(profile interface{}) error {
+	return s.validateProfile(profile, false)
+}
+
+// ValidateProfileUpdate checks a profile to partially update a user with, like ValidateProfile. Required
+// attributes may be left out
+func (s *Schema) ValidateProfileUpdate(profile interface{}) error {
+	return s.validateProfile(profile, true)
+}
+
+// validateProfile (unexported) checks profile against the Schema, the required attributes only when partial is false
+func (s *Schema) validateProfile(profile interface{}, partial bool) error {
+	values, err := profileValues(profile)
+	if err != nil {
+		return err
+	}
+
+	var violations []ProfileViolation
+	known := make(map[string]bool)
+	for _, a := range s.profileAttributes() {
+		known[a.index] = true
+		value, ok := values[a.index]
+		violations = append(violations, a.validate(value, ok, partial)...)
+	}
+
+	var unknown []string
+	for index, value := range values {
+		if !known[index] && value != nil && value != "" {
+			unknown = append(unknown, index)
+		}
+	}
+	sort.Strings(unknown)
+	for _, index := range unknown {
+		violations = append(violations, ProfileViolation{index, ProfileViolationUnknown, "is not defined in the profile schema"})
+	}
+
+	if len(violations) > 0 {
+		return &ProfileValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// profileValues (unexported) returns the attributes of profile, with numbers as json.Number
+func profileValues(profile interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(profile)
+	if err != nil {
+		return nil, err
+	}
+	switch profile.(type) {
+	case NewUser, *NewUser:
+		var user struct {
+			Profile json.RawMessage `json:"profile"`
+		}
+		if err := json.Unmarshal(data, &user); err != nil {
+			return nil, err
+		}
+		data = user.Profile
+	}
+
+	var values map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&values); err != nil {
+		return nil, fmt.Errorf("[ERROR] profile is not a JSON object: %v", err)
+	}
+	return values, nil
+}
+
+// profileAttributes (unexported) returns the base attributes of the Schema followed by the custom ones
+func (s *Schema) profileAttributes() []profileAttribute {
+	required := make(map[string]bool)
+	for _, index := range s.Definitions.Base.Required {
+		required[index] = true
+	}
+	for _, index := range s.Definitions.Custom.Required {
+		required[index] = true
+	}
+
+	var attributes []profileAttribute
+	for _, sub := range s.Definitions.Base.Properties {
+		attributes = append(attributes, profileAttribute{
+			index:      sub.Index,
+			schemaType: sub.Type,
+			format:     sub.Format,
+			required:   sub.Required || required[sub.Index],
+			mutability: sub.Mutability,
+			nullable:   sub.Nullable,
+			minLength:  sub.MinLength,
+			maxLength:  sub.MaxLength,
+			pattern:    sub.Pattern,
+		})
+	}
+	for _, sub := range s.Definitions.Custom.Properties {
+		attributes = append(attributes, profileAttribute{
+			index:      sub.Index,
+			schemaType: sub.Type,
+			format:     sub.Format,
+			required:   sub.Required || required[sub.Index],
+			mutability: sub.Mutability,
+			nullable:   sub.Nullable,
+			minLength:  sub.MinLength,
+			maxLength:  sub.MaxLength,
+			minimum:    sub.Minimum,
+			maximum:    sub.Maximum,
+			pattern:    sub.Pattern,
+			items:      sub.Items,
+			enum:       sub.Enum,
+			oneOf:      sub.OneOf,
+		})
+	}
+	return attributes
+}
+
+// validate (unexported) returns the violations of the value of the attribute, ok is false when the profile has none
+func (a profileAttribute) validate(value interface{}, ok bool, partial bool) []ProfileViolation {
+	empty := !ok || value == nil || value == ""
+	if empty {
+		switch {
+		case a.required && (ok || !partial):
+			return []ProfileViolation{a.violation(ProfileViolationRequired, "is required")}
+		case ok && value == nil && a.nullable != nil && !*a.nullable:
+			return []ProfileViolation{a.violation(ProfileViolationNull, "can not be null")}
+		}
+		return nil
+	}
+	if a.mutability == "READ_ONLY" {
+		return []ProfileViolation{a.violation(ProfileViolationReadOnly, "is read only")}
+	}
+
+	if a.schemaType != "array" {
+		return a.validateValue(value, a.schemaType, a.enum, a.oneOf)
+	}
+	items, isArray := value.([]interface{})
+	if !isArray {
+		return []ProfileViolation{a.violation(ProfileViolationType, "must be an array")}
+	}
+	var violations []ProfileViolation
+	for _, item := range items {
+		violations = append(violations, a.validateValue(item, a.items.Type, a.items.Enum, a.items.OneOf)...)
+	}
+	return violations
+}
+
+// validateValue (unexported) returns the violations of a value, or an item of an array, of type schemaType
+func (a profileAttribute) validateValue(value interface{}, schemaType string, enum SchemaEnum, oneOf []OneOf) []ProfileViolation {
+	var violations []ProfileViolation
+	switch schemaType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return []ProfileViolation{a.violation(ProfileViolationType, "must be a string")}
+		}
+		violations = append(violations, a.validateString(s)...)
+	case "integer", "number":
+		message := "must be a number"
+		if schemaType == "integer" {
+			message = "must be an integer"
+		}
+		n, ok := value.(json.Number)
+		if !ok {
+			return []ProfileViolation{a.violation(ProfileViolationType, message)}
+		}
+		f, err := n.Float64()
+		if err != nil || (schemaType == "integer" && f != math.Trunc(f)) {
+			return []ProfileViolation{a.violation(ProfileViolationType, message)}
+		}
+		if (a.minimum != nil && f < *a.minimum) || (a.maximum != nil && f > *a.maximum) {
+			violations = append(violations, a.violation(ProfileViolationRange, fmt.Sprintf("%v is out of range", n)))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []ProfileViolation{a.violation(ProfileViolationType, "must be a boolean")}
+		}
+	case "object":
+		if _, ok := value.(map[string]interface{}); !ok {
+			return []ProfileViolation{a.violation(ProfileViolationType, "must be an object")}
+		}
+	}
+
+	if len(enum) > 0 || len(oneOf) > 0 {
+		allowed := append([]string(nil), enum...)
+		for _, o := range oneOf {
+			allowed = append(allowed, o.Const)
+		}
+		if !enumContains(allowed, value) {
+			violations = append(violations, a.violation(ProfileViolationEnum, fmt.Sprintf("%v is not one of %v", value, strings.Join(allowed, ", "))))
+		}
+	}
+	return violations
+}
+
+// validateString (unexported) returns the length, pattern and format violations of a string value
+func (a profileAttribute) validateString(s string) []ProfileViolation {
+	var violations []ProfileViolation
+	switch length := utf8.RuneCountInString(s); {
+	case length < a.minLength:
+		violations = append(violations, a.violation(ProfileViolationLength, fmt.Sprintf("is shorter than %v characters", a.minLength)))
+	case a.maxLength > 0 && length > a.maxLength:
+		violations = append(violations, a.violation(ProfileViolationLength, fmt.Sprintf("is longer than %v characters", a.maxLength)))
+	}
+	if a.pattern != "" {
+		// an invalid pattern is left to OKTA
+		if re, err := regexp.Compile("^(?:" + a.pattern + ")$"); err == nil && !re.MatchString(s) {
+			violations = append(violations, a.violation(ProfileViolationPattern, fmt.Sprintf("does not match %v", a.pattern)))
+		}
+	}
+
+	valid := true
+	switch a.format {
+	case "email":
+		address, err := mail.ParseAddress(s)
+		valid = err == nil && address.Address == s
+	case "uri":
+		u, err := url.Parse(s)
+		valid = err == nil && u.Scheme != "" && (u.Host != "" || u.Opaque != "")
+	case "country-code":
+		valid = countryCodePattern.MatchString(s)
+	case "language-code":
+		valid = languageCodePattern.MatchString(s)
+	case "locale":
+		valid = localePattern.MatchString(s)
+	}
+	if !valid {
+		violations = append(violations, a.violation(ProfileViolationFormat, fmt.Sprintf("%q is not a valid %v", s, a.format)))
+	}
+	return violations
+}
+
+// violation (unexported) returns a violation of the attribute
+func (a profileAttribute) violation(reason string, message string) ProfileViolation {
+	return ProfileViolation{Attribute: a.index, Reason: reason, Message: message}
+}
+
+// enumContains (unexported) reports if value is one of the allowed values, comparing numbers by value
+func enumContains(allowed []string, value interface{}) bool {
+	for _, v := range allowed {
+		switch value := value.(type) {
+		case string:
+			if v == value {
+				return true
+			}
+		case json.Number:
+			f, err := value.Float64()
+			g, errAllowed := strconv.ParseFloat(v, 64)
+			if err == nil && errAllowed == nil && f == g {
+				return true
+			}
+		case bool:
+			if v == strconv.FormatBool(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package okta
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var validationSchemaJSONString = `
+{
+    "definitions": {
+        "base": {
+            "id": "#base",
+            "type": "object",
+            "properties": {
+                "login": {"title": "Username", "type": "string", "required": true, "minLength": 5, "maxLength": 100, "pattern": ".+@.+", "permissions": []},
+                "email": {"title": "Primary email", "type": "string", "required": true, "format": "email", "permissions": []},
+                "firstName": {"title": "First name", "type": "string", "required": true, "permissions": []},
+                "lastName": {"title": "Last name", "type": "string", "required": true, "permissions": []},
+                "profileUrl": {"title": "Profile Url", "type": "string", "format": "uri", "permissions": []},
+                "countryCode": {"title": "Country code", "type": "string", "format": "country-code", "permissions": []},
+                "locale": {"title": "Locale", "type": "string", "format": "locale", "permissions": []}
+            },
+            "required": ["login", "email", "firstName", "lastName"]
+        },
+        "custom": {
+            "id": "#custom",
+            "type": "object",
+            "properties": {
+                "clearance": {"title": "Clearance", "type": "integer", "minimum": 0, "maximum": 3, "permissions": []},
+                "shirtSizes": {"title": "Shirt sizes", "type": "array", "items": {"type": "string", "oneOf": [{"const": "S", "title": "Small"}, {"const": "M", "title": "Medium"}]}, "permissions": []},
+                "level": {"title": "Level", "type": "number", "enum": [1, 2.5], "permissions": []},
+                "badgeId": {"title": "Badge", "type": "string", "mutability": "READ_ONLY", "permissions": []},
+                "contractor": {"title": "Contractor", "type": "boolean", "nullable": false, "permissions": []}
+            },
+            "required": []
+        }
+    }
+}
+`
+
+func testValidationSchema(t *testing.T) *Schema {
+	schema := new(Schema)
+	if err := json.Unmarshal([]byte(validationSchemaJSONString), schema); err != nil {
+		t.Fatalf("failed to unmarshal the schema, error %v", err)
+	}
+	return schema
+}
+
+func TestValidateProfile(t *testing.T) {
+	schema := testValidationSchema(t)
+
+	var newUser NewUser
+	newUser.Profile.Login = "isaac.brock@example.com"
+	newUser.Profile.Email = "isaac.brock@example.com"
+	newUser.Profile.FirstName = "Isaac"
+	newUser.Profile.LastName = "Brock"
+	newUser.Profile.Locale = "en_US"
+	if err := schema.ValidateProfile(newUser); err != nil {
+		t.Errorf("Schema.ValidateProfile of a valid NewUser returned error: %v", err)
+	}
+
+	newUser.SetProfile(map[string]interface{}{
+		"login":      "isaac.brock@example.com",
+		"email":      "isaac.brock@example.com",
+		"firstName":  "Isaac",
+		"lastName":   "Brock",
+		"clearance":  2,
+		"level":      2.50,
+		"shirtSizes": []string{"S", "M"},
+		"contractor": false,
+	})
+	if err := schema.ValidateProfile(&newUser); err != nil {
+		t.Errorf("Schema.ValidateProfile of a valid custom profile returned error: %v", err)
+	}
+}
+
+func TestValidateProfileViolations(t *testing.T) {
+	schema := testValidationSchema(t)
+
+	err := schema.ValidateProfile(map[string]interface{}{
+		"login":       "ib",
+		"email":       "Isaac <isaac.brock@example.com>",
+		"lastName":    "",
+		"profileUrl":  "example.com/isaac",
+		"countryCode": "usa",
+		"locale":      "en-US",
+		"clearance":   4,
+		"shirtSizes":  []interface{}{"S", "XL", 1},
+		"level":       "2.5",
+		"badgeId":     "B-1234",
+		"contractor":  nil,
+		"nickName":    "Ike",
+	})
+	verr, ok := err.(*ProfileValidationError)
+	if !ok {
+		t.Fatalf("Schema.ValidateProfile returned %v, want a *ProfileValidationError", err)
+	}
+	var got [][2]string
+	for _, v := range verr.Violations {
+		got = append(got, [2]string{v.Attribute, v.Reason})
+	}
+	want := [][2]string{
+		{"countryCode", ProfileViolationFormat},
+		{"email", ProfileViolationFormat},
+		{"firstName", ProfileViolationRequired},
+		{"lastName", ProfileViolationRequired},
+		{"locale", ProfileViolationFormat},
+		{"login", ProfileViolationLength},
+		{"login", ProfileViolationPattern},
+		{"profileUrl", ProfileViolationFormat},
+		{"badgeId", ProfileViolationReadOnly},
+		{"clearance", ProfileViolationRange},
+		{"contractor", ProfileViolationNull},
+		{"level", ProfileViolationType},
+		{"shirtSizes", ProfileViolationEnum},
+		{"shirtSizes", ProfileViolationType},
+		{"nickName", ProfileViolationUnknown},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Schema.ValidateProfile returned \n\t%v, want \n\t%v\n", got, want)
+	}
+}
+
+func TestValidateProfileUpdate(t *testing.T) {
+	schema := testValidationSchema(t)
+
+	if err := schema.ValidateProfileUpdate(map[string]interface{}{"clearance": 1}); err != nil {
+		t.Errorf("Schema.ValidateProfileUpdate of a partial profile returned error: %v", err)
+	}
+	err := schema.ValidateProfileUpdate(map[string]interface{}{"firstName": "", "clearance": 1.5})
+	if err == nil || err.Error() != "[ERROR] invalid profile: firstName: is required; clearance: must be an integer" {
+		t.Errorf("Schema.ValidateProfileUpdate returned %v", err)
+	}
+	if err := schema.ValidateProfileUpdate([]string{"login"}); err == nil {
+		t.Errorf("Schema.ValidateProfileUpdate of a non object profile returned no error")
+	}
+}
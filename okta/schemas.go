@@ -1,8 +1,13 @@
 package okta
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -30,70 +35,129 @@ func (p *SchemasService) OneOf() OneOf {
 	return OneOf{}
 }
 
-// User Profiles Schema obj
+// Profile Schema obj of users, groups and app users
+// Attributes the SDK does not model are kept in Extra and sent back when the Schema is marshalled
 type Schema struct {
-	ID          string    `json:"id"`
-	Schema      string    `json:"$schema"`
-	Name        string    `json:"name"`
-	Title       string    `json:"title"`
-	Created     time.Time `json:"created"`
-	LastUpdated time.Time `json:"lastUpdated"`
-	Definitions struct {
-		Base struct {
-			ID         string          `json:"id"`
-			Type       string          `json:"type"`
-			Properties []BaseSubSchema `json:"properties"`
-			Required   []string        `json:"required"`
-		}
-		Custom struct {
-			ID         string            `json:"id"`
-			Type       string            `json:"type"`
-			Properties []CustomSubSchema `json:"properties"`
-			Required   []string          `json:"required"`
-		} `json:"custom"`
-	} `json:"definitions"`
-	Type string `json:"type"`
+	ID          string            `json:"id,omitempty"`
+	Schema      string            `json:"$schema,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Created     time.Time         `json:"created"`
+	LastUpdated time.Time         `json:"lastUpdated"`
+	Definitions SchemaDefinitions `json:"definitions"`
+	Type        string            `json:"type,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// SchemaDefinitions are the base and custom attributes of a Schema
+type SchemaDefinitions struct {
+	Base   BaseSchemaDefinition   `json:"base"`
+	Custom CustomSchemaDefinition `json:"custom"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// BaseSchemaDefinition holds the base attributes of a Schema, Properties sorted by Index
+type BaseSchemaDefinition struct {
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type,omitempty"`
+	Properties []BaseSubSchema `json:"properties"`
+	Required   []string        `json:"required"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CustomSchemaDefinition holds the custom attributes of a Schema, Properties sorted by Index
+type CustomSchemaDefinition struct {
+	ID         string            `json:"id,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Properties []CustomSubSchema `json:"properties"`
+	Required   []string          `json:"required"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // User Profiles Base SubSchema
 type BaseSubSchema struct {
-	Index       string        `json:"-"`
-	Title       string        `json:"title"`
-	Type        string        `json:"type"`
-	Format      string        `json:"format,omitempty"`
-	Required    bool          `json:"required,omitempty"`
-	Mutability  string        `json:"mutablity,omitempty"`
-	Scope       string        `json:"scope,omitempty"`
-	MinLength   int           `json:"minLength,omitempty"`
-	MaxLength   int           `json:"maxLength,omitempty"`
-	Permissions []Permissions `json:"permissions"`
-	Master      *Master       `json:"master,omitempty"`
+	Index             string        `json:"-"`
+	Title             string        `json:"title"`
+	Type              string        `json:"type"`
+	Description       string        `json:"description,omitempty"`
+	Format            string        `json:"format,omitempty"`
+	Required          bool          `json:"required,omitempty"`
+	Mutability        string        `json:"mutability,omitempty"`
+	Scope             string        `json:"scope,omitempty"`
+	MinLength         int           `json:"minLength,omitempty"`
+	MaxLength         int           `json:"maxLength,omitempty"`
+	Pattern           string        `json:"pattern,omitempty"`
+	Unique            string        `json:"unique,omitempty"`
+	Nullable          *bool         `json:"nullable,omitempty"`
+	ExternalName      string        `json:"externalName,omitempty"`
+	ExternalNamespace string        `json:"externalNamespace,omitempty"`
+	Permissions       []Permissions `json:"permissions"`
+	Master            *Master       `json:"master,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // User Profiles Custom SubSchema
 type CustomSubSchema struct {
-	Index       string `json:"-"`
-	Title       string `json:"title"`
-	Type        string `json:"type"`
-	Description string `json:"description,omitempty"`
-	Format      string `json:"format,omitempty"`
-	Required    bool   `json:"required,omitempty"`
-	Mutability  string `json:"mutablity,omitempty"`
-	Scope       string `json:"scope,omitempty"`
-	MinLength   int    `json:"minLength,omitempty"`
-	MaxLength   int    `json:"maxLength,omitempty"`
-	Items       struct {
-		Type string `json:"type,omitempty"`
-	} `json:"items,omitempty"`
-	Union       string        `json:"union,omitempty"`
-	Enum        []string      `json:"enum,omitempty"`
-	OneOf       []OneOf       `json:"oneOf,omitempty"`
-	Permissions []Permissions `json:"permissions"`
-	Master      *Master       `json:"master,omitempty"`
+	Index             string         `json:"-"`
+	Title             string         `json:"title"`
+	Type              string         `json:"type"`
+	Description       string         `json:"description,omitempty"`
+	Format            string         `json:"format,omitempty"`
+	Required          bool           `json:"required,omitempty"`
+	Mutability        string         `json:"mutability,omitempty"`
+	Scope             string         `json:"scope,omitempty"`
+	MinLength         int            `json:"minLength,omitempty"`
+	MaxLength         int            `json:"maxLength,omitempty"`
+	Minimum           *float64       `json:"minimum,omitempty"`
+	Maximum           *float64       `json:"maximum,omitempty"`
+	Pattern           string         `json:"pattern,omitempty"`
+	Unique            string         `json:"unique,omitempty"`
+	Nullable          *bool          `json:"nullable,omitempty"`
+	ExternalName      string         `json:"externalName,omitempty"`
+	ExternalNamespace string         `json:"externalNamespace,omitempty"`
+	Items             SubSchemaItems `json:"items,omitempty"`
+	Union             string         `json:"union,omitempty"`
+	Enum              SchemaEnum     `json:"enum,omitempty"`
+	OneOf             []OneOf        `json:"oneOf,omitempty"`
+	Permissions       []Permissions  `json:"permissions"`
+	Master            *Master        `json:"master,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// manifestKeys are the JSON attributes set by the manifest the SubSchema is parsed from
+	manifestKeys []string
+}
+
+// SubSchemaItems describes the elements of an array Custom SubSchema
+type SubSchemaItems struct {
+	Type  string     `json:"type,omitempty"`
+	Enum  SchemaEnum `json:"enum,omitempty"`
+	OneOf []OneOf    `json:"oneOf,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
+// SchemaEnum are the allowed values of a SubSchema. Number and boolean values are kept in their JSON notation,
+// for example "42", and are sent as numbers for integer, number and boolean SubSchemas
+type SchemaEnum []string
+
+// Master obj for User Profiles SubSchemas, the source of the attribute. Priority orders the sources of
+// an OVERRIDE master
 type Master struct {
-	Type string `json:"type,omitempty"`
+	Type     string           `json:"type,omitempty"`
+	Priority []MasterPriority `json:"priority,omitempty"`
+}
+
+// MasterPriority is a source of an attribute with an OVERRIDE master
+type MasterPriority struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
 }
 
 // Permissions obj for User Profiles SubSchemas
@@ -102,15 +166,434 @@ type Permissions struct {
 	Action    string `json:"action"`
 }
 
-// OneOf obj for User Profiles Custom SubSchema
+// OneOf obj for User Profiles Custom SubSchema. Const is kept in its JSON notation like the values of a SchemaEnum
 type OneOf struct {
 	Const string `json:"const"`
 	Title string `json:"title"`
 }
 
-// GetRawUserSchema returns the User Profile Schema as a map[string]interface{}
+// schemaTimeLayout (unexported) is the layout of the created and lastUpdated times of a Schema
+const schemaTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// UnmarshalJSON decodes a Schema, keeping unknown attributes in Extra
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type schema Schema
+	aux := struct {
+		*schema
+		Created     *time.Time `json:"created"`
+		LastUpdated *time.Time `json:"lastUpdated"`
+	}{schema: (*schema)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.Created != nil {
+		s.Created = *aux.Created
+	}
+	if aux.LastUpdated != nil {
+		s.LastUpdated = *aux.LastUpdated
+	}
+	extra, err := schemaExtra(data, aux.schema)
+	s.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Schema with its Extra attributes, leaving out unset times
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	aux := struct {
+		schema
+		Created     string `json:"created,omitempty"`
+		LastUpdated string `json:"lastUpdated,omitempty"`
+	}{schema: schema(s)}
+	if !s.Created.IsZero() {
+		aux.Created = s.Created.UTC().Format(schemaTimeLayout)
+	}
+	if !s.LastUpdated.IsZero() {
+		aux.LastUpdated = s.LastUpdated.UTC().Format(schemaTimeLayout)
+	}
+	return marshalSchemaExtra(aux, s.Extra)
+}
+
+// UnmarshalJSON decodes SchemaDefinitions, keeping unknown definitions in Extra
+func (d *SchemaDefinitions) UnmarshalJSON(data []byte) error {
+	type schemaDefinitions SchemaDefinitions
+	if err := json.Unmarshal(data, (*schemaDefinitions)(d)); err != nil {
+		return err
+	}
+	extra, err := schemaExtra(data, (*schemaDefinitions)(d))
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes SchemaDefinitions with their Extra definitions
+func (d SchemaDefinitions) MarshalJSON() ([]byte, error) {
+	type schemaDefinitions SchemaDefinitions
+	return marshalSchemaExtra(schemaDefinitions(d), d.Extra)
+}
+
+// UnmarshalJSON decodes the base definition, setting the Index of every property
+func (d *BaseSchemaDefinition) UnmarshalJSON(data []byte) error {
+	type baseSchemaDefinition BaseSchemaDefinition
+	aux := struct {
+		*baseSchemaDefinition
+		Properties map[string]json.RawMessage `json:"properties"`
+	}{baseSchemaDefinition: (*baseSchemaDefinition)(d)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	d.Properties = nil
+	for _, index := range sortedSchemaKeys(aux.Properties) {
+		var sub BaseSubSchema
+		if err := json.Unmarshal(aux.Properties[index], &sub); err != nil {
+			return fmt.Errorf("[ERROR] base subschema %v: %v", index, err)
+		}
+		sub.Index = index
+		d.Properties = append(d.Properties, sub)
+	}
+	extra, err := schemaExtra(data, aux.baseSchemaDefinition)
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the base definition, with the properties keyed by their Index
+func (d BaseSchemaDefinition) MarshalJSON() ([]byte, error) {
+	type baseSchemaDefinition BaseSchemaDefinition
+	properties := make(map[string]BaseSubSchema, len(d.Properties))
+	for _, sub := range d.Properties {
+		properties[sub.Index] = sub
+	}
+	return marshalSchemaExtra(struct {
+		baseSchemaDefinition
+		Properties map[string]BaseSubSchema `json:"properties"`
+	}{baseSchemaDefinition(d), properties}, d.Extra)
+}
+
+// UnmarshalJSON decodes the custom definition, setting the Index of every property
+func (d *CustomSchemaDefinition) UnmarshalJSON(data []byte) error {
+	type customSchemaDefinition CustomSchemaDefinition
+	aux := struct {
+		*customSchemaDefinition
+		Properties map[string]json.RawMessage `json:"properties"`
+	}{customSchemaDefinition: (*customSchemaDefinition)(d)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	d.Properties = nil
+	for _, index := range sortedSchemaKeys(aux.Properties) {
+		var sub CustomSubSchema
+		if err := json.Unmarshal(aux.Properties[index], &sub); err != nil {
+			return fmt.Errorf("[ERROR] custom subschema %v: %v", index, err)
+		}
+		sub.Index = index
+		d.Properties = append(d.Properties, sub)
+	}
+	extra, err := schemaExtra(data, aux.customSchemaDefinition)
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the custom definition, with the properties keyed by their Index
+func (d CustomSchemaDefinition) MarshalJSON() ([]byte, error) {
+	type customSchemaDefinition CustomSchemaDefinition
+	properties := make(map[string]CustomSubSchema, len(d.Properties))
+	for _, sub := range d.Properties {
+		properties[sub.Index] = sub
+	}
+	return marshalSchemaExtra(struct {
+		customSchemaDefinition
+		Properties map[string]CustomSubSchema `json:"properties"`
+	}{customSchemaDefinition(d), properties}, d.Extra)
+}
+
+// UnmarshalJSON decodes a Base SubSchema, keeping unknown attributes in Extra
+func (b *BaseSubSchema) UnmarshalJSON(data []byte) error {
+	type baseSubSchema BaseSubSchema
+	if err := json.Unmarshal(data, (*baseSubSchema)(b)); err != nil {
+		return err
+	}
+	extra, err := schemaExtra(data, (*baseSubSchema)(b))
+	b.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Base SubSchema with its Extra attributes
+func (b BaseSubSchema) MarshalJSON() ([]byte, error) {
+	type baseSubSchema BaseSubSchema
+	return marshalSchemaExtra(baseSubSchema(b), b.Extra)
+}
+
+// UnmarshalJSON decodes a Custom SubSchema, keeping unknown attributes in Extra
+func (c *CustomSubSchema) UnmarshalJSON(data []byte) error {
+	type customSubSchema CustomSubSchema
+	if err := json.Unmarshal(data, (*customSubSchema)(c)); err != nil {
+		return err
+	}
+	extra, err := schemaExtra(data, (*customSubSchema)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes a Custom SubSchema with its Extra attributes. The items are left out of non array
+// SubSchemas, the enum and oneOf values are sent as numbers or booleans for SubSchemas of those types
+func (c CustomSubSchema) MarshalJSON() ([]byte, error) {
+	type customSubSchema CustomSubSchema
+	aux := struct {
+		customSubSchema
+		Items *SubSchemaItems   `json:"items,omitempty"`
+		Enum  []json.RawMessage `json:"enum,omitempty"`
+		OneOf []json.RawMessage `json:"oneOf,omitempty"`
+	}{customSubSchema: customSubSchema(c)}
+	if c.Type == "array" || c.Items.Type != "" || len(c.Items.Enum) > 0 || len(c.Items.OneOf) > 0 || len(c.Items.Extra) > 0 {
+		aux.Items = &c.Items
+	}
+	var err error
+	if aux.Enum, err = c.Enum.values(c.Type); err != nil {
+		return nil, err
+	}
+	if aux.OneOf, err = oneOfValues(c.OneOf, c.Type); err != nil {
+		return nil, err
+	}
+	return marshalSchemaExtra(aux, c.Extra)
+}
+
+// UnmarshalJSON decodes the items of a SubSchema, keeping unknown attributes in Extra
+func (i *SubSchemaItems) UnmarshalJSON(data []byte) error {
+	type subSchemaItems SubSchemaItems
+	if err := json.Unmarshal(data, (*subSchemaItems)(i)); err != nil {
+		return err
+	}
+	extra, err := schemaExtra(data, (*subSchemaItems)(i))
+	i.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the items of a SubSchema with their Extra attributes
+func (i SubSchemaItems) MarshalJSON() ([]byte, error) {
+	type subSchemaItems SubSchemaItems
+	aux := struct {
+		subSchemaItems
+		Enum  []json.RawMessage `json:"enum,omitempty"`
+		OneOf []json.RawMessage `json:"oneOf,omitempty"`
+	}{subSchemaItems: subSchemaItems(i)}
+	var err error
+	if aux.Enum, err = i.Enum.values(i.Type); err != nil {
+		return nil, err
+	}
+	if aux.OneOf, err = oneOfValues(i.OneOf, i.Type); err != nil {
+		return nil, err
+	}
+	return marshalSchemaExtra(aux, i.Extra)
+}
+
+// UnmarshalJSON decodes the values of an enum, keeping numbers and booleans in their JSON notation
+func (e *SchemaEnum) UnmarshalJSON(data []byte) error {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if values == nil {
+		*e = nil
+		return nil
+	}
+	enum := make(SchemaEnum, len(values))
+	for i, value := range values {
+		v, err := schemaScalar(value)
+		if err != nil {
+			return fmt.Errorf("[ERROR] enum value %v: %v", i, err)
+		}
+		enum[i] = v
+	}
+	*e = enum
+	return nil
+}
+
+// UnmarshalJSON decodes a OneOf, keeping a number or boolean const in its JSON notation
+func (o *OneOf) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Const json.RawMessage `json:"const"`
+		Title string          `json:"title"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	o.Title = aux.Title
+	if aux.Const == nil {
+		o.Const = ""
+		return nil
+	}
+	v, err := schemaScalar(aux.Const)
+	if err != nil {
+		return fmt.Errorf("[ERROR] oneOf const: %v", err)
+	}
+	o.Const = v
+	return nil
+}
+
+// values (unexported) returns the JSON values of the enum of a SubSchema of type schemaType
+func (e SchemaEnum) values(schemaType string) ([]json.RawMessage, error) {
+	if e == nil {
+		return nil, nil
+	}
+	values := make([]json.RawMessage, len(e))
+	for i, v := range e {
+		value, err := schemaScalarJSON(v, schemaType)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// oneOfValues (unexported) returns the JSON values of the oneOf of a SubSchema of type schemaType
+func oneOfValues(oneOf []OneOf, schemaType string) ([]json.RawMessage, error) {
+	if oneOf == nil {
+		return nil, nil
+	}
+	values := make([]json.RawMessage, len(oneOf))
+	for i, o := range oneOf {
+		c, err := schemaScalarJSON(o.Const, schemaType)
+		if err != nil {
+			return nil, err
+		}
+		title, _ := json.Marshal(o.Title)
+		values[i] = json.RawMessage(fmt.Sprintf(`{"const":%s,"title":%s}`, c, title))
+	}
+	return values, nil
+}
+
+// schemaScalar (unexported) returns a string JSON value as is, a number or boolean in its JSON notation
+func schemaScalar(value json.RawMessage) (string, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(value))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("%s is not a string, number or boolean", value)
+}
+
+// schemaScalarJSON (unexported) returns the JSON value of v for a SubSchema of type schemaType
+func schemaScalarJSON(v string, schemaType string) (json.RawMessage, error) {
+	switch schemaType {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("[ERROR] %q is not a valid %v", v, schemaType)
+		}
+		return json.RawMessage(v), nil
+	case "boolean":
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] %q is not a valid boolean", v)
+		}
+		return json.RawMessage(strconv.FormatBool(b)), nil
+	}
+	return json.Marshal(v)
+}
+
+// schemaExtra (unexported) returns the members of the JSON object data that are not fields of the struct v
+func schemaExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		for key := range members {
+			// encoding/json matches the members case insensitively
+			if strings.EqualFold(key, name) {
+				delete(members, key)
+			}
+		}
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members, nil
+}
+
+// marshalSchemaExtra (unexported) encodes v with the extra members
+func marshalSchemaExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := members[key]; !ok {
+			members[key] = value
+		}
+	}
+	return json.Marshal(members)
+}
+
+// sortedSchemaKeys (unexported) returns the keys of the properties of a definition, leaving out the
+// properties deleted with a null value
+func sortedSchemaKeys(properties map[string]json.RawMessage) []string {
+	var keys []string
+	for key, value := range properties {
+		if string(value) != "null" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// groupSchemaURL (unexported) is the URL of the Group Profile Schema
+const groupSchemaURL = "meta/schemas/group/default"
+
+// userSchemaURL (unexported) returns the URL of the User Profile Schema with schemaID,
+// the schema of the default user type when schemaID is empty
+func userSchemaURL(schemaID string) string {
+	if schemaID == "" {
+		schemaID = DefaultUserSchemaID
+	}
+	return fmt.Sprintf("meta/schemas/user/%v", schemaID)
+}
+
+// GetRawUserSchema returns the User Profile Schema of the default user type as a map[string]interface{}
 func (s *SchemasService) GetRawUserSchema() (map[string]interface{}, *Response, error) {
-	u := fmt.Sprintf("meta/schemas/user/default")
+	return s.rawSchema(userSchemaURL(""))
+}
+
+// GetRawUserSchemaForType returns the User Profile Schema of a user type as a map[string]interface{}
+// input is the schema ID of the user type, such as from UserType.SchemaID()
+func (s *SchemasService) GetRawUserSchemaForType(schemaID string) (map[string]interface{}, *Response, error) {
+	return s.rawSchema(userSchemaURL(schemaID))
+}
+
+// GetUserSchema returns the User Profile Schema of the default user type as a Schema struct
+func (s *SchemasService) GetUserSchema() (*Schema, *Response, error) {
+	return s.schema(userSchemaURL(""))
+}
+
+// GetUserSchemaForType returns the User Profile Schema of a user type as a Schema struct
+// input is the schema ID of the user type
+func (s *SchemasService) GetUserSchemaForType(schemaID string) (*Schema, *Response, error) {
+	return s.schema(userSchemaURL(schemaID))
+}
+
+// rawSchema (unexported) returns the Schema at u as a map[string]interface{}
+func (s *SchemasService) rawSchema(u string) (map[string]interface{}, *Response, error) {
 	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
 		return nil, nil, err
@@ -123,135 +606,66 @@ func (s *SchemasService) GetRawUserSchema() (map[string]interface{}, *Response,
 	return obj, resp, err
 }
 
-// GetUserSchema returns the User Profile Schema as a Schema struct
-func (s *SchemasService) GetUserSchema() (*Schema, *Response, error) {
-	obj, resp, err := s.client.Schemas.GetRawUserSchema()
+// schema (unexported) returns the Schema at u as a Schema struct
+func (s *SchemasService) schema(u string) (*Schema, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return nil, resp, err
+		return nil, nil, err
 	}
-	schema, err := s.client.Schemas.userSchema(obj)
+	schema := new(Schema)
+	resp, err := s.client.Do(req, schema)
 	if err != nil {
 		return nil, resp, err
 	}
-	return schema, resp, nil
+	return schema, resp, err
 }
 
 // userSchema (unexported) used to populate the Schema struct from a map[string]interface{}
 // input is a map[string]interface{} of the User Profile Schema, such as from GetRawUserSchema()
 func (s *SchemasService) userSchema(obj map[string]interface{}) (*Schema, error) {
-	layout := "2006-01-02T15:04:05.000Z"
-	create, err := time.Parse(layout, obj["created"].(string))
-	if err != nil {
-		return nil, err
-	}
-	update, _ := time.Parse(layout, obj["lastUpdated"].(string))
+	data, err := json.Marshal(obj)
 	if err != nil {
 		return nil, err
 	}
 	schema := new(Schema)
-	schema.ID = obj["id"].(string)
-	schema.Schema = obj["$schema"].(string)
-	schema.Name = obj["name"].(string)
-	schema.Title = obj["title"].(string)
-	schema.Created = create
-	schema.LastUpdated = update
-	schema.Type = obj["type"].(string)
-	for k, v := range obj["definitions"].(map[string]interface{}) {
-		switch k {
-		case "base":
-			for k2, v2 := range v.(map[string]interface{}) {
-				switch k2 {
-				case "id":
-					schema.Definitions.Base.ID = v2.(string)
-				case "type":
-					schema.Definitions.Base.Type = v2.(string)
-				case "required":
-					var req []string
-					for _, v3 := range v2.([]interface{}) {
-						req = append(req, v3.(string))
-					}
-					schema.Definitions.Base.Required = req
-				case "properties":
-					for k3, v3 := range v2.(map[string]interface{}) {
-						sub, err := s.client.Schemas.GetUserBaseSubSchema(k3, v3.(map[string]interface{}))
-						if err != nil {
-							return nil, err
-						}
-						schema.Definitions.Base.Properties = append(schema.Definitions.Base.Properties, *sub)
-					}
-				}
-			}
-		case "custom":
-			for k2, v2 := range v.(map[string]interface{}) {
-				switch k2 {
-				case "id":
-					schema.Definitions.Custom.ID = v2.(string)
-				case "type":
-					schema.Definitions.Custom.Type = v2.(string)
-				case "required":
-					var req []string
-					for _, v3 := range v2.([]interface{}) {
-						req = append(req, v3.(string))
-					}
-					schema.Definitions.Custom.Required = req
-				case "properties":
-					for k3, v3 := range v2.(map[string]interface{}) {
-						sub, err := s.client.Schemas.GetUserCustomSubSchema(k3, v3.(map[string]interface{}))
-						if err != nil {
-							return nil, err
-						}
-						schema.Definitions.Custom.Properties = append(schema.Definitions.Custom.Properties, *sub)
-					}
-				}
-			}
-		}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("[ERROR] Schema parsing error: %v", err)
 	}
-	return schema, err
+	return schema, nil
 }
 
-// userSubSchemaPropMap (unexported) returns the User Profile Schema Properties as a map[string]interface{}
+// subSchemaPropMap (unexported) returns the Properties of the Schema at u as a map[string]interface{}
 // input is a string subschema scope "base" or "custom"
-func (s *SchemasService) userSubSchemaPropMap(scope string) (map[string]interface{}, *Response, error) {
+func (s *SchemasService) subSchemaPropMap(u string, scope string) (map[string]interface{}, *Response, error) {
 	if scope != "base" && scope != "custom" {
 		return nil, nil, fmt.Errorf("[ERROR] SubSchema Properties Map scope input supports values \"base\" or \"custom\"")
 	}
-	obj, resp, err := s.client.Schemas.GetRawUserSchema()
+	obj, resp, err := s.rawSchema(u)
 	if err != nil {
 		return nil, resp, err
 	}
-	for k, v := range obj["definitions"].(map[string]interface{}) {
-		if k == scope {
-			for k2, v2 := range v.(map[string]interface{}) {
-				if k2 == "properties" {
-					return v2.(map[string]interface{}), nil, nil
-				}
-			}
-		}
-	}
-	return nil, nil, nil
+	definitions, _ := obj["definitions"].(map[string]interface{})
+	definition, _ := definitions[scope].(map[string]interface{})
+	properties, _ := definition["properties"].(map[string]interface{})
+	return properties, resp, nil
 }
 
-// GetUserSubSchemaPropMap returns the User Profile SubSchema as a map[string]interface{}
-// inputs are a string subschema scope "base" or "custom" & the index key for the User Profile SubSchema
-func (s *SchemasService) GetUserSubSchemaPropMap(scope string, index string) (map[string]interface{}, *Response, error) {
-	prop, resp, err := s.client.Schemas.userSubSchemaPropMap(scope)
+// subSchemaProp (unexported) returns the SubSchema index of the Schema at u as a map[string]interface{}
+func (s *SchemasService) subSchemaProp(u string, scope string, index string) (map[string]interface{}, *Response, error) {
+	prop, resp, err := s.subSchemaPropMap(u, scope)
 	if err != nil {
 		return nil, resp, err
 	}
-	if v, ok := prop[index]; ok {
-		return v.(map[string]interface{}), resp, err
-	} else {
-		return nil, resp, fmt.Errorf("[ERROR] GetUserSubSchemaPropMap subschema %v not found in Okta", index)
+	if v, ok := prop[index].(map[string]interface{}); ok {
+		return v, resp, err
 	}
-
-	return nil, resp, err
+	return nil, resp, fmt.Errorf("[ERROR] subschema %v not found in Okta", index)
 }
 
-// GetUserSubSchemaIndex returns an array of User Profile SubSchema index keys
-// input is a string subschema scope "base" or "custom"
-func (s *SchemasService) GetUserSubSchemaIndex(scope string) ([]string, *Response, error) {
+// subSchemaIndex (unexported) returns an array of the SubSchema index keys of the Schema at u
+func (s *SchemasService) subSchemaIndex(u string, scope string) ([]string, *Response, error) {
 	var index []string
-	prop, resp, err := s.client.Schemas.userSubSchemaPropMap(scope)
+	prop, resp, err := s.subSchemaPropMap(u, scope)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -261,61 +675,43 @@ func (s *SchemasService) GetUserSubSchemaIndex(scope string) ([]string, *Respons
 	return index, resp, err
 }
 
+// GetUserSubSchemaPropMap returns the User Profile SubSchema as a map[string]interface{}
+// inputs are a string subschema scope "base" or "custom" & the index key for the User Profile SubSchema
+func (s *SchemasService) GetUserSubSchemaPropMap(scope string, index string) (map[string]interface{}, *Response, error) {
+	return s.subSchemaProp(userSchemaURL(""), scope, index)
+}
+
+// GetUserSubSchemaPropMapForType returns the User Profile SubSchema of a user type as a map[string]interface{}
+// inputs are the schema ID of the user type, a string subschema scope "base" or "custom" & the index key for the SubSchema
+func (s *SchemasService) GetUserSubSchemaPropMapForType(schemaID string, scope string, index string) (map[string]interface{}, *Response, error) {
+	return s.subSchemaProp(userSchemaURL(schemaID), scope, index)
+}
+
+// GetUserSubSchemaIndex returns an array of User Profile SubSchema index keys
+// input is a string subschema scope "base" or "custom"
+func (s *SchemasService) GetUserSubSchemaIndex(scope string) ([]string, *Response, error) {
+	return s.subSchemaIndex(userSchemaURL(""), scope)
+}
+
+// GetUserSubSchemaIndexForType returns an array of the User Profile SubSchema index keys of a user type
+// inputs are the schema ID of the user type & a string subschema scope "base" or "custom"
+func (s *SchemasService) GetUserSubSchemaIndexForType(schemaID string, scope string) ([]string, *Response, error) {
+	return s.subSchemaIndex(userSchemaURL(schemaID), scope)
+}
+
 // GetUserBaseSubSchema returns the User Base Profile SubSchema as a BaseSubSchema struct
 // inputs are a string index key for the SubSchema & a map[string]interface{} for the
 // User Profile SubSchema, such as from GetUserSubSchemaPropMap()
 func (s *SchemasService) GetUserBaseSubSchema(index string, obj map[string]interface{}) (*BaseSubSchema, error) {
-	subSchema := new(BaseSubSchema)
-	subSchema.Index = index
-	if v, ok := obj["title"]; ok {
-		subSchema.Title = v.(string)
-	} else {
+	if _, ok := obj["title"].(string); !ok {
 		// if we cant find a title field, we'll assume this obj map is not correct
 		return nil, fmt.Errorf("[ERROR] GetUserBaseSubSchema interface map parsing error")
 	}
-	if v, ok := obj["type"]; ok {
-		subSchema.Type = v.(string)
-	}
-	if v, ok := obj["format"]; ok {
-		subSchema.Format = v.(string)
-	}
-	if v, ok := obj["required"]; ok {
-		subSchema.Required = v.(bool)
-	}
-	if v, ok := obj["mutability"]; ok {
-		subSchema.Mutability = v.(string)
-	}
-	if v, ok := obj["scope"]; ok {
-		subSchema.Scope = v.(string)
-	}
-	if v, ok := obj["minLength"]; ok {
-		subSchema.MinLength = int(v.(float64))
-	}
-	if v, ok := obj["maxLength"]; ok {
-		subSchema.MaxLength = int(v.(float64))
-	}
-	if v, ok := obj["permissions"]; ok {
-		perms := make([]Permissions, len(v.([]interface{})))
-		for k2, v2 := range v.([]interface{}) {
-			for k3, v3 := range v2.(map[string]interface{}) {
-				switch k3 {
-				case "principal":
-					perms[k2].Principal = v3.(string)
-				case "action":
-					perms[k2].Action = v3.(string)
-				}
-			}
-		}
-		subSchema.Permissions = perms
-	}
-	if v, ok := obj["master"]; ok {
-		for k2, v2 := range v.(map[string]interface{}) {
-			switch k2 {
-			case "type":
-				subSchema.Master = &Master{Type: v2.(string)}
-			}
-		}
+	subSchema := new(BaseSubSchema)
+	if err := remarshalSubSchema(obj, subSchema); err != nil {
+		return nil, fmt.Errorf("[ERROR] GetUserBaseSubSchema interface map parsing error: %v", err)
 	}
+	subSchema.Index = index
 	return subSchema, nil
 }
 
@@ -323,163 +719,173 @@ func (s *SchemasService) GetUserBaseSubSchema(index string, obj map[string]inter
 // inputs are a string index key for the SubSchema & a map[string]interface{} for the
 // User Profile SubSchema, such as from GetUserSubSchemaPropMap()
 func (s *SchemasService) GetUserCustomSubSchema(index string, obj map[string]interface{}) (*CustomSubSchema, error) {
-	subSchema := new(CustomSubSchema)
-	subSchema.Index = index
-	if v, ok := obj["title"]; ok {
-		subSchema.Title = v.(string)
-	} else {
+	if _, ok := obj["title"].(string); !ok {
 		// if we cant find a title field, we'll assume this obj map is not correct
 		return nil, fmt.Errorf("[ERROR] GetUserCustomSubSchema interface map parsing error")
 	}
-	if v, ok := obj["type"]; ok {
-		subSchema.Type = v.(string)
-	}
-	if v, ok := obj["description"]; ok {
-		subSchema.Description = v.(string)
-	}
-	if v, ok := obj["format"]; ok {
-		subSchema.Format = v.(string)
-	}
-	if v, ok := obj["required"]; ok {
-		subSchema.Required = v.(bool)
-	}
-	if v, ok := obj["mutability"]; ok {
-		subSchema.Mutability = v.(string)
-	}
-	if v, ok := obj["scope"]; ok {
-		subSchema.Scope = v.(string)
-	}
-	if v, ok := obj["minLength"]; ok {
-		subSchema.MinLength = int(v.(float64))
-	}
-	if v, ok := obj["maxLength"]; ok {
-		subSchema.MaxLength = int(v.(float64))
-	}
-	if v, ok := obj["items"]; ok {
-		for k2, v2 := range v.(map[string]interface{}) {
-			switch k2 {
-			case "type":
-				subSchema.Items.Type = v2.(string)
-			}
-		}
-	}
-	if v, ok := obj["union"]; ok {
-		subSchema.Union = v.(string)
-	}
-	if v, ok := obj["enum"]; ok {
-		// assuming here all enum values are strings, I hope i'm right
-		enum := make([]string, 0)
-		for _, v2 := range v.([]interface{}) {
-			enum = append(enum, v2.(string))
-		}
-		subSchema.Enum = enum
-	}
-	if v, ok := obj["oneOf"]; ok {
-		oneof := make([]OneOf, len(v.([]interface{})))
-		for k2, v2 := range v.([]interface{}) {
-			for k3, v3 := range v2.(map[string]interface{}) {
-				switch k3 {
-				case "const":
-					oneof[k2].Const = v3.(string)
-				case "title":
-					oneof[k2].Title = v3.(string)
-				}
-			}
-		}
-		subSchema.OneOf = oneof
-	}
-	if v, ok := obj["permissions"]; ok {
-		perms := make([]Permissions, len(v.([]interface{})))
-		for k2, v2 := range v.([]interface{}) {
-			for k3, v3 := range v2.(map[string]interface{}) {
-				switch k3 {
-				case "principal":
-					perms[k2].Principal = v3.(string)
-				case "action":
-					perms[k2].Action = v3.(string)
-				}
-			}
-		}
-		subSchema.Permissions = perms
-	}
-	if v, ok := obj["master"]; ok {
-		for k2, v2 := range v.(map[string]interface{}) {
-			switch k2 {
-			case "type":
-				subSchema.Master = &Master{Type: v2.(string)}
-			}
-		}
+	subSchema := new(CustomSubSchema)
+	if err := remarshalSubSchema(obj, subSchema); err != nil {
+		return nil, fmt.Errorf("[ERROR] GetUserCustomSubSchema interface map parsing error: %v", err)
 	}
+	subSchema.Index = index
 	return subSchema, nil
 }
 
+// remarshalSubSchema (unexported) decodes the SubSchema map obj into subSchema
+func remarshalSubSchema(obj map[string]interface{}, subSchema interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, subSchema)
+}
+
 // UpdateUserCustomSubSchema Adds or Updates a Custom SubSchema
 // input is a CustomSubSchema struct
 func (s *SchemasService) UpdateUserCustomSubSchema(update CustomSubSchema) (*Schema, *Response, error) {
-	index := update.Index
-	subschema, err := json.Marshal(update)
-	if err != nil {
-		return nil, nil, err
-	}
-	raw := fmt.Sprintf(`{ "definitions": { "custom": { "id": "#custom", "type": "object", "properties": { "%s": %s }, "required": [] } } }`, index, string(subschema))
-	// remove the escaped double quotes during NewRequest Marshal serialization
-	ser := json.RawMessage(raw)
-	u := fmt.Sprintf("meta/schemas/user/default")
-	req, err := s.client.NewRequest("POST", u, ser)
-	if err != nil {
-		return nil, nil, err
-	}
-	var obj map[string]interface{}
-	resp, err := s.client.Do(req, &obj)
-	if err != nil {
-		return nil, resp, err
-	}
-	schema, err := s.client.Schemas.userSchema(obj)
-	return schema, resp, err
+	return s.updateSubSchema(userSchemaURL(""), "custom", update.Index, update)
+}
+
+// UpdateUserCustomSubSchemaForType Adds or Updates a Custom SubSchema of a user type
+// inputs are the schema ID of the user type & a CustomSubSchema struct
+func (s *SchemasService) UpdateUserCustomSubSchemaForType(schemaID string, update CustomSubSchema) (*Schema, *Response, error) {
+	return s.updateSubSchema(userSchemaURL(schemaID), "custom", update.Index, update)
 }
 
 // DeleteUserCustomSubSchema deletes a Custom SubSchema
 // input is a string of the custom subschema index key
 func (s *SchemasService) DeleteUserCustomSubSchema(index string) (*Schema, *Response, error) {
-	raw := fmt.Sprintf(`{ "definitions": { "custom": { "id": "#custom", "type": "object", "properties": { "%s": null }, "required": [] } } }`, index)
-	// remove the escaped double quotes during NewRequest Marshal serialization
-	ser := json.RawMessage(raw)
-	u := fmt.Sprintf("meta/schemas/user/default")
-	req, err := s.client.NewRequest("POST", u, ser)
-	if err != nil {
-		return nil, nil, err
-	}
-	var obj map[string]interface{}
-	resp, err := s.client.Do(req, &obj)
-	if err != nil {
-		return nil, resp, err
-	}
-	schema, err := s.client.Schemas.userSchema(obj)
-	return schema, resp, err
+	return s.updateSubSchema(userSchemaURL(""), "custom", index, nil)
+}
+
+// DeleteUserCustomSubSchemaForType deletes a Custom SubSchema of a user type
+// inputs are the schema ID of the user type & a string of the custom subschema index key
+func (s *SchemasService) DeleteUserCustomSubSchemaForType(schemaID string, index string) (*Schema, *Response, error) {
+	return s.updateSubSchema(userSchemaURL(schemaID), "custom", index, nil)
 }
 
 // UpdateUserBaseSubSchema Updates a Base SubSchema
 // can only update subschema permissions & the nullability of the firstName and lastName subschemas
 // input is a BaseSubSchema struct
 func (s *SchemasService) UpdateUserBaseSubSchema(update BaseSubSchema) (*Schema, *Response, error) {
-	index := update.Index
-	subschema, err := json.Marshal(update)
-	if err != nil {
-		return nil, nil, err
-	}
-	raw := fmt.Sprintf(`{ "definitions": { "base": { "id": "#base", "type": "object", "properties": { "%s": %s }, "required": [] } } }`, index, string(subschema))
-	// remove the escaped double quotes during NewRequest Marshal serialization
-	ser := json.RawMessage(raw)
-	u := fmt.Sprintf("meta/schemas/user/default")
-	req, err := s.client.NewRequest("POST", u, ser)
+	return s.updateSubSchema(userSchemaURL(""), "base", update.Index, update)
+}
+
+// UpdateUserBaseSubSchemaForType Updates a Base SubSchema of a user type
+// inputs are the schema ID of the user type & a BaseSubSchema struct
+func (s *SchemasService) UpdateUserBaseSubSchemaForType(schemaID string, update BaseSubSchema) (*Schema, *Response, error) {
+	return s.updateSubSchema(userSchemaURL(schemaID), "base", update.Index, update)
+}
+
+// updateSubSchema (unexported) adds, updates or, when update is nil, deletes the SubSchema index in the
+// scope "base" or "custom" of the Schema at u
+func (s *SchemasService) updateSubSchema(u string, scope string, index string, update interface{}) (*Schema, *Response, error) {
+	body := map[string]interface{}{
+		"definitions": map[string]interface{}{
+			scope: map[string]interface{}{
+				"id":         "#" + scope,
+				"type":       "object",
+				"properties": map[string]interface{}{index: update},
+				"required":   []string{},
+			},
+		},
+	}
+	req, err := s.client.NewRequest("POST", u, body)
 	if err != nil {
 		return nil, nil, err
 	}
-	var obj map[string]interface{}
-	resp, err := s.client.Do(req, &obj)
+	schema := new(Schema)
+	resp, err := s.client.Do(req, schema)
 	if err != nil {
 		return nil, resp, err
 	}
-	schema, err := s.client.Schemas.userSchema(obj)
 	return schema, resp, err
 }
+
+// GetRawGroupSchema returns the Group Profile Schema as a map[string]interface{}
+func (s *SchemasService) GetRawGroupSchema() (map[string]interface{}, *Response, error) {
+	return s.rawSchema(groupSchemaURL)
+}
+
+// GetGroupSchema returns the Group Profile Schema as a Schema struct
+func (s *SchemasService) GetGroupSchema() (*Schema, *Response, error) {
+	return s.schema(groupSchemaURL)
+}
+
+// GetGroupSubSchemaPropMap returns the Group Profile SubSchema as a map[string]interface{}
+// inputs are a string subschema scope "base" or "custom" & the index key for the Group Profile SubSchema
+func (s *SchemasService) GetGroupSubSchemaPropMap(scope string, index string) (map[string]interface{}, *Response, error) {
+	return s.subSchemaProp(groupSchemaURL, scope, index)
+}
+
+// GetGroupSubSchemaIndex returns an array of Group Profile SubSchema index keys
+// input is a string subschema scope "base" or "custom"
+func (s *SchemasService) GetGroupSubSchemaIndex(scope string) ([]string, *Response, error) {
+	return s.subSchemaIndex(groupSchemaURL, scope)
+}
+
+// UpdateGroupCustomSubSchema Adds or Updates a Custom Group SubSchema
+// input is a CustomSubSchema struct
+func (s *SchemasService) UpdateGroupCustomSubSchema(update CustomSubSchema) (*Schema, *Response, error) {
+	return s.updateSubSchema(groupSchemaURL, "custom", update.Index, update)
+}
+
+// DeleteGroupCustomSubSchema deletes a Custom Group SubSchema
+// input is a string of the custom subschema index key
+func (s *SchemasService) DeleteGroupCustomSubSchema(index string) (*Schema, *Response, error) {
+	return s.updateSubSchema(groupSchemaURL, "custom", index, nil)
+}
+
+// UpdateGroupBaseSubSchema Updates a Base Group SubSchema
+// can only update the subschema permissions
+// input is a BaseSubSchema struct
+func (s *SchemasService) UpdateGroupBaseSubSchema(update BaseSubSchema) (*Schema, *Response, error) {
+	return s.updateSubSchema(groupSchemaURL, "base", update.Index, update)
+}
+
+// appUserSchemaURL (unexported) returns the URL of the App User Profile Schema of the app appID
+func appUserSchemaURL(appID string) string {
+	return fmt.Sprintf("meta/schemas/apps/%v/default", appID)
+}
+
+// GetRawAppUserSchema returns the App User Profile Schema of an app as a map[string]interface{}
+// input is the App ID from the App object
+func (s *SchemasService) GetRawAppUserSchema(appID string) (map[string]interface{}, *Response, error) {
+	return s.rawSchema(appUserSchemaURL(appID))
+}
+
+// GetAppUserSchema returns the App User Profile Schema of an app as a Schema struct
+// input is the App ID from the App object
+func (s *SchemasService) GetAppUserSchema(appID string) (*Schema, *Response, error) {
+	return s.schema(appUserSchemaURL(appID))
+}
+
+// GetAppUserSubSchemaPropMap returns the App User Profile SubSchema as a map[string]interface{}
+// inputs are the App ID, a string subschema scope "base" or "custom" & the index key for the App User Profile SubSchema
+func (s *SchemasService) GetAppUserSubSchemaPropMap(appID string, scope string, index string) (map[string]interface{}, *Response, error) {
+	return s.subSchemaProp(appUserSchemaURL(appID), scope, index)
+}
+
+// GetAppUserSubSchemaIndex returns an array of App User Profile SubSchema index keys
+// inputs are the App ID & a string subschema scope "base" or "custom"
+func (s *SchemasService) GetAppUserSubSchemaIndex(appID string, scope string) ([]string, *Response, error) {
+	return s.subSchemaIndex(appUserSchemaURL(appID), scope)
+}
+
+// UpdateAppUserCustomSubSchema Adds or Updates a Custom App User SubSchema
+// inputs are the App ID & a CustomSubSchema struct
+func (s *SchemasService) UpdateAppUserCustomSubSchema(appID string, update CustomSubSchema) (*Schema, *Response, error) {
+	return s.updateSubSchema(appUserSchemaURL(appID), "custom", update.Index, update)
+}
+
+// DeleteAppUserCustomSubSchema deletes a Custom App User SubSchema
+// inputs are the App ID & a string of the custom subschema index key
+func (s *SchemasService) DeleteAppUserCustomSubSchema(appID string, index string) (*Schema, *Response, error) {
+	return s.updateSubSchema(appUserSchemaURL(appID), "custom", index, nil)
+}
+
+// UpdateAppUserBaseSubSchema Updates a Base App User SubSchema
+// inputs are the App ID & a BaseSubSchema struct
+func (s *SchemasService) UpdateAppUserBaseSubSchema(appID string, update BaseSubSchema) (*Schema, *Response, error) {
+	return s.updateSubSchema(appUserSchemaURL(appID), "base", update.Index, update)
+}
@@ -3,8 +3,10 @@ package okta
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -384,3 +386,352 @@ func TestDeleteCustomSubSchema(t *testing.T) {
 		t.Errorf("client.Schemas.GetUserBaseSubSchema returned \n\t%+v, want \n\t%+v\n", final, orig)
 	}
 }
+
+func TestUserTypeSchema(t *testing.T) {
+
+	setup()
+	defer teardown()
+	setupTestSchemas()
+
+	mux.HandleFunc("/meta/schemas/user/oscfnjfba4ye7pgjB0g4", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"testSubSchema"`) {
+				t.Errorf("Schemas.UpdateUserCustomSubSchemaForType sent %v", string(body))
+			}
+		} else {
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, schemaTestJSONString)
+	})
+
+	index, _, err := client.Schemas.GetUserSubSchemaIndexForType("oscfnjfba4ye7pgjB0g4", "custom")
+	if err != nil {
+		t.Errorf("SchemaIndex.Get returned error: %v", err)
+	}
+	if len(index) != 1 || index[0] != testCustomSubSchema.Index {
+		t.Errorf("client.Schemas.GetUserSubSchemaIndexForType returned %+v", index)
+	}
+
+	schema, _, err := client.Schemas.UpdateUserCustomSubSchemaForType("oscfnjfba4ye7pgjB0g4", *testCustomSubSchema)
+	if err != nil {
+		t.Errorf("CustomSubSchema.Update returned error: %v", err)
+	}
+	if schema.Definitions.Custom.Properties[0].Index != testCustomSubSchema.Index {
+		t.Errorf("client.Schemas.UpdateUserCustomSubSchemaForType returned %+v", schema)
+	}
+}
+
+var groupSchemaTestJSONString = `
+{
+    "id": "https://dev-XXXX.oktapreview.com/meta/schemas/group/default",
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "name": "group",
+    "title": "Okta group",
+    "description": "Okta group profile template",
+    "type": "object",
+    "lastUpdated": "2018-02-16T19:59:05.000Z",
+    "created": "2018-02-16T19:59:05.000Z",
+    "definitions": {
+        "base": {
+            "id": "#base",
+            "type": "object",
+            "properties": {
+                "name": {
+                    "title": "Name",
+                    "type": "string",
+                    "required": true,
+                    "mutability": "READ_WRITE",
+                    "scope": "NONE",
+                    "maxLength": 255,
+                    "permissions": [{"principal": "SELF", "action": "READ_ONLY"}]
+                }
+            },
+            "required": ["name"]
+        },
+        "custom": {
+            "id": "#custom",
+            "type": "object",
+            "properties": {
+                "costCenter": {
+                    "title": "Cost center",
+                    "type": "string",
+                    "mutability": "READ_WRITE",
+                    "scope": "NONE",
+                    "permissions": [{"principal": "SELF", "action": "READ_ONLY"}]
+                }
+            },
+            "required": []
+        }
+    },
+    "properties": {
+        "allOf": [{"$ref": "#/definitions/base"}, {"$ref": "#/definitions/custom"}]
+    }
+}
+`
+
+func TestGroupSchema(t *testing.T) {
+
+	setup()
+	defer teardown()
+
+	costCenter := CustomSubSchema{Index: "costCenter", Title: "Cost center", Type: "string", Mutability: "READ_WRITE", Scope: "NONE"}
+	costCenter.Permissions = []Permissions{{Principal: "SELF", Action: "READ_ONLY"}}
+
+	mux.HandleFunc("/meta/schemas/group/default", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			testBody(t, r, map[string]interface{}{"definitions": map[string]interface{}{"custom": map[string]interface{}{
+				"id": "#custom", "type": "object", "properties": map[string]interface{}{"costCenter": costCenter}, "required": []string{},
+			}}})
+		} else {
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, groupSchemaTestJSONString)
+	})
+
+	schema, _, err := client.Schemas.GetGroupSchema()
+	if err != nil {
+		t.Fatalf("Schemas.GetGroupSchema returned error: %v", err)
+	}
+	if schema.Name != "group" || schema.Definitions.Base.Properties[0].Index != "name" || schema.Definitions.Base.Properties[0].MaxLength != 255 {
+		t.Errorf("client.Schemas.GetGroupSchema returned %+v", schema)
+	}
+
+	propMap, _, err := client.Schemas.GetGroupSubSchemaPropMap("custom", "costCenter")
+	if err != nil {
+		t.Fatalf("Schemas.GetGroupSubSchemaPropMap returned error: %v", err)
+	}
+	custom, err := client.Schemas.GetUserCustomSubSchema("costCenter", propMap)
+	if err != nil {
+		t.Errorf("Schemas.GetUserCustomSubSchema returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*custom, costCenter) {
+		t.Errorf("client.Schemas.GetUserCustomSubSchema returned \n\t%+v, want \n\t%+v\n", custom, costCenter)
+	}
+
+	if _, _, err := client.Schemas.UpdateGroupCustomSubSchema(costCenter); err != nil {
+		t.Errorf("Schemas.UpdateGroupCustomSubSchema returned error: %v", err)
+	}
+}
+
+func TestAppUserSchema(t *testing.T) {
+
+	setup()
+	defer teardown()
+	setupTestSchemas()
+
+	mux.HandleFunc("/meta/schemas/apps/0oa25gejWwdXNnFH90g4/default", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		if r.Method == "POST" {
+			testBody(t, r, json.RawMessage(`{"definitions":{"custom":{"id":"#custom","properties":{"testSubSchema":null},"required":[],"type":"object"}}}`))
+		} else {
+			testMethod(t, r, "GET")
+		}
+		fmt.Fprint(w, schemaTestJSONString)
+	})
+
+	index, _, err := client.Schemas.GetAppUserSubSchemaIndex("0oa25gejWwdXNnFH90g4", "base")
+	if err != nil {
+		t.Errorf("Schemas.GetAppUserSubSchemaIndex returned error: %v", err)
+	}
+	if len(index) != 1 || index[0] != testBaseSubSchema.Index {
+		t.Errorf("client.Schemas.GetAppUserSubSchemaIndex returned %+v", index)
+	}
+
+	if _, _, err := client.Schemas.DeleteAppUserCustomSubSchema("0oa25gejWwdXNnFH90g4", "testSubSchema"); err != nil {
+		t.Errorf("Schemas.DeleteAppUserCustomSubSchema returned error: %v", err)
+	}
+}
+
+var schemaLosslessTestJSONString = `
+{
+    "id": "https://dev-XXXX.oktapreview.com/meta/schemas/user/osc1g6ttimJeW8Zxg0g4",
+    "$schema": "http://json-schema.org/draft-04/schema#",
+    "name": "contractor",
+    "title": "Contractor",
+    "type": "object",
+    "created": "2019-10-02T17:19:16.000Z",
+    "lastUpdated": "2019-10-02T17:19:16.000Z",
+    "definitions": {
+        "base": {
+            "id": "#base",
+            "type": "object",
+            "properties": {
+                "login": {
+                    "title": "Username",
+                    "type": "string",
+                    "required": true,
+                    "mutability": "READ_WRITE",
+                    "scope": "NONE",
+                    "minLength": 5,
+                    "maxLength": 100,
+                    "pattern": ".+",
+                    "unique": "UNIQUE_VALIDATED",
+                    "permissions": [{"principal": "SELF", "action": "READ_ONLY"}],
+                    "master": {"type": "OVERRIDE", "priority": [{"type": "APP", "value": "0oa25gejWwdXNnFH90g4"}, {"type": "OKTA"}]}
+                }
+            },
+            "required": ["login"]
+        },
+        "custom": {
+            "id": "#custom",
+            "type": "object",
+            "properties": {
+                "employeeNumber": {
+                    "title": "Employee number",
+                    "type": "string",
+                    "externalName": "employeeNumber",
+                    "externalNamespace": "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User",
+                    "unique": "UNIQUE_VALIDATED",
+                    "nullable": false,
+                    "permissions": [{"principal": "SELF", "action": "READ_ONLY"}],
+                    "x-okta-future": {"enabled": true}
+                },
+                "shirtSizes": {
+                    "title": "Shirt sizes",
+                    "type": "array",
+                    "items": {"type": "string", "enum": ["S", "M", "L"], "oneOf": [{"const": "S", "title": "Small"}, {"const": "M", "title": "Medium"}, {"const": "L", "title": "Large"}]},
+                    "permissions": [{"principal": "SELF", "action": "READ_WRITE"}]
+                },
+                "clearance": {
+                    "title": "Clearance",
+                    "type": "integer",
+                    "minimum": 0,
+                    "maximum": 3,
+                    "enum": [0, 1, 2, 3],
+                    "oneOf": [{"const": 0, "title": "None"}, {"const": 3, "title": "Top"}],
+                    "permissions": [{"principal": "SELF", "action": "HIDE"}]
+                }
+            },
+            "required": []
+        }
+    },
+    "properties": {
+        "profile": {"allOf": [{"$ref": "#/definitions/base"}, {"$ref": "#/definitions/custom"}]}
+    }
+}
+`
+
+func TestSchemaLossless(t *testing.T) {
+
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/meta/schemas/user/osc1g6ttimJeW8Zxg0g4", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, schemaLosslessTestJSONString)
+	})
+
+	schema, _, err := client.Schemas.GetUserSchemaForType("osc1g6ttimJeW8Zxg0g4")
+	if err != nil {
+		t.Fatalf("Schemas.GetUserSchemaForType returned error: %v", err)
+	}
+
+	login := schema.Definitions.Base.Properties[0]
+	if login.Index != "login" || login.Pattern != ".+" || login.Unique != "UNIQUE_VALIDATED" || len(login.Master.Priority) != 2 {
+		t.Errorf("client.Schemas.GetUserSchemaForType returned base subschema %+v", login)
+	}
+	var indexes []string
+	for _, sub := range schema.Definitions.Custom.Properties {
+		indexes = append(indexes, sub.Index)
+	}
+	if want := []string{"clearance", "employeeNumber", "shirtSizes"}; !reflect.DeepEqual(indexes, want) {
+		t.Errorf("client.Schemas.GetUserSchemaForType returned custom subschemas %v, want %v", indexes, want)
+	}
+	clearance, employee, shirts := schema.Definitions.Custom.Properties[0], schema.Definitions.Custom.Properties[1], schema.Definitions.Custom.Properties[2]
+	if !reflect.DeepEqual(clearance.Enum, SchemaEnum{"0", "1", "2", "3"}) || clearance.OneOf[1].Const != "3" || *clearance.Maximum != 3 {
+		t.Errorf("client.Schemas.GetUserSchemaForType returned subschema %+v", clearance)
+	}
+	if employee.ExternalName != "employeeNumber" || employee.Nullable == nil || *employee.Nullable || string(employee.Extra["x-okta-future"]) != `{"enabled": true}` {
+		t.Errorf("client.Schemas.GetUserSchemaForType returned subschema %+v", employee)
+	}
+	if !reflect.DeepEqual(shirts.Items.Enum, SchemaEnum{"S", "M", "L"}) || len(shirts.Items.OneOf) != 3 {
+		t.Errorf("client.Schemas.GetUserSchemaForType returned subschema items %+v", shirts.Items)
+	}
+	if _, ok := schema.Extra["properties"]; !ok {
+		t.Errorf("client.Schemas.GetUserSchema dropped the profile properties: %+v", schema.Extra)
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("json.Marshal of the Schema returned error: %v", err)
+	}
+	var got, want interface{}
+	json.Unmarshal(data, &got)
+	json.Unmarshal([]byte(schemaLosslessTestJSONString), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Marshal of the Schema returned \n\t%s, want \n\t%s\n", data, schemaLosslessTestJSONString)
+	}
+}
+
+func TestSubSchemaMarshal(t *testing.T) {
+	data, err := json.Marshal(BaseSubSchema{Index: "firstName", Title: "First name", Type: "string", Mutability: "READ_WRITE"})
+	if err != nil {
+		t.Fatalf("json.Marshal of the BaseSubSchema returned error: %v", err)
+	}
+	if want := `{"title":"First name","type":"string","mutability":"READ_WRITE","permissions":null}`; string(data) != want {
+		t.Errorf("json.Marshal of the BaseSubSchema returned %s, want %s", data, want)
+	}
+
+	if _, err := json.Marshal(CustomSubSchema{Title: "Level", Type: "integer", Enum: SchemaEnum{"1", "two"}}); err == nil {
+		t.Errorf("json.Marshal of a CustomSubSchema with a non integer enum value returned no error")
+	}
+}
+
+func TestSchemaMalformed(t *testing.T) {
+
+	setup()
+	defer teardown()
+
+	tests := []string{
+		`{"definitions": "base"}`,
+		`{"created": 42, "definitions": {}}`,
+		`{"definitions": {"custom": {"properties": {"shirtSize": "L"}}}}`,
+		`{"definitions": {"custom": {"properties": {"shirtSize": {"title": "Shirt size", "enum": [{"size": "L"}]}}}}}`,
+		`{"definitions": {"base": {"properties": {"login": {"title": "Username", "minLength": "five"}}}}}`,
+	}
+	for _, test := range tests {
+		var obj map[string]interface{}
+		json.Unmarshal([]byte(test), &obj)
+		if schema, err := client.Schemas.userSchema(obj); err == nil {
+			t.Errorf("client.Schemas.userSchema of %v returned %+v, want an error", test, schema)
+		}
+	}
+
+	if _, err := client.Schemas.GetUserCustomSubSchema("shirtSize", map[string]interface{}{"title": 42}); err == nil {
+		t.Errorf("client.Schemas.GetUserCustomSubSchema with a non string title returned no error")
+	}
+	if _, err := client.Schemas.GetUserBaseSubSchema("login", map[string]interface{}{"title": "Username", "master": "OKTA"}); err == nil {
+		t.Errorf("client.Schemas.GetUserBaseSubSchema with a string master returned no error")
+	}
+
+	mux.HandleFunc("/meta/schemas/user/default", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"definitions": {"custom": []}}`)
+	})
+	if index, _, err := client.Schemas.GetUserSubSchemaIndex("custom"); err != nil || len(index) != 0 {
+		t.Errorf("client.Schemas.GetUserSubSchemaIndex of a malformed schema returned %v, %v", index, err)
+	}
+	if _, _, err := client.Schemas.GetUserSubSchemaPropMap("custom", "shirtSize"); err == nil {
+		t.Errorf("client.Schemas.GetUserSubSchemaPropMap of a malformed schema returned no error")
+	}
+}
+
+func TestDeleteUserCustomSubSchemaQuotedIndex(t *testing.T) {
+
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/meta/schemas/user/default", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, json.RawMessage(`{"definitions":{"custom":{"id":"#custom","properties":{"cost\"Center":null},"required":[],"type":"object"}}}`))
+		fmt.Fprint(w, schemaTestJSONString)
+	})
+
+	if _, _, err := client.Schemas.DeleteUserCustomSubSchema(`cost"Center`); err != nil {
+		t.Errorf("Schemas.DeleteUserCustomSubSchema returned error: %v", err)
+	}
+}
@@ -65,6 +65,7 @@ type Client struct {
 	// From the http response, populate this var with the okta error code, if applicable
 	// https://developer.okta.com/reference/error_codes/
 	OktaErrorCode string
+	errorMu       sync.Mutex // errorMu protects OktaErrorCode when requests are made concurrently
 
 	// RateRemainingFloor - If the API returns a "X-Rate-Limit-Remaining" header less than this the SDK will either pause
 	//  Or throw  RateLimitError depending on the client.PauseOnRateLimit value. It defaults to 30
@@ -84,24 +85,51 @@ type Client struct {
 	// Service for Working with Users
 	Users *UsersService
 
+	// Service for Working with User Factors
+	UserFactors *UserFactorsService
+
+	// Service for the Authentication (authn) API
+	Authn *AuthnService
+
+	// Service for Working with Sessions
+	Sessions *SessionsService
+
+	// Service for Working with custom Authorization Servers
+	AuthorizationServers *AuthorizationServersService
+
 	// Service for Working with Groups
 	Groups *GroupsService
 
 	// Service for Working with Apps
 	Apps *AppsService
 
+	// Service for Working with Administrator Roles
+	Roles *RolesService
+
 	// Service for Working with Policies
 	Policies *PoliciesService
 
 	// Service for Working with Schemas
 	Schemas *SchemasService
 
+	// Service for Working with User Types
+	UserTypes *UserTypesService
+
 	// Service for Working with Identity Providers
 	IdentityProviders *IdentityProvidersService
 
 	// Service for Working with Trusted Origins
 	TrustedOrigins *TrustedOriginsService
 
+	// Service for Working with Event Hooks
+	EventHooks *EventHooksService
+
+	// Service for Working with Inline Hooks
+	InlineHooks *InlineHooksService
+
+	// Service for Working with Profile Mappings
+	ProfileMappings *ProfileMappingsService
+
 	// Org service for administrating org level resources
 	Org *OrgService
 }
@@ -153,12 +181,21 @@ func NewClientWithBaseURL(httpClient *http.Client, baseURL *url.URL, apiToken st
 	c.common.client = c
 
 	c.Users = (*UsersService)(&c.common)
+	c.UserFactors = (*UserFactorsService)(&c.common)
+	c.Authn = (*AuthnService)(&c.common)
+	c.Sessions = (*SessionsService)(&c.common)
+	c.AuthorizationServers = (*AuthorizationServersService)(&c.common)
 	c.Groups = (*GroupsService)(&c.common)
 	c.Apps = (*AppsService)(&c.common)
+	c.Roles = (*RolesService)(&c.common)
 	c.Policies = (*PoliciesService)(&c.common)
 	c.Schemas = (*SchemasService)(&c.common)
+	c.UserTypes = (*UserTypesService)(&c.common)
 	c.IdentityProviders = (*IdentityProvidersService)(&c.common)
 	c.TrustedOrigins = (*TrustedOriginsService)(&c.common)
+	c.EventHooks = (*EventHooksService)(&c.common)
+	c.InlineHooks = (*InlineHooksService)(&c.common)
+	c.ProfileMappings = (*ProfileMappingsService)(&c.common)
 	c.Org = (*OrgService)(&c.common)
 	return c
 }
@@ -360,7 +397,9 @@ func CheckResponse(c *Client, r *http.Response) error {
 	if err == nil && data != nil {
 		json.Unmarshal(data, &errorResp.ErrorDetail)
 	}
+	c.errorMu.Lock()
 	c.OktaErrorCode = errorResp.ErrorDetail.ErrorCode
+	c.errorMu.Unlock()
 
 	switch {
 	case r.StatusCode == http.StatusTooManyRequests:
@@ -383,6 +422,10 @@ type apiError struct {
 	ErrorCauses  []struct {
 		ErrorSummary string `json:"errorSummary"`
 	} `json:"errorCauses"`
+
+	// OAuth endpoints (/oauth2/...) report errors as defined in RFC 6749
+	OAuthError            string `json:"error"`
+	OAuthErrorDescription string `json:"error_description"`
 }
 
 type errorResponse struct {
@@ -391,6 +434,10 @@ type errorResponse struct {
 }
 
 func (r *errorResponse) Error() string {
+	if r.ErrorDetail.ErrorCode == "" && r.ErrorDetail.OAuthError != "" {
+		return fmt.Sprintf("HTTP Method: %v - URL: %v: - HTTP Status Code: %d, OAuth Error: %v, OAuth Error Description: %v",
+			r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.ErrorDetail.OAuthError, r.ErrorDetail.OAuthErrorDescription)
+	}
 	return fmt.Sprintf("HTTP Method: %v - URL: %v: - HTTP Status Code: %d, OKTA Error Code: %v, OKTA Error Summary: %v, OKTA Error Causes: %v",
 		r.Response.Request.Method, r.Response.Request.URL, r.Response.StatusCode, r.ErrorDetail.ErrorCode, r.ErrorDetail.ErrorSummary, r.ErrorDetail.ErrorCauses)
 }
@@ -542,6 +589,49 @@ func addOptions(s string, opt interface{}) (string, error) {
 	return u.String(), nil
 }
 
+// listPages (unexported) gets the pages of a list, from nextURL when set or else from s with the query parameters
+// in opt, and appends their items to the slice v points to. It follows the next links of the responses until the
+// last page or, unless allPages is set, until it got numberOfPages pages
+func (c *Client) listPages(s string, opt interface{}, nextURL *url.URL, allPages bool, numberOfPages int, v interface{}) (*Response, error) {
+	u := s
+	if nextURL != nil {
+		u = nextURL.String()
+	} else {
+		var err error
+		if u, err = addOptions(s, opt); err != nil {
+			return nil, err
+		}
+	}
+
+	items := reflect.ValueOf(v).Elem()
+	for pages := 1; ; pages++ {
+		req, err := c.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+		page := reflect.New(items.Type())
+		resp, err := c.Do(req, page.Interface())
+		if err != nil {
+			return resp, err
+		}
+		items.Set(reflect.AppendSlice(items, page.Elem()))
+		if resp.NextURL == nil || (!allPages && pages >= numberOfPages) {
+			return resp, err
+		}
+		u = resp.NextURL.String()
+	}
+}
+
+// do (unexported) sends a request to the API and decodes the response into v
+func (c *Client) do(method string, u string, body interface{}, v interface{}) (*Response, error) {
+	req, err := c.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(req, v)
+}
+
 type dateFilter struct {
 	Value    time.Time
 	Operator string
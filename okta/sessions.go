@@ -0,0 +1,161 @@
+package okta
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// SessionCookieName is the name of the cookie holding the OKTA session ID
+	SessionCookieName = "sid"
+
+	// SessionStatusActive - the session is established and active
+	SessionStatusActive = "ACTIVE"
+	// SessionStatusMFARequired - the user must verify a factor before the session is established
+	SessionStatusMFARequired = "MFA_REQUIRED"
+	// SessionStatusMFAEnroll - the user must enroll a factor before the session is established
+	SessionStatusMFAEnroll = "MFA_ENROLL"
+)
+
+// SessionsService handles communication with the Sessions related
+// methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/sessions/
+type SessionsService service
+
+// Session is an OKTA session of a user
+type Session struct {
+	ID                       string        `json:"id,omitempty"`
+	Login                    string        `json:"login,omitempty"`
+	UserID                   string        `json:"userId,omitempty"`
+	Status                   string        `json:"status,omitempty"`
+	CreatedAt                *time.Time    `json:"createdAt,omitempty"`
+	ExpiresAt                *time.Time    `json:"expiresAt,omitempty"`
+	LastPasswordVerification *time.Time    `json:"lastPasswordVerification,omitempty"`
+	LastFactorVerification   *time.Time    `json:"lastFactorVerification,omitempty"`
+	AMR                      []string      `json:"amr,omitempty"`
+	IDP                      *SessionIDP   `json:"idp,omitempty"`
+	MFAActive                bool          `json:"mfaActive,omitempty"`
+	Links                    *SessionLinks `json:"_links,omitempty"`
+}
+
+// SessionIDP is the identity provider that authenticated the user of a session
+type SessionIDP struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// SessionLinks are the links of a session
+type SessionLinks struct {
+	Self    *FactorLink `json:"self,omitempty"`
+	Refresh *FactorLink `json:"refresh,omitempty"`
+	User    *FactorLink `json:"user,omitempty"`
+}
+
+// Cookie returns the sid cookie for the session, to set on a response of a page in the
+// OKTA org domain. Use SessionCookieRedirectURL for applications on another domain
+func (s *Session) Cookie(domain string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    s.ID,
+		Domain:   domain,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+	}
+	if s.ExpiresAt != nil {
+		cookie.Expires = *s.ExpiresAt
+	}
+	return cookie
+}
+
+// Create: Create a session from the sessionToken of a successful authentication transaction.
+// The sessionToken can only be used once
+func (p *SessionsService) Create(sessionToken string) (*Session, *Response, error) {
+	if sessionToken == "" {
+		return nil, nil, errors.New("[ERROR] Sessions.Create requires a sessionToken")
+	}
+	return p.do("POST", "sessions", map[string]string{"sessionToken": sessionToken})
+}
+
+// Get: Get an active session
+// Requires Session ID from Session object
+func (p *SessionsService) Get(sessionID string) (*Session, *Response, error) {
+	u := fmt.Sprintf("sessions/%v", sessionID)
+	return p.do("GET", u, nil)
+}
+
+// Refresh: Extend the lifetime of a session
+// Requires Session ID from Session object
+func (p *SessionsService) Refresh(sessionID string) (*Session, *Response, error) {
+	u := fmt.Sprintf("sessions/%v/lifecycle/refresh", sessionID)
+	return p.do("POST", u, nil)
+}
+
+// Close: Close a session
+// Requires Session ID from Session object
+func (p *SessionsService) Close(sessionID string) (*Response, error) {
+	u := fmt.Sprintf("sessions/%v", sessionID)
+
+	req, err := p.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// CloseUserSessions: Close all sessions of a user, as done when offboarding. If oauthTokens is true
+// the OpenID Connect and OAuth refresh and access tokens issued to the user are revoked too
+// Requires User ID from User object
+func (p *SessionsService) CloseUserSessions(userID string, oauthTokens bool) (*Response, error) {
+	u := fmt.Sprintf("users/%v/sessions", userID)
+	if oauthTokens {
+		u += "?oauthTokens=true"
+	}
+
+	req, err := p.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// SessionCookieRedirectURL returns the URL that sets the sid cookie in the OKTA org domain for a sessionToken
+// and then redirects the browser to redirectURL. redirectURL must be a trusted origin of the org
+func (p *SessionsService) SessionCookieRedirectURL(sessionToken string, redirectURL string) string {
+	u := p.client.BaseURL.ResolveReference(&url.URL{Path: "/login/sessionCookieRedirect"})
+	q := url.Values{}
+	q.Set("token", sessionToken)
+	q.Set("redirectUrl", redirectURL)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (p *SessionsService) do(method string, u string, body interface{}) (*Session, *Response, error) {
+	req, err := p.client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session := new(Session)
+	resp, err := p.client.Do(req, session)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return session, resp, err
+}
@@ -0,0 +1,156 @@
+package okta
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSessionJSONString = `
+{
+    "id": "101W_juydrDRByB7fUdRyE2JQ",
+    "login": "dade.murphy@example.com",
+    "userId": "00ubgaSARVOQDIOXMORI",
+    "expiresAt": "2015-08-30T18:41:35.818Z",
+    "status": "ACTIVE",
+    "lastPasswordVerification": "2015-08-30T18:21:35.818Z",
+    "lastFactorVerification": null,
+    "amr": ["pwd"],
+    "idp": {
+        "id": "00oi5cpnylv792IcF0g3",
+        "type": "OKTA"
+    },
+    "mfaActive": false,
+    "_links": {
+        "self": {
+            "href": "https://your-domain.okta.com/api/v1/sessions/101W_juydrDRByB7fUdRyE2JQ",
+            "hints": {"allow": ["GET", "DELETE"]}
+        },
+        "refresh": {
+            "href": "https://your-domain.okta.com/api/v1/sessions/101W_juydrDRByB7fUdRyE2JQ/lifecycle/refresh",
+            "hints": {"allow": ["POST"]}
+        }
+    }
+}
+`
+
+func TestSessionsCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, map[string]string{"sessionToken": "00HiohZYJIOhJ4ubw3cqdZgNXl0iN6i7Jf5u1MaBwY"})
+		fmt.Fprint(w, testSessionJSONString)
+	})
+
+	session, _, err := client.Sessions.Create("00HiohZYJIOhJ4ubw3cqdZgNXl0iN6i7Jf5u1MaBwY")
+	if err != nil {
+		t.Fatalf("Sessions.Create returned error: %v", err)
+	}
+
+	expiresAt := time.Date(2015, 8, 30, 18, 41, 35, 818000000, time.UTC)
+	lastPasswordVerification := time.Date(2015, 8, 30, 18, 21, 35, 818000000, time.UTC)
+	want := &Session{
+		ID:                       "101W_juydrDRByB7fUdRyE2JQ",
+		Login:                    "dade.murphy@example.com",
+		UserID:                   "00ubgaSARVOQDIOXMORI",
+		Status:                   SessionStatusActive,
+		ExpiresAt:                &expiresAt,
+		LastPasswordVerification: &lastPasswordVerification,
+		AMR:                      []string{"pwd"},
+		IDP:                      &SessionIDP{ID: "00oi5cpnylv792IcF0g3", Type: "OKTA"},
+	}
+	links := session.Links
+	session.Links = nil
+	if !reflect.DeepEqual(session, want) {
+		t.Errorf("client.Sessions.Create returned \n\t%+v, want \n\t%+v\n", session, want)
+	}
+	if links == nil || links.Refresh == nil || links.Refresh.Hints.Allow[0] != "POST" {
+		t.Errorf("client.Sessions.Create returned links %+v", links)
+	}
+
+	if _, _, err := client.Sessions.Create(""); err == nil {
+		t.Errorf("Sessions.Create expected an error without a sessionToken")
+	}
+}
+
+func TestSessionsGetAndRefresh(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/sessions/101W_juydrDRByB7fUdRyE2JQ", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, testSessionJSONString)
+	})
+	mux.HandleFunc("/sessions/101W_juydrDRByB7fUdRyE2JQ/lifecycle/refresh", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `{"id":"101W_juydrDRByB7fUdRyE2JQ","status":"ACTIVE","expiresAt":"2015-08-30T19:41:35.818Z"}`)
+	})
+
+	session, _, err := client.Sessions.Get("101W_juydrDRByB7fUdRyE2JQ")
+	if err != nil {
+		t.Errorf("Sessions.Get returned error: %v", err)
+	}
+	refreshed, _, err := client.Sessions.Refresh(session.ID)
+	if err != nil {
+		t.Errorf("Sessions.Refresh returned error: %v", err)
+	}
+	if !refreshed.ExpiresAt.After(*session.ExpiresAt) {
+		t.Errorf("client.Sessions.Refresh returned expiresAt %v, want after %v", refreshed.ExpiresAt, session.ExpiresAt)
+	}
+}
+
+func TestSessionsClose(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/sessions/101W_juydrDRByB7fUdRyE2JQ", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/users/00ubgaSARVOQDIOXMORI/sessions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		if got := r.URL.Query().Get("oauthTokens"); got != "true" {
+			t.Errorf("Sessions.CloseUserSessions sent oauthTokens=%v, want true", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Sessions.Close("101W_juydrDRByB7fUdRyE2JQ"); err != nil {
+		t.Errorf("Sessions.Close returned error: %v", err)
+	}
+	if _, err := client.Sessions.CloseUserSessions("00ubgaSARVOQDIOXMORI", true); err != nil {
+		t.Errorf("Sessions.CloseUserSessions returned error: %v", err)
+	}
+}
+
+func TestSessionCookies(t *testing.T) {
+	client := NewClient(nil, testServerOrg, testToken, true)
+
+	expiresAt := time.Date(2015, 8, 30, 18, 41, 35, 0, time.UTC)
+	session := &Session{ID: "101W_juydrDRByB7fUdRyE2JQ", ExpiresAt: &expiresAt}
+	cookie := session.Cookie("test-org.okta.com")
+	if cookie.Name != "sid" || cookie.Value != session.ID || !cookie.Expires.Equal(expiresAt) || !cookie.Secure || !cookie.HttpOnly {
+		t.Errorf("Session.Cookie returned %+v", cookie)
+	}
+
+	redirect, err := url.Parse(client.Sessions.SessionCookieRedirectURL("00HiohZYJIOhJ4ubw3cqdZgNXl0iN6i7Jf5u1MaBwY", "https://example.com/app?x=1"))
+	if err != nil {
+		t.Fatalf("Sessions.SessionCookieRedirectURL returned an invalid URL: %v", err)
+	}
+	if redirect.Host != "test-org.okta.com" || redirect.Path != "/login/sessionCookieRedirect" {
+		t.Errorf("Sessions.SessionCookieRedirectURL returned %v", redirect)
+	}
+	if q := redirect.Query(); q.Get("token") != "00HiohZYJIOhJ4ubw3cqdZgNXl0iN6i7Jf5u1MaBwY" || q.Get("redirectUrl") != "https://example.com/app?x=1" {
+		t.Errorf("Sessions.SessionCookieRedirectURL returned query %v", q)
+	}
+}
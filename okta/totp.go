@@ -0,0 +1,209 @@
+package okta
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPAlgorithmSHA1 is the HMAC algorithm used by Okta Verify and Google Authenticator
+	TOTPAlgorithmSHA1 = "SHA1"
+	// TOTPAlgorithmSHA256 - HMAC-SHA256 as allowed by RFC 6238
+	TOTPAlgorithmSHA256 = "SHA256"
+	// TOTPAlgorithmSHA512 - HMAC-SHA512 as allowed by RFC 6238
+	TOTPAlgorithmSHA512 = "SHA512"
+
+	defaultTOTPDigits = 6
+	defaultTOTPPeriod = 30 * time.Second
+)
+
+// TOTP generates and validates RFC 6238 time based one time passwords. It is meant for
+// automated test users and service accounts enrolled in a token:software:totp factor, so
+// codes can be computed locally from the shared secret returned on enrollment
+type TOTP struct {
+	// Secret is the decoded shared secret
+	Secret []byte
+	// Digits is the length of the generated codes. Defaults to 6
+	Digits int
+	// Period is the time step. Defaults to 30 seconds
+	Period time.Duration
+	// Algorithm is one of TOTPAlgorithmSHA1, TOTPAlgorithmSHA256 or TOTPAlgorithmSHA512. Defaults to SHA1
+	Algorithm string
+}
+
+// NewTOTP returns a TOTP with the default settings (6 digits, 30 seconds, SHA1) for a base32 encoded secret
+func NewTOTP(secret string) (*TOTP, error) {
+	key, err := decodeTOTPSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &TOTP{
+		Secret:    key,
+		Digits:    defaultTOTPDigits,
+		Period:    defaultTOTPPeriod,
+		Algorithm: TOTPAlgorithmSHA1,
+	}, nil
+}
+
+// NewTOTPFromActivation returns a TOTP for the activation data of a token:software:totp enrollment,
+// as found in UserFactor.Embedded.Activation
+func NewTOTPFromActivation(activation *FactorActivation) (*TOTP, error) {
+	if activation == nil || activation.SharedSecret == "" {
+		return nil, errors.New("[ERROR] NewTOTPFromActivation activation has no shared secret")
+	}
+	if activation.Encoding != "" && !strings.EqualFold(activation.Encoding, "base32") {
+		return nil, fmt.Errorf("[ERROR] NewTOTPFromActivation shared secret encoding %v is not supported", activation.Encoding)
+	}
+	totp, err := NewTOTP(activation.SharedSecret)
+	if err != nil {
+		return nil, err
+	}
+	if activation.TimeStep > 0 {
+		totp.Period = time.Duration(activation.TimeStep) * time.Second
+	}
+	return totp, nil
+}
+
+// decodeTOTPSecret decodes a base32 secret, ignoring case, spaces and padding
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Replace(secret, " ", "", -1))
+	secret = strings.TrimRight(secret, "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] TOTP secret is not valid base32: %v", err)
+	}
+	if len(key) == 0 {
+		return nil, errors.New("[ERROR] TOTP secret is empty")
+	}
+	return key, nil
+}
+
+// Generate returns the code for the time step containing t
+func (t *TOTP) Generate(at time.Time) (string, error) {
+	counter, err := t.counter(at)
+	if err != nil {
+		return "", err
+	}
+	return t.generate(counter)
+}
+
+// Now returns the code for the current time step
+func (t *TOTP) Now() (string, error) {
+	return t.Generate(time.Now())
+}
+
+// Validate reports whether code is valid at time at, accepting codes from up to skew
+// time steps before or after to allow for clock drift
+func (t *TOTP) Validate(code string, at time.Time, skew int) bool {
+	counter, err := t.counter(at)
+	if err != nil {
+		return false
+	}
+	for i := -skew; i <= skew; i++ {
+		if int64(counter)+int64(i) < 0 {
+			continue
+		}
+		want, err := t.generate(uint64(int64(counter) + int64(i)))
+		if err != nil {
+			return false
+		}
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// counter (unexported) returns the time step containing at. Periods shorter than 1s are refused
+func (t *TOTP) counter(at time.Time) (uint64, error) {
+	period := t.Period
+	if period <= 0 {
+		period = defaultTOTPPeriod
+	}
+	if period < time.Second {
+		return 0, fmt.Errorf("[ERROR] TOTP period %v is shorter than 1s", period)
+	}
+	return uint64(at.Unix() / int64(period/time.Second)), nil
+}
+
+// generate implements the HOTP algorithm of RFC 4226 for a counter value
+func (t *TOTP) generate(counter uint64) (string, error) {
+	var h func() hash.Hash
+	switch strings.ToUpper(t.Algorithm) {
+	case TOTPAlgorithmSHA1, "":
+		h = sha1.New
+	case TOTPAlgorithmSHA256:
+		h = sha256.New
+	case TOTPAlgorithmSHA512:
+		h = sha512.New
+	default:
+		return "", fmt.Errorf("[ERROR] TOTP algorithm %v is not supported", t.Algorithm)
+	}
+	digits := t.Digits
+	if digits <= 0 {
+		digits = defaultTOTPDigits
+	}
+	if digits > 10 {
+		return "", fmt.Errorf("[ERROR] TOTP digits %v is not supported", digits)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+	mac := hmac.New(h, t.Secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := int64(binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff)
+
+	mod := int64(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod), nil
+}
+
+// ActivateTOTP activates a token:software:totp factor returned by Enroll, using a code computed
+// locally from the shared secret in its activation data. The returned TOTP can be kept to verify
+// the factor later with VerifyTOTP. Okta rejects a code that was already used, so wait for the next
+// time step before verifying with the same TOTP
+// Requires User ID from User object
+func (p *UserFactorsService) ActivateTOTP(userID string, factor *UserFactor) (*UserFactor, *TOTP, *Response, error) {
+	if factor == nil || factor.FactorType != FactorTypeTOTP {
+		return nil, nil, nil, errors.New("[ERROR] UserFactors.ActivateTOTP requires a token:software:totp factor")
+	}
+	if factor.Embedded == nil {
+		return nil, nil, nil, errors.New("[ERROR] UserFactors.ActivateTOTP factor has no activation data")
+	}
+	totp, err := NewTOTPFromActivation(factor.Embedded.Activation)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	code, err := totp.Now()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	activated, resp, err := p.Activate(userID, factor.ID, FactorActivationRequest{PassCode: code})
+	return activated, totp, resp, err
+}
+
+// VerifyTOTP verifies a token:software:totp factor with the current code of totp
+// Requires User ID from User object and Factor ID from UserFactor object
+func (p *UserFactorsService) VerifyTOTP(userID string, factorID string, totp *TOTP) (*FactorVerification, *Response, error) {
+	code, err := totp.Now()
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.Verify(userID, factorID, FactorVerifyRequest{PassCode: code})
+}
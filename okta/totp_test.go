@@ -0,0 +1,165 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// RFC 6238 Appendix B test vectors
+func TestTOTPGenerateRFC6238(t *testing.T) {
+	secrets := map[string][]byte{
+		TOTPAlgorithmSHA1:   []byte("12345678901234567890"),
+		TOTPAlgorithmSHA256: []byte("12345678901234567890123456789012"),
+		TOTPAlgorithmSHA512: []byte("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+	vectors := []struct {
+		unix      int64
+		algorithm string
+		want      string
+	}{
+		{59, TOTPAlgorithmSHA1, "94287082"},
+		{59, TOTPAlgorithmSHA256, "46119246"},
+		{59, TOTPAlgorithmSHA512, "90693936"},
+		{1111111109, TOTPAlgorithmSHA1, "07081804"},
+		{1111111109, TOTPAlgorithmSHA256, "68084774"},
+		{1111111109, TOTPAlgorithmSHA512, "25091201"},
+		{1234567890, TOTPAlgorithmSHA1, "89005924"},
+		{1234567890, TOTPAlgorithmSHA256, "91819424"},
+		{1234567890, TOTPAlgorithmSHA512, "93441116"},
+		{2000000000, TOTPAlgorithmSHA1, "69279037"},
+		{2000000000, TOTPAlgorithmSHA256, "90698825"},
+		{2000000000, TOTPAlgorithmSHA512, "38618901"},
+		{20000000000, TOTPAlgorithmSHA1, "65353130"},
+		{20000000000, TOTPAlgorithmSHA256, "77737706"},
+		{20000000000, TOTPAlgorithmSHA512, "47863826"},
+	}
+
+	for _, v := range vectors {
+		totp := &TOTP{Secret: secrets[v.algorithm], Digits: 8, Period: 30 * time.Second, Algorithm: v.algorithm}
+		got, err := totp.Generate(time.Unix(v.unix, 0))
+		if err != nil {
+			t.Errorf("TOTP.Generate returned error: %v", err)
+		}
+		if got != v.want {
+			t.Errorf("TOTP.Generate(%v, %v) returned %v, want %v", v.unix, v.algorithm, got, v.want)
+		}
+	}
+}
+
+func TestTOTPValidateSkew(t *testing.T) {
+	totp, err := NewTOTP("jbsw y3dp ehpk 3pxp")
+	if err != nil {
+		t.Fatalf("NewTOTP returned error: %v", err)
+	}
+	at := time.Unix(1500000000, 0)
+	code, _ := totp.Generate(at.Add(-30 * time.Second))
+
+	if !totp.Validate(code, at, 1) {
+		t.Errorf("TOTP.Validate rejected a code from the previous time step with a skew of 1")
+	}
+	if totp.Validate(code, at, 0) {
+		t.Errorf("TOTP.Validate accepted a code from the previous time step with a skew of 0")
+	}
+	if len(code) != 6 {
+		t.Errorf("TOTP.Generate returned %v, want 6 digits", code)
+	}
+}
+
+func TestTOTPShortPeriod(t *testing.T) {
+	totp, err := NewTOTP("jbsw y3dp ehpk 3pxp")
+	if err != nil {
+		t.Fatalf("NewTOTP returned error: %v", err)
+	}
+	totp.Period = 500 * time.Millisecond
+	at := time.Unix(1500000000, 0)
+
+	if code, err := totp.Generate(at); err == nil {
+		t.Errorf("TOTP.Generate with a period of 500ms returned %v, want an error", code)
+	}
+	if totp.Validate("123456", at, 1) {
+		t.Errorf("TOTP.Validate with a period of 500ms accepted a code")
+	}
+}
+
+func TestNewTOTPFromActivation(t *testing.T) {
+	totp, err := NewTOTPFromActivation(&FactorActivation{SharedSecret: "JBSWY3DPEHPK3PXP", Encoding: "base32", TimeStep: 60})
+	if err != nil {
+		t.Fatalf("NewTOTPFromActivation returned error: %v", err)
+	}
+	if totp.Period != time.Minute || totp.Digits != 6 || string(totp.Secret) != "Hello!\xde\xad\xbe\xef" {
+		t.Errorf("NewTOTPFromActivation returned %+v", totp)
+	}
+
+	if _, err := NewTOTPFromActivation(&FactorActivation{SharedSecret: "not base32!"}); err == nil {
+		t.Errorf("NewTOTPFromActivation expected an error for an invalid secret")
+	}
+	if _, err := NewTOTPFromActivation(nil); err == nil {
+		t.Errorf("NewTOTPFromActivation expected an error for missing activation data")
+	}
+}
+
+func TestUserFactorsActivateAndVerifyTOTP(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// the fake server validates codes with its own copy of the secret
+	serverTOTP, _ := NewTOTP("JBSWY3DPEHPK3PXP")
+	checkPassCode := func(w http.ResponseWriter, r *http.Request) bool {
+		var body struct {
+			PassCode string `json:"passCode"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if !serverTOTP.Validate(body.PassCode, time.Now(), 1) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errorCode":"E0000068","errorSummary":"Invalid Passcode/Answer"}`)
+			return false
+		}
+		return true
+	}
+
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, testTOTPEnrollJSONString)
+	})
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors/ostf1fmaMGJLMNGNLIVG/lifecycle/activate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		if checkPassCode(w, r) {
+			fmt.Fprint(w, `{"id":"ostf1fmaMGJLMNGNLIVG","factorType":"token:software:totp","provider":"OKTA","status":"ACTIVE"}`)
+		}
+	})
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors/ostf1fmaMGJLMNGNLIVG/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		if checkPassCode(w, r) {
+			fmt.Fprint(w, `{"factorResult":"SUCCESS"}`)
+		}
+	})
+
+	enrolled, _, err := client.UserFactors.Enroll("00u15s1KDETTQMQYABRL", UserFactor{FactorType: FactorTypeTOTP, Provider: FactorProviderOkta}, nil)
+	if err != nil {
+		t.Fatalf("UserFactors.Enroll returned error: %v", err)
+	}
+	factor, totp, _, err := client.UserFactors.ActivateTOTP("00u15s1KDETTQMQYABRL", enrolled)
+	if err != nil {
+		t.Fatalf("UserFactors.ActivateTOTP returned error: %v", err)
+	}
+	if factor.Status != MFAStatusActive {
+		t.Errorf("client.UserFactors.ActivateTOTP returned status %v, want %v", factor.Status, MFAStatusActive)
+	}
+
+	verification, _, err := client.UserFactors.VerifyTOTP("00u15s1KDETTQMQYABRL", factor.ID, totp)
+	if err != nil {
+		t.Fatalf("UserFactors.VerifyTOTP returned error: %v", err)
+	}
+	if verification.FactorResult != FactorResultSuccess {
+		t.Errorf("client.UserFactors.VerifyTOTP returned %v, want %v", verification.FactorResult, FactorResultSuccess)
+	}
+
+	if _, _, _, err := client.UserFactors.ActivateTOTP("00u15s1KDETTQMQYABRL", &UserFactor{FactorType: FactorTypeSMS}); err == nil {
+		t.Errorf("UserFactors.ActivateTOTP expected an error for a non totp factor")
+	}
+}
@@ -20,7 +20,7 @@ type TrustedOrigin struct {
 	Created       *time.Time          `json:"created,omitempty"`
 	CreatedBy     string              `json:"createdBy,omitempty"`
 	LastUpdated   *time.Time          `json:"lastUpdated,omitempty"`
-	LastUpdatedBy string              `json:"lastUpdated,omitempty"`
+	LastUpdatedBy string              `json:"lastUpdatedBy,omitempty"`
 	Links         *TrustedOriginLinks `json:"_links,omitempty"`
 }
 
@@ -0,0 +1,337 @@
+package okta
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	// UserExportFormatCSV writes one header row followed by one row per user
+	UserExportFormatCSV = "csv"
+	// UserExportFormatJSONL writes one JSON object per line per user
+	UserExportFormatJSONL = "jsonl"
+
+	// export columns that require an extra API call per user
+	userExportGroupsColumn  = "groups"
+	userExportFactorsColumn = "factors"
+	userExportAppsColumn    = "apps"
+
+	userExportProfilePrefix = "profile."
+
+	// value separator used when a multi-valued column is written to CSV
+	userExportCSVListSeparator = ";"
+
+	defaultUserExportWorkers = 4
+)
+
+// DefaultUserExportColumns are the columns written when UserExportOptions.Columns is empty
+var DefaultUserExportColumns = []string{"id", "status", "lastLogin", "profile.login", "profile.email"}
+
+// UserExportOptions controls what Users.Export writes.
+// Columns is the projection written for every user. Supported values are the top level user
+// attributes (id, status, created, activated, statusChanged, lastLogin, lastUpdated, passwordChanged),
+// any "profile.<attribute>" including custom profile attributes, and the enrichment columns
+// "groups", "factors" and "apps". Enrichment columns cost one API call per user each and are
+// fetched using up to Workers concurrent requests.
+type UserExportOptions struct {
+	Format  string
+	Columns []string
+	Workers int
+
+	// Filter is used to select the users to export. If nil all users are exported
+	Filter *UserListFilterOptions
+}
+
+// exportUser holds a user along with its raw profile (so custom attributes are not lost)
+// and any enrichment data fetched for the export
+type exportUser struct {
+	user    User
+	profile map[string]interface{}
+	apps    []App
+}
+
+// Export streams every user matching opt.Filter through the list users endpoint and writes
+// the selected columns to w as CSV or JSON Lines. Pages are written as they are retrieved so
+// the full user list is never held in memory.
+// Returns the number of users written.
+func (s *UsersService) Export(w io.Writer, opt *UserExportOptions) (int, error) {
+	if opt == nil {
+		opt = &UserExportOptions{}
+	}
+	columns := opt.Columns
+	if len(columns) == 0 {
+		columns = DefaultUserExportColumns
+	}
+	for _, col := range columns {
+		if !validUserExportColumn(col) {
+			return 0, fmt.Errorf("[ERROR] Users.Export column %v is not supported", col)
+		}
+	}
+
+	var writeRow func([]string, *exportUser) error
+	var flush func() error
+	switch opt.Format {
+	case UserExportFormatCSV, "":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return 0, err
+		}
+		writeRow = func(cols []string, user *exportUser) error {
+			return cw.Write(user.csvRow(cols))
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case UserExportFormatJSONL:
+		enc := json.NewEncoder(w)
+		writeRow = func(cols []string, user *exportUser) error {
+			return enc.Encode(user.jsonRow(cols))
+		}
+		flush = func() error { return nil }
+	default:
+		return 0, fmt.Errorf("[ERROR] Users.Export format supports values %q or %q", UserExportFormatCSV, UserExportFormatJSONL)
+	}
+
+	filter := opt.Filter
+	if filter == nil {
+		filter = &UserListFilterOptions{}
+	}
+	u, err := userListURL(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	workers := opt.Workers
+	if workers <= 0 {
+		workers = defaultUserExportWorkers
+	}
+
+	count := 0
+	for u != "" {
+		page, resp, err := s.exportPage(u)
+		if err != nil {
+			return count, err
+		}
+		if err := s.enrichExportPage(page, columns, workers); err != nil {
+			return count, err
+		}
+		for _, user := range page {
+			if err := writeRow(columns, user); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if err := flush(); err != nil {
+			return count, err
+		}
+
+		u = ""
+		if resp.NextURL != nil {
+			u = resp.NextURL.String()
+		}
+	}
+
+	return count, nil
+}
+
+// exportPage retrieves one page of users keeping the raw profile of each user
+func (s *UsersService) exportPage(u string) ([]*exportUser, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var raw []json.RawMessage
+	resp, err := s.client.Do(req, &raw)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	page := make([]*exportUser, 0, len(raw))
+	for _, r := range raw {
+		user := new(exportUser)
+		if err := json.Unmarshal(r, &user.user); err != nil {
+			return nil, resp, err
+		}
+		var profile struct {
+			Profile map[string]interface{} `json:"profile"`
+		}
+		if err := json.Unmarshal(r, &profile); err != nil {
+			return nil, resp, err
+		}
+		user.profile = profile.Profile
+		page = append(page, user)
+	}
+	return page, resp, nil
+}
+
+// enrichExportPage populates the groups, factors and app assignments required by columns
+// using a pool of workers. The first error encountered is returned
+func (s *UsersService) enrichExportPage(page []*exportUser, columns []string, workers int) error {
+	var groups, factors, apps bool
+	for _, col := range columns {
+		switch col {
+		case userExportGroupsColumn:
+			groups = true
+		case userExportFactorsColumn:
+			factors = true
+		case userExportAppsColumn:
+			apps = true
+		}
+	}
+	if !groups && !factors && !apps {
+		return nil
+	}
+
+	jobs := make(chan *exportUser)
+	errs := make(chan error, len(page))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for user := range jobs {
+				if groups {
+					u := fmt.Sprintf("users/%v/groups", user.user.ID)
+					opt := &struct {
+						Limit int `url:"limit"`
+					}{Limit: 200}
+					user.user.Groups = nil
+					if _, err := s.client.listPages(u, opt, nil, true, 0, &user.user.Groups); err != nil {
+						errs <- err
+						continue
+					}
+				}
+				if factors {
+					u := fmt.Sprintf("users/%v/factors", user.user.ID)
+					user.user.MFAFactors = nil
+					if _, err := s.client.listPages(u, nil, nil, true, 0, &user.user.MFAFactors); err != nil {
+						errs <- err
+						continue
+					}
+				}
+				if apps {
+					userApps, _, err := s.listAssignedApps(user.user.ID)
+					if err != nil {
+						errs <- err
+						continue
+					}
+					user.apps = userApps
+				}
+			}
+		}()
+	}
+	for _, user := range page {
+		jobs <- user
+	}
+	close(jobs)
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// listAssignedApps returns the apps a user is assigned to, from every page
+// http://developer.okta.com/docs/api/resources/apps.html#list-applications-assigned-to-a-user
+func (s *UsersService) listAssignedApps(id string) ([]App, *Response, error) {
+	opt := &struct {
+		Filter string `url:"filter"`
+		Limit  int    `url:"limit"`
+	}{
+		Filter: appendToFilterString("", "user.id", FilterEqualOperator, id),
+		Limit:  200,
+	}
+	var apps []App
+	resp, err := s.client.listPages("apps", opt, nil, true, 0, &apps)
+	if err != nil {
+		return nil, resp, err
+	}
+	return apps, resp, err
+}
+
+func validUserExportColumn(col string) bool {
+	switch col {
+	case "id", "status", "created", "activated", "statusChanged", "lastLogin", "lastUpdated", "passwordChanged",
+		userExportGroupsColumn, userExportFactorsColumn, userExportAppsColumn:
+		return true
+	}
+	return strings.HasPrefix(col, userExportProfilePrefix) && len(col) > len(userExportProfilePrefix)
+}
+
+// value returns the value of a single export column for the user
+func (u *exportUser) value(col string) interface{} {
+	switch col {
+	case "id":
+		return u.user.ID
+	case "status":
+		return u.user.Status
+	case "created":
+		return u.user.Created
+	case "activated":
+		return u.user.Activated
+	case "statusChanged":
+		return u.user.StatusChanged
+	case "lastLogin":
+		return u.user.LastLogin
+	case "lastUpdated":
+		return u.user.LastUpdated
+	case "passwordChanged":
+		return u.user.PasswordChanged
+	case userExportGroupsColumn:
+		names := make([]string, 0, len(u.user.Groups))
+		for _, g := range u.user.Groups {
+			if g.GroupProfile != nil {
+				names = append(names, g.GroupProfile.Name)
+			}
+		}
+		return names
+	case userExportFactorsColumn:
+		factors := make([]string, 0, len(u.user.MFAFactors))
+		for _, f := range u.user.MFAFactors {
+			factors = append(factors, f.FactorType)
+		}
+		return factors
+	case userExportAppsColumn:
+		apps := make([]string, 0, len(u.apps))
+		for _, a := range u.apps {
+			apps = append(apps, a.Label)
+		}
+		return apps
+	}
+	return u.profile[strings.TrimPrefix(col, userExportProfilePrefix)]
+}
+
+func (u *exportUser) csvRow(columns []string) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		switch v := u.value(col).(type) {
+		case nil:
+			row[i] = ""
+		case string:
+			row[i] = v
+		case []string:
+			row[i] = strings.Join(v, userExportCSVListSeparator)
+		case []interface{}:
+			vals := make([]string, len(v))
+			for j := range v {
+				vals[j] = fmt.Sprint(v[j])
+			}
+			row[i] = strings.Join(vals, userExportCSVListSeparator)
+		default:
+			row[i] = fmt.Sprint(v)
+		}
+	}
+	return row
+}
+
+func (u *exportUser) jsonRow(columns []string) map[string]interface{} {
+	row := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		row[col] = u.value(col)
+	}
+	return row
+}
@@ -0,0 +1,173 @@
+package okta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func setupTestUserExport(t *testing.T) {
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Add("Link", fmt.Sprintf(`<%v/users?after=00u2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id":"00u1","status":"ACTIVE","lastLogin":"2018-02-16T19:59:05.000Z","profile":{"login":"isaac.brock@example.com","email":"isaac.brock@example.com","costCenterCode":"CC-10"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":"00u2","status":"SUSPENDED","profile":{"login":"kent.brockman@example.com","email":"kent.brockman@example.com"}}]`)
+	})
+	mux.HandleFunc("/users/00u1/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"00g1","profile":{"name":"Everyone"}},{"id":"00g2","profile":{"name":"Engineering"}}]`)
+	})
+	mux.HandleFunc("/users/00u2/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"00g1","profile":{"name":"Everyone"}}]`)
+	})
+}
+
+func TestUserExportCSV(t *testing.T) {
+	setup()
+	defer teardown()
+	setupTestUserExport(t)
+
+	var buf bytes.Buffer
+	count, err := client.Users.Export(&buf, &UserExportOptions{
+		Format:  UserExportFormatCSV,
+		Columns: []string{"id", "status", "profile.login", "profile.costCenterCode", "groups"},
+		Workers: 2,
+	})
+	if err != nil {
+		t.Errorf("Users.Export returned error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Users.Export returned count %v, want 2", count)
+	}
+
+	want := strings.Join([]string{
+		"id,status,profile.login,profile.costCenterCode,groups",
+		"00u1,ACTIVE,isaac.brock@example.com,CC-10,Everyone;Engineering",
+		"00u2,SUSPENDED,kent.brockman@example.com,,Everyone",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("Users.Export returned \n\t%v, want \n\t%v\n", buf.String(), want)
+	}
+}
+
+func TestUserExportJSONL(t *testing.T) {
+	setup()
+	defer teardown()
+	setupTestUserExport(t)
+
+	var buf bytes.Buffer
+	_, err := client.Users.Export(&buf, &UserExportOptions{
+		Format:  UserExportFormatJSONL,
+		Columns: []string{"id", "lastLogin", "groups"},
+	})
+	if err != nil {
+		t.Errorf("Users.Export returned error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Users.Export returned %v lines, want 2", len(lines))
+	}
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &row); err != nil {
+		t.Errorf("Users.Export json Unmarshal returned error: %v", err)
+	}
+	want := map[string]interface{}{
+		"id":        "00u1",
+		"lastLogin": "2018-02-16T19:59:05.000Z",
+		"groups":    []interface{}{"Everyone", "Engineering"},
+	}
+	if !reflect.DeepEqual(row, want) {
+		t.Errorf("Users.Export returned \n\t%+v, want \n\t%+v\n", row, want)
+	}
+}
+
+func TestUserExportInvalidColumn(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	if _, err := client.Users.Export(&buf, &UserExportOptions{Columns: []string{"nope"}}); err == nil {
+		t.Errorf("Users.Export expected an error for an unsupported column")
+	}
+}
+
+func TestUserExportAppsPaged(t *testing.T) {
+	setup()
+	defer teardown()
+	setupTestUserExport(t)
+
+	mux.HandleFunc("/apps", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		filter := r.URL.Query().Get("filter")
+		switch {
+		case filter == `user.id eq "00u2"`:
+			fmt.Fprint(w, `[]`)
+		case r.URL.Query().Get("after") == "":
+			w.Header().Add("Link", fmt.Sprintf(`<%v/apps?after=0oa2&filter=%v&limit=200>; rel="next"`, server.URL, url.QueryEscape(filter)))
+			fmt.Fprint(w, `[{"id":"0oa1","label":"Salesforce"}]`)
+		default:
+			fmt.Fprint(w, `[{"id":"0oa2","label":"Workday"}]`)
+		}
+	})
+
+	var buf bytes.Buffer
+	if _, err := client.Users.Export(&buf, &UserExportOptions{Columns: []string{"id", "apps"}}); err != nil {
+		t.Errorf("Users.Export returned error: %v", err)
+	}
+	want := "id,apps\n00u1,Salesforce;Workday\n00u2,\n"
+	if buf.String() != want {
+		t.Errorf("Users.Export returned \n\t%v, want \n\t%v\n", buf.String(), want)
+	}
+}
+
+func TestUserExportGroupsAndFactorsPaged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id":"00u1","status":"ACTIVE","profile":{"login":"isaac.brock@example.com"}}]`)
+	})
+	mux.HandleFunc("/users/00u1/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("limit"); got != "200" {
+			t.Errorf("Users.Export sent groups limit %v, want 200", got)
+		}
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Add("Link", fmt.Sprintf(`<%v/users/00u1/groups?after=00g1&limit=200>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id":"00g1","profile":{"name":"Everyone"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":"00g2","profile":{"name":"Engineering"}}]`)
+	})
+	mux.HandleFunc("/users/00u1/factors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("after") == "" {
+			w.Header().Add("Link", fmt.Sprintf(`<%v/users/00u1/factors?after=ufs1>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id":"ufs1","factorType":"push"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":"ufs2","factorType":"sms"}]`)
+	})
+
+	var buf bytes.Buffer
+	if _, err := client.Users.Export(&buf, &UserExportOptions{Columns: []string{"id", "groups", "factors"}}); err != nil {
+		t.Errorf("Users.Export returned error: %v", err)
+	}
+	want := "id,groups,factors\n00u1,Everyone;Engineering,push;sms\n"
+	if buf.String() != want {
+		t.Errorf("Users.Export returned \n\t%v, want \n\t%v\n", buf.String(), want)
+	}
+}
@@ -0,0 +1,428 @@
+package okta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultFactorPollInterval = 5 * time.Second
+
+// UserFactorsService handles communication with the user Factors related
+// methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/factors/
+type UserFactorsService service
+
+// UserFactor represents a factor a user is enrolled in, or can enroll in.
+// Profile holds the profile matching the FactorType, for example *SMSFactorProfile for
+// FactorTypeSMS or *TOTPFactorProfile for FactorTypeTOTP
+type UserFactor struct {
+	ID          string              `json:"id,omitempty"`
+	FactorType  string              `json:"factorType,omitempty"`
+	Provider    string              `json:"provider,omitempty"`
+	VendorName  string              `json:"vendorName,omitempty"`
+	Status      string              `json:"status,omitempty"`
+	Enrollment  string              `json:"enrollment,omitempty"`
+	Created     *time.Time          `json:"created,omitempty"`
+	LastUpdated *time.Time          `json:"lastUpdated,omitempty"`
+	Profile     interface{}         `json:"profile,omitempty"`
+	Embedded    *UserFactorEmbedded `json:"_embedded,omitempty"`
+	Links       *UserFactorLinks    `json:"_links,omitempty"`
+}
+
+// SMSFactorProfile is the profile of a FactorTypeSMS factor
+type SMSFactorProfile struct {
+	PhoneNumber string `json:"phoneNumber,omitempty"`
+}
+
+// CallFactorProfile is the profile of a FactorTypeCall factor
+type CallFactorProfile struct {
+	PhoneNumber    string `json:"phoneNumber,omitempty"`
+	PhoneExtension string `json:"phoneExtension,omitempty"`
+}
+
+// EmailFactorProfile is the profile of a FactorTypeEmail factor
+type EmailFactorProfile struct {
+	Email string `json:"email,omitempty"`
+}
+
+// QuestionFactorProfile is the profile of a FactorTypeQuestion factor. Answer is only sent on enrollment
+type QuestionFactorProfile struct {
+	Question     string `json:"question,omitempty"`
+	QuestionText string `json:"questionText,omitempty"`
+	Answer       string `json:"answer,omitempty"`
+}
+
+// TOTPFactorProfile is the profile of a FactorTypeTOTP factor
+type TOTPFactorProfile struct {
+	CredentialID string `json:"credentialId,omitempty"`
+}
+
+// PushFactorProfile is the profile of a FactorTypePush factor
+type PushFactorProfile struct {
+	CredentialID string `json:"credentialId,omitempty"`
+	DeviceType   string `json:"deviceType,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Platform     string `json:"platform,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// WebAuthnFactorProfile is the profile of a FactorTypeWebAuthn or FactorTypeU2F factor
+type WebAuthnFactorProfile struct {
+	CredentialID      string `json:"credentialId,omitempty"`
+	AuthenticatorName string `json:"authenticatorName,omitempty"`
+	Version           string `json:"version,omitempty"`
+}
+
+// TokenFactorProfile is the profile of a FactorTypeToken or FactorTypeHardwareToken factor
+type TokenFactorProfile struct {
+	CredentialID string `json:"credentialId,omitempty"`
+}
+
+// UserFactorEmbedded holds the activation data returned when a factor is enrolled,
+// and the challenge returned when a push or webauthn factor is verified
+type UserFactorEmbedded struct {
+	Activation *FactorActivation `json:"activation,omitempty"`
+	Challenge  *FactorChallenge  `json:"challenge,omitempty"`
+}
+
+// FactorActivation holds the data needed to activate an enrolled factor. For FactorTypeTOTP
+// it contains the shared secret and the QR code link, for FactorTypePush the QR code and the
+// poll link, and for FactorTypeWebAuthn the attestation challenge
+type FactorActivation struct {
+	TimeStep     int        `json:"timeStep,omitempty"`
+	SharedSecret string     `json:"sharedSecret,omitempty"`
+	Encoding     string     `json:"encoding,omitempty"`
+	KeyLength    int        `json:"keyLength,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	FactorResult string     `json:"factorResult,omitempty"`
+
+	// WebAuthn attestation options
+	Attestation            string          `json:"attestation,omitempty"`
+	Challenge              string          `json:"challenge,omitempty"`
+	RP                     json.RawMessage `json:"rp,omitempty"`
+	User                   json.RawMessage `json:"user,omitempty"`
+	PubKeyCredParams       json.RawMessage `json:"pubKeyCredParams,omitempty"`
+	AuthenticatorSelection json.RawMessage `json:"authenticatorSelection,omitempty"`
+	ExcludeCredentials     json.RawMessage `json:"excludeCredentials,omitempty"`
+
+	Links *UserFactorLinks `json:"_links,omitempty"`
+}
+
+// FactorChallenge is the challenge returned when verifying a FactorTypeWebAuthn or FactorTypePush factor
+type FactorChallenge struct {
+	Challenge        string          `json:"challenge,omitempty"`
+	CorrectAnswer    int             `json:"correctAnswer,omitempty"`
+	UserVerification string          `json:"userVerification,omitempty"`
+	Extensions       json.RawMessage `json:"extensions,omitempty"`
+}
+
+// UserFactorLinks holds the links of a factor, activation or verification. Not every link is set for every factor
+type UserFactorLinks struct {
+	Self      *FactorLink  `json:"self,omitempty"`
+	Activate  *FactorLink  `json:"activate,omitempty"`
+	Verify    *FactorLink  `json:"verify,omitempty"`
+	Poll      *FactorLink  `json:"poll,omitempty"`
+	Cancel    *FactorLink  `json:"cancel,omitempty"`
+	Questions *FactorLink  `json:"questions,omitempty"`
+	QRCode    *FactorLink  `json:"qrcode,omitempty"`
+	User      *FactorLink  `json:"user,omitempty"`
+	Enroll    *FactorLink  `json:"enroll,omitempty"`
+	Resend    []FactorLink `json:"resend,omitempty"`
+	Send      []FactorLink `json:"send,omitempty"`
+}
+
+// FactorLink is a single factor link
+type FactorLink struct {
+	Name  string `json:"name,omitempty"`
+	Href  string `json:"href,omitempty"`
+	Type  string `json:"type,omitempty"`
+	Hints *Hints `json:"hints,omitempty"`
+}
+
+// FactorEnrollOptions are the optional query parameters of a factor enrollment
+type FactorEnrollOptions struct {
+	// UpdatePhone updates the phone number of an already enrolled sms or call factor
+	UpdatePhone bool `url:"updatePhone,omitempty"`
+	// TemplateID of a custom sms template
+	TemplateID string `url:"templateId,omitempty"`
+	// TokenLifetimeSeconds is the lifetime of the sms or email OTP
+	TokenLifetimeSeconds int `url:"tokenLifetimeSeconds,omitempty"`
+	// Activate activates the factor on enrollment. Only supported by some factors, for example email
+	Activate bool `url:"activate,omitempty"`
+}
+
+// FactorActivationRequest is the body of a factor activation. Use PassCode for otp based factors
+// and Attestation & ClientData for webauthn
+type FactorActivationRequest struct {
+	PassCode    string `json:"passCode,omitempty"`
+	Attestation string `json:"attestation,omitempty"`
+	ClientData  string `json:"clientData,omitempty"`
+}
+
+// FactorVerifyRequest is the body of a factor verification. Use PassCode for otp based factors,
+// Answer for question factors, and the webauthn fields for webauthn. An empty request sends a
+// challenge for sms, call, email and push factors
+type FactorVerifyRequest struct {
+	PassCode          string `json:"passCode,omitempty"`
+	Answer            string `json:"answer,omitempty"`
+	NextPassCode      string `json:"nextPassCode,omitempty"`
+	ClientData        string `json:"clientData,omitempty"`
+	AuthenticatorData string `json:"authenticatorData,omitempty"`
+	SignatureData     string `json:"signatureData,omitempty"`
+}
+
+// FactorVerification is the result of a factor verification
+type FactorVerification struct {
+	FactorResult        string              `json:"factorResult,omitempty"`
+	FactorResultMessage string              `json:"factorResultMessage,omitempty"`
+	ExpiresAt           *time.Time          `json:"expiresAt,omitempty"`
+	Profile             json.RawMessage     `json:"profile,omitempty"`
+	Embedded            *UserFactorEmbedded `json:"_embedded,omitempty"`
+	Links               *UserFactorLinks    `json:"_links,omitempty"`
+}
+
+// SecurityQuestion is a security question a user can pick when enrolling a question factor
+type SecurityQuestion struct {
+	Question     string `json:"question,omitempty"`
+	QuestionText string `json:"questionText,omitempty"`
+}
+
+// UnmarshalJSON decodes a factor and its profile into the profile type matching the factorType
+func (f *UserFactor) UnmarshalJSON(data []byte) error {
+	type userFactor UserFactor
+	var raw struct {
+		userFactor
+		Profile json.RawMessage `json:"profile,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = UserFactor(raw.userFactor)
+	f.Profile = nil
+	if len(raw.Profile) == 0 || string(raw.Profile) == "null" {
+		return nil
+	}
+
+	var profile interface{}
+	switch f.FactorType {
+	case FactorTypeSMS:
+		profile = new(SMSFactorProfile)
+	case FactorTypeCall:
+		profile = new(CallFactorProfile)
+	case FactorTypeEmail:
+		profile = new(EmailFactorProfile)
+	case FactorTypeQuestion:
+		profile = new(QuestionFactorProfile)
+	case FactorTypeTOTP:
+		profile = new(TOTPFactorProfile)
+	case FactorTypePush:
+		profile = new(PushFactorProfile)
+	case FactorTypeWebAuthn, FactorTypeU2F:
+		profile = new(WebAuthnFactorProfile)
+	case FactorTypeToken, FactorTypeHardwareToken:
+		profile = new(TokenFactorProfile)
+	default:
+		profile = new(map[string]interface{})
+	}
+	if err := json.Unmarshal(raw.Profile, profile); err != nil {
+		return err
+	}
+	f.Profile = profile
+	return nil
+}
+
+// ListSupportedFactors: List the factors a user can enroll in
+// Requires User ID from User object
+func (p *UserFactorsService) ListSupportedFactors(userID string) ([]UserFactor, *Response, error) {
+	return p.listFactors(fmt.Sprintf("users/%v/factors/catalog", userID))
+}
+
+// ListFactors: List the factors a user is enrolled in
+// Requires User ID from User object
+func (p *UserFactorsService) ListFactors(userID string) ([]UserFactor, *Response, error) {
+	return p.listFactors(fmt.Sprintf("users/%v/factors", userID))
+}
+
+func (p *UserFactorsService) listFactors(u string) ([]UserFactor, *Response, error) {
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factors := make([]UserFactor, 0)
+	resp, err := p.client.Do(req, &factors)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return factors, resp, err
+}
+
+// GetFactor: Get an enrolled factor
+// Requires User ID from User object and Factor ID from UserFactor object
+func (p *UserFactorsService) GetFactor(userID string, factorID string) (*UserFactor, *Response, error) {
+	u := fmt.Sprintf("users/%v/factors/%v", userID, factorID)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factor := new(UserFactor)
+	resp, err := p.client.Do(req, factor)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return factor, resp, err
+}
+
+// Enroll: Enroll a user in a factor. FactorType, Provider and the matching Profile must be set on factor.
+// Most factors are returned in PENDING_ACTIVATION status together with the activation data in Embedded
+// Requires User ID from User object. opt is optional
+func (p *UserFactorsService) Enroll(userID string, factor UserFactor, opt *FactorEnrollOptions) (*UserFactor, *Response, error) {
+	if factor.FactorType == "" || factor.Provider == "" {
+		return nil, nil, errors.New("[ERROR] UserFactors.Enroll requires a FactorType and Provider")
+	}
+
+	u, err := addOptions(fmt.Sprintf("users/%v/factors", userID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := p.client.NewRequest("POST", u, factor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newFactor := new(UserFactor)
+	resp, err := p.client.Do(req, newFactor)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return newFactor, resp, err
+}
+
+// Activate: Activate a factor in PENDING_ACTIVATION status, for example with the passCode generated from
+// the TOTP shared secret or sent by sms
+// Requires User ID from User object and Factor ID from UserFactor object
+func (p *UserFactorsService) Activate(userID string, factorID string, activation FactorActivationRequest) (*UserFactor, *Response, error) {
+	u := fmt.Sprintf("users/%v/factors/%v/lifecycle/activate", userID, factorID)
+	req, err := p.client.NewRequest("POST", u, activation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factor := new(UserFactor)
+	resp, err := p.client.Do(req, factor)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return factor, resp, err
+}
+
+// Verify: Verify a factor. For sms, call and email factors an empty request sends the challenge
+// and a second request with the PassCode verifies it. For push factors use VerifyPush to wait for the result
+// Requires User ID from User object and Factor ID from UserFactor object
+func (p *UserFactorsService) Verify(userID string, factorID string, verify FactorVerifyRequest) (*FactorVerification, *Response, error) {
+	return p.verify(context.Background(), userID, factorID, verify)
+}
+
+// verify (unexported) sends a verification with the context ctx
+func (p *UserFactorsService) verify(ctx context.Context, userID string, factorID string, verify FactorVerifyRequest) (*FactorVerification, *Response, error) {
+	u := fmt.Sprintf("users/%v/factors/%v/verify", userID, factorID)
+	req, err := p.client.NewRequest("POST", u, verify)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	verification := new(FactorVerification)
+	resp, err := p.client.Do(req.WithContext(ctx), verification)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return verification, resp, err
+}
+
+// PollVerification: Get the current result of a verification from its poll link
+func (p *UserFactorsService) PollVerification(ctx context.Context, pollURL string) (*FactorVerification, *Response, error) {
+	req, err := p.client.NewRequest("GET", pollURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	verification := new(FactorVerification)
+	resp, err := p.client.Do(req.WithContext(ctx), verification)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return verification, resp, err
+}
+
+// VerifyPush: Send a push verification and poll for the result every pollInterval until the user answers,
+// the verification expires, or ctx is done. Use context.WithTimeout to bound the wait.
+// A pollInterval of 0 uses 5 seconds
+// Requires User ID from User object and Factor ID from UserFactor object
+func (p *UserFactorsService) VerifyPush(ctx context.Context, userID string, factorID string, pollInterval time.Duration) (*FactorVerification, *Response, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultFactorPollInterval
+	}
+
+	// a done ctx sends no push
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	verification, resp, err := p.verify(ctx, userID, factorID, FactorVerifyRequest{})
+	for err == nil && verification.FactorResult == FactorResultWaiting {
+		if verification.Links == nil || verification.Links.Poll == nil {
+			return verification, resp, errors.New("[ERROR] UserFactors.VerifyPush verification has no poll link")
+		}
+		select {
+		case <-ctx.Done():
+			return verification, resp, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+		verification, resp, err = p.PollVerification(ctx, verification.Links.Poll.Href)
+	}
+
+	return verification, resp, err
+}
+
+// Reset: Unenroll a user from a factor
+// Requires User ID from User object and Factor ID from UserFactor object
+func (p *UserFactorsService) Reset(userID string, factorID string) (*Response, error) {
+	u := fmt.Sprintf("users/%v/factors/%v", userID, factorID)
+	req, err := p.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, err
+}
+
+// ListSecurityQuestions: List the security questions a user can pick for a question factor
+// Requires User ID from User object
+func (p *UserFactorsService) ListSecurityQuestions(userID string) ([]SecurityQuestion, *Response, error) {
+	u := fmt.Sprintf("users/%v/factors/questions", userID)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	questions := make([]SecurityQuestion, 0)
+	resp, err := p.client.Do(req, &questions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return questions, resp, err
+}
@@ -0,0 +1,251 @@
+package okta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testTOTPEnrollJSONString = `
+{
+    "id": "ostf1fmaMGJLMNGNLIVG",
+    "factorType": "token:software:totp",
+    "provider": "OKTA",
+    "vendorName": "OKTA",
+    "status": "PENDING_ACTIVATION",
+    "created": "2014-07-16T16:13:56.000Z",
+    "lastUpdated": "2014-08-06T00:31:07.000Z",
+    "profile": {
+        "credentialId": "dade.murphy@example.com"
+    },
+    "_links": {
+        "activate": {
+            "href": "https://your-domain.okta.com/api/v1/users/00u15s1KDETTQMQYABRL/factors/ostf1fmaMGJLMNGNLIVG/lifecycle/activate",
+            "hints": {
+                "allow": ["POST"]
+            }
+        }
+    },
+    "_embedded": {
+        "activation": {
+            "timeStep": 30,
+            "sharedSecret": "JBSWY3DPEHPK3PXP",
+            "encoding": "base32",
+            "keyLength": 16,
+            "_links": {
+                "qrcode": {
+                    "href": "https://your-domain.okta.com/api/v1/users/00u15s1KDETTQMQYABRL/factors/ostf1fmaMGJLMNGNLIVG/qr/00fukNElRS_Tz6k-CFhg3pH4KO2dj2guhmaapXWbc4",
+                    "type": "image/png"
+                }
+            }
+        }
+    }
+}
+`
+
+func TestUserFactorsList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `[
+			{"id":"mbl1nz9JHJGHWRKMTLHP","factorType":"sms","provider":"OKTA","status":"ACTIVE","profile":{"phoneNumber":"+1-555-415-1337"}},
+			{"id":"ufs2bysphxKODSZKWVCT","factorType":"question","provider":"OKTA","status":"ACTIVE","profile":{"question":"favorite_art_piece","questionText":"What is your favorite piece of art?"}},
+			{"id":"opf3hkfocI4JTLAju0g4","factorType":"push","provider":"OKTA","status":"ACTIVE","profile":{"credentialId":"dade.murphy@example.com","deviceType":"SmartPhone_IPhone","name":"Gibson","platform":"IOS","version":"9.0"}}
+		]`)
+	})
+
+	factors, _, err := client.UserFactors.ListFactors("00u15s1KDETTQMQYABRL")
+	if err != nil {
+		t.Errorf("UserFactors.ListFactors returned error: %v", err)
+	}
+	if len(factors) != 3 {
+		t.Fatalf("UserFactors.ListFactors returned %v factors, want 3", len(factors))
+	}
+	if want := (&SMSFactorProfile{PhoneNumber: "+1-555-415-1337"}); !reflect.DeepEqual(factors[0].Profile, want) {
+		t.Errorf("client.UserFactors.ListFactors returned sms profile \n\t%+v, want \n\t%+v\n", factors[0].Profile, want)
+	}
+	if want := (&QuestionFactorProfile{Question: "favorite_art_piece", QuestionText: "What is your favorite piece of art?"}); !reflect.DeepEqual(factors[1].Profile, want) {
+		t.Errorf("client.UserFactors.ListFactors returned question profile \n\t%+v, want \n\t%+v\n", factors[1].Profile, want)
+	}
+	if profile, ok := factors[2].Profile.(*PushFactorProfile); !ok || profile.Platform != "IOS" {
+		t.Errorf("client.UserFactors.ListFactors returned push profile %+v", factors[2].Profile)
+	}
+}
+
+func TestUserFactorsEnrollTOTP(t *testing.T) {
+	setup()
+	defer teardown()
+
+	enroll := UserFactor{FactorType: FactorTypeTOTP, Provider: FactorProviderOkta}
+
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, enroll)
+		fmt.Fprint(w, testTOTPEnrollJSONString)
+	})
+
+	factor, _, err := client.UserFactors.Enroll("00u15s1KDETTQMQYABRL", enroll, nil)
+	if err != nil {
+		t.Fatalf("UserFactors.Enroll returned error: %v", err)
+	}
+	if factor.Status != MFAStatusPending {
+		t.Errorf("client.UserFactors.Enroll returned status %v, want %v", factor.Status, MFAStatusPending)
+	}
+	if want := (&TOTPFactorProfile{CredentialID: "dade.murphy@example.com"}); !reflect.DeepEqual(factor.Profile, want) {
+		t.Errorf("client.UserFactors.Enroll returned profile \n\t%+v, want \n\t%+v\n", factor.Profile, want)
+	}
+	activation := factor.Embedded.Activation
+	if activation.SharedSecret != "JBSWY3DPEHPK3PXP" || activation.TimeStep != 30 || activation.KeyLength != 16 {
+		t.Errorf("client.UserFactors.Enroll returned activation %+v", activation)
+	}
+	if activation.Links.QRCode == nil || activation.Links.QRCode.Type != "image/png" {
+		t.Errorf("client.UserFactors.Enroll returned activation links %+v", activation.Links)
+	}
+}
+
+func TestUserFactorsEnrollSMSOptions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	enroll := UserFactor{
+		FactorType: FactorTypeSMS,
+		Provider:   FactorProviderOkta,
+		Profile:    &SMSFactorProfile{PhoneNumber: "+1-555-415-1337"},
+	}
+
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		if got := r.URL.Query().Get("updatePhone"); got != "true" {
+			t.Errorf("UserFactors.Enroll sent updatePhone=%v, want true", got)
+		}
+		testBody(t, r, enroll)
+		fmt.Fprint(w, `{"id":"mbl1nz9JHJGHWRKMTLHP","factorType":"sms","provider":"OKTA","status":"PENDING_ACTIVATION","profile":{"phoneNumber":"+1-555-415-1337"}}`)
+	})
+
+	if _, _, err := client.UserFactors.Enroll("00u15s1KDETTQMQYABRL", enroll, &FactorEnrollOptions{UpdatePhone: true}); err != nil {
+		t.Errorf("UserFactors.Enroll returned error: %v", err)
+	}
+	if _, _, err := client.UserFactors.Enroll("00u15s1KDETTQMQYABRL", UserFactor{}, nil); err == nil {
+		t.Errorf("UserFactors.Enroll expected an error without factorType and provider")
+	}
+}
+
+func TestUserFactorsActivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors/ostf1fmaMGJLMNGNLIVG/lifecycle/activate", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, FactorActivationRequest{PassCode: "123456"})
+		fmt.Fprint(w, `{"id":"ostf1fmaMGJLMNGNLIVG","factorType":"token:software:totp","provider":"OKTA","status":"ACTIVE"}`)
+	})
+
+	factor, _, err := client.UserFactors.Activate("00u15s1KDETTQMQYABRL", "ostf1fmaMGJLMNGNLIVG", FactorActivationRequest{PassCode: "123456"})
+	if err != nil {
+		t.Errorf("UserFactors.Activate returned error: %v", err)
+	}
+	if factor.Status != MFAStatusActive {
+		t.Errorf("client.UserFactors.Activate returned status %v, want %v", factor.Status, MFAStatusActive)
+	}
+}
+
+func TestUserFactorsVerifyPush(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors/opf3hkfocI4JTLAju0g4/verify", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		fmt.Fprintf(w, `{"factorResult":"WAITING","_links":{"poll":{"href":"%v/users/00u15s1KDETTQMQYABRL/factors/opf3hkfocI4JTLAju0g4/transactions/v2mst.GldKV5VxTrifyeZmWSQguA"}}}`, server.URL)
+	})
+	mux.HandleFunc("/users/00u15s1KDETTQMQYABRL/factors/opf3hkfocI4JTLAju0g4/transactions/v2mst.GldKV5VxTrifyeZmWSQguA", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		polls++
+		if polls < 2 {
+			fmt.Fprintf(w, `{"factorResult":"WAITING","_links":{"poll":{"href":"%v%v"}}}`, server.URL, r.URL.Path)
+			return
+		}
+		fmt.Fprint(w, `{"factorResult":"SUCCESS"}`)
+	})
+
+	verification, _, err := client.UserFactors.VerifyPush(context.Background(), "00u15s1KDETTQMQYABRL", "opf3hkfocI4JTLAju0g4", time.Millisecond)
+	if err != nil {
+		t.Errorf("UserFactors.VerifyPush returned error: %v", err)
+	}
+	if verification.FactorResult != FactorResultSuccess || polls != 2 {
+		t.Errorf("client.UserFactors.VerifyPush returned %v after %v polls, want SUCCESS after 2", verification.FactorResult, polls)
+	}
+}
+
+func TestUserFactorsVerifyPushTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/00u1/factors/opf1/verify", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"factorResult":"WAITING","_links":{"poll":{"href":"%v/users/00u1/factors/opf1/transactions/1"}}}`, server.URL)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	verification, _, err := client.UserFactors.VerifyPush(ctx, "00u1", "opf1", time.Second)
+	if err != context.DeadlineExceeded {
+		t.Errorf("UserFactors.VerifyPush returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if verification == nil || verification.FactorResult != FactorResultWaiting {
+		t.Errorf("UserFactors.VerifyPush returned %+v, want the last WAITING result", verification)
+	}
+}
+
+func TestUserFactorsVerifyPushCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/00u1/factors/opf1/verify", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("UserFactors.VerifyPush sent a push with a canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.UserFactors.VerifyPush(ctx, "00u1", "opf1", time.Millisecond); err != context.Canceled {
+		t.Errorf("UserFactors.VerifyPush returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestUserFactorsResetAndQuestions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/users/00u1/factors/ufs1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testAuthHeader(t, r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/users/00u1/factors/questions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `[{"question":"disliked_food","questionText":"What is the food you least liked as a child?"}]`)
+	})
+
+	if _, err := client.UserFactors.Reset("00u1", "ufs1"); err != nil {
+		t.Errorf("UserFactors.Reset returned error: %v", err)
+	}
+	questions, _, err := client.UserFactors.ListSecurityQuestions("00u1")
+	if err != nil {
+		t.Errorf("UserFactors.ListSecurityQuestions returned error: %v", err)
+	}
+	want := []SecurityQuestion{{Question: "disliked_food", QuestionText: "What is the food you least liked as a child?"}}
+	if !reflect.DeepEqual(questions, want) {
+		t.Errorf("client.UserFactors.ListSecurityQuestions returned \n\t%+v, want \n\t%+v\n", questions, want)
+	}
+}
@@ -10,7 +10,7 @@ import (
 )
 
 var testuser *User
-var testroles *userRoles
+var testroles *UserRoles
 
 func setupTestUsers() {
 
@@ -65,7 +65,7 @@ func setupTestRoles() {
 
 	hmm, _ := time.Parse("2006-01-02T15:04:05.000Z", "2018-02-16T19:59:05.000Z")
 
-	testrole := new(userRole)
+	testrole := new(Role)
 	testrole.ID = "KVJUKUS7IFCE2SKO"
 	testrole.Label = "User Administrator"
 	testrole.Type = "USER_ADMIN"
@@ -73,7 +73,7 @@ func setupTestRoles() {
 	testrole.Created = hmm
 	testrole.LastUpdated = hmm
 
-	testroles = new(userRoles)
+	testroles = new(UserRoles)
 	testroles.Role = append(testroles.Role, *testrole)
 
 }
@@ -236,3 +236,31 @@ func TestUnAssignRole(t *testing.T) {
 //  Test User Search Query Parameter Generation
 // Test Pagination
 //
+
+func TestUserCreateWithProfile(t *testing.T) {
+
+	setup()
+	defer teardown()
+
+	type contractorProfile struct {
+		Login          string  `json:"login"`
+		Email          string  `json:"email"`
+		EmployeeNumber *string `json:"employeeNumber,omitempty"`
+		Clearance      *int64  `json:"clearance,omitempty"`
+	}
+	clearance := int64(2)
+	newUser := client.Users.NewUser()
+	newUser.Profile.FirstName = "ignored"
+	newUser.SetProfile(contractorProfile{Login: "isaac.brock@example.com", Email: "isaac.brock@example.com", Clearance: &clearance})
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, json.RawMessage(`{"profile":{"login":"isaac.brock@example.com","email":"isaac.brock@example.com","clearance":2}}`))
+		fmt.Fprint(w, `{"id": "00u118oQYT4TBGuay0g4", "status": "STAGED", "profile": {"login": "isaac.brock@example.com"}}`)
+	})
+
+	if _, _, err := client.Users.Create(newUser, false); err != nil {
+		t.Errorf("Users.Create returned error: %v", err)
+	}
+}
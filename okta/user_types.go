@@ -0,0 +1,106 @@
+package okta
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// DefaultUserSchemaID is the ID of the schema of the default user type
+const DefaultUserSchemaID = "default"
+
+// UserTypesService handles communication with the User Types related
+// methods of the OKTA API.
+// https://developer.okta.com/docs/reference/api/user-types/
+type UserTypesService service
+
+// UserType is a type of user with its own profile schema, for example contractors or partners
+type UserType struct {
+	ID            string         `json:"id,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	DisplayName   string         `json:"displayName,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	Default       bool           `json:"default,omitempty"`
+	Created       *time.Time     `json:"created,omitempty"`
+	CreatedBy     string         `json:"createdBy,omitempty"`
+	LastUpdated   *time.Time     `json:"lastUpdated,omitempty"`
+	LastUpdatedBy string         `json:"lastUpdatedBy,omitempty"`
+	Links         *UserTypeLinks `json:"_links,omitempty"`
+}
+
+// UserTypeLinks are the links of a user type
+type UserTypeLinks struct {
+	Self   *FactorLink `json:"self,omitempty"`
+	Schema *FactorLink `json:"schema,omitempty"`
+}
+
+// UserTypeRef references the user type of a user
+type UserTypeRef struct {
+	ID string `json:"id"`
+}
+
+// SchemaID returns the ID of the profile schema of the user type, to pass to the SchemasService methods.
+// It is DefaultUserSchemaID for the default user type
+func (t *UserType) SchemaID() string {
+	if t.Links == nil || t.Links.Schema == nil || t.Links.Schema.Href == "" {
+		if t.Default {
+			return DefaultUserSchemaID
+		}
+		return ""
+	}
+	return path.Base(t.Links.Schema.Href)
+}
+
+// ListUserTypes: List the user types of the org, including the default user type
+func (p *UserTypesService) ListUserTypes() ([]UserType, *Response, error) {
+	var types []UserType
+	resp, err := p.client.do("GET", "meta/types/user", nil, &types)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return types, resp, err
+}
+
+// GetUserType: Get a user type
+// Requires UserType ID from UserType object
+func (p *UserTypesService) GetUserType(id string) (*UserType, *Response, error) {
+	u := fmt.Sprintf("meta/types/user/%v", id)
+	return p.doType("GET", u, nil)
+}
+
+// CreateUserType: Create a user type. Name and DisplayName are required, Name can not be changed later
+func (p *UserTypesService) CreateUserType(userType UserType) (*UserType, *Response, error) {
+	return p.doType("POST", "meta/types/user", userType)
+}
+
+// UpdateUserType: Update the DisplayName and Description of a user type
+// Requires UserType ID from UserType object
+func (p *UserTypesService) UpdateUserType(id string, userType UserType) (*UserType, *Response, error) {
+	u := fmt.Sprintf("meta/types/user/%v", id)
+	return p.doType("POST", u, userType)
+}
+
+// ReplaceUserType: Replace a user type. The Name must be the Name of the existing user type
+// Requires UserType ID from UserType object
+func (p *UserTypesService) ReplaceUserType(id string, userType UserType) (*UserType, *Response, error) {
+	u := fmt.Sprintf("meta/types/user/%v", id)
+	return p.doType("PUT", u, userType)
+}
+
+// DeleteUserType: Delete a user type. The default user type and user types with users can not be deleted
+// Requires UserType ID from UserType object
+func (p *UserTypesService) DeleteUserType(id string) (*Response, error) {
+	u := fmt.Sprintf("meta/types/user/%v", id)
+	return p.client.do("DELETE", u, nil, nil)
+}
+
+func (p *UserTypesService) doType(method string, u string, body interface{}) (*UserType, *Response, error) {
+	userType := new(UserType)
+	resp, err := p.client.do(method, u, body, userType)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return userType, resp, err
+}
@@ -0,0 +1,120 @@
+package okta
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestListUserTypes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/meta/types/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testAuthHeader(t, r)
+		fmt.Fprint(w, `[{
+			"id": "otyfnly5cQjJT9PnR0g4",
+			"displayName": "User",
+			"name": "user",
+			"description": "Okta user profile template with default permission settings",
+			"default": true,
+			"_links": {"schema": {"href": "https://your-domain.okta.com/api/v1/meta/schemas/user/default"}}
+		}, {
+			"id": "otyfnjfba4ye7pgjB0g4",
+			"displayName": "Contractor",
+			"name": "contractor",
+			"default": false,
+			"_links": {"schema": {"href": "https://your-domain.okta.com/api/v1/meta/schemas/user/oscfnjfba4ye7pgjB0g4"}}
+		}]`)
+	})
+
+	types, _, err := client.UserTypes.ListUserTypes()
+	if err != nil {
+		t.Fatalf("UserTypes.ListUserTypes returned error: %v", err)
+	}
+	if len(types) != 2 || !types[0].Default {
+		t.Fatalf("client.UserTypes.ListUserTypes returned %+v", types)
+	}
+	if types[0].SchemaID() != DefaultUserSchemaID || types[1].SchemaID() != "oscfnjfba4ye7pgjB0g4" {
+		t.Errorf("UserType.SchemaID returned %v and %v", types[0].SchemaID(), types[1].SchemaID())
+	}
+}
+
+func TestUserTypeLifecycle(t *testing.T) {
+	setup()
+	defer teardown()
+
+	userType := UserType{Name: "contractor", DisplayName: "Contractor", Description: "Contract workers"}
+	want := userType
+	want.ID = "otyfnjfba4ye7pgjB0g4"
+
+	mux.HandleFunc("/meta/types/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, userType)
+		json.NewEncoder(w).Encode(want)
+	})
+	mux.HandleFunc("/meta/types/user/otyfnjfba4ye7pgjB0g4", func(w http.ResponseWriter, r *http.Request) {
+		testAuthHeader(t, r)
+		switch r.Method {
+		case "POST":
+			testBody(t, r, UserType{DisplayName: "Contract worker"})
+			updated := want
+			updated.DisplayName = "Contract worker"
+			json.NewEncoder(w).Encode(updated)
+		default:
+			testMethod(t, r, "DELETE")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	created, _, err := client.UserTypes.CreateUserType(userType)
+	if err != nil {
+		t.Fatalf("UserTypes.CreateUserType returned error: %v", err)
+	}
+	if !reflect.DeepEqual(*created, want) {
+		t.Errorf("client.UserTypes.CreateUserType returned \n\t%+v, want \n\t%+v\n", created, want)
+	}
+
+	updated, _, err := client.UserTypes.UpdateUserType(created.ID, UserType{DisplayName: "Contract worker"})
+	if err != nil {
+		t.Errorf("UserTypes.UpdateUserType returned error: %v", err)
+	}
+	if updated.DisplayName != "Contract worker" {
+		t.Errorf("client.UserTypes.UpdateUserType returned %+v", updated)
+	}
+
+	if _, err := client.UserTypes.DeleteUserType(created.ID); err != nil {
+		t.Errorf("UserTypes.DeleteUserType returned error: %v", err)
+	}
+}
+
+func TestCreateUserOfType(t *testing.T) {
+	setup()
+	defer teardown()
+
+	newUser := client.Users.NewUser()
+	newUser.Profile.Login = "isaac.brock@example.com"
+	newUser.Profile.Email = "isaac.brock@example.com"
+	newUser.Profile.FirstName = "Isaac"
+	newUser.Profile.LastName = "Brock"
+	newUser.SetUserType("otyfnjfba4ye7pgjB0g4")
+
+	mux.HandleFunc("/users", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testAuthHeader(t, r)
+		testBody(t, r, newUser)
+		fmt.Fprint(w, `{"id": "00u118oQYT4TBGuay0g4", "status": "STAGED", "profile": {"login": "isaac.brock@example.com"}, "type": {"id": "otyfnjfba4ye7pgjB0g4"}}`)
+	})
+
+	user, _, err := client.Users.Create(newUser, false)
+	if err != nil {
+		t.Fatalf("Users.Create returned error: %v", err)
+	}
+	if user.Type == nil || user.Type.ID != "otyfnjfba4ye7pgjB0g4" {
+		t.Errorf("client.Users.Create returned %+v", user)
+	}
+}
@@ -1,6 +1,7 @@
 package okta
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -135,8 +136,9 @@ type User struct {
 	Status          string          `json:"status,omitempty"`
 	StatusChanged   string          `json:"statusChanged,omitempty"`
 	Links           userLinks       `json:"_links,omitempty"`
-	MFAFactors      []userMFAFactor `json:"-,omitempty"`
-	Groups          []Group         `json:"-,omitempty"`
+	Type            *UserTypeRef    `json:"type,omitempty"`
+	MFAFactors      []userMFAFactor `json:"-"`
+	Groups          []Group         `json:"-"`
 }
 
 type userMFAFactor struct {
@@ -156,6 +158,21 @@ type userMFAFactor struct {
 type NewUser struct {
 	Profile     userProfile  `json:"profile"`
 	Credentials *credentials `json:"credentials,omitempty"`
+	Type        *UserTypeRef `json:"type,omitempty"`
+
+	profile interface{}
+}
+
+// MarshalJSON encodes the NewUser, with the profile set by SetProfile instead of Profile when there is one
+func (u NewUser) MarshalJSON() ([]byte, error) {
+	type newUser NewUser
+	if u.profile == nil {
+		return json.Marshal(newUser(u))
+	}
+	return json.Marshal(struct {
+		newUser
+		Profile interface{} `json:"profile"`
+	}{newUser(u), u.profile})
 }
 
 type newPasswordSet struct {
@@ -166,17 +183,9 @@ type resetPasswordResponse struct {
 	ResetPasswordURL string `json:"resetPasswordUrl"`
 }
 
-type userRoles struct {
-	Role []userRole `json:"-,omitempty"`
-}
-
-type userRole struct {
-	ID          string    `json:"id,omitempty"`
-	Label       string    `json:"label,omitempty"`
-	Type        string    `json:"type,omitempty"`
-	Status      string    `json:"status,omitempty"`
-	Created     time.Time `json:"created,omitempty"`
-	LastUpdated time.Time `json:"lastUpdated,omitempty"`
+// UserRoles holds the roles assigned to a user, as returned by ListRoles
+type UserRoles struct {
+	Role []Role `json:"-"`
 }
 
 // NewUser - Returns a new user object. This is used to create users in OKTA. It only has the properties that
@@ -227,6 +236,21 @@ func (u *NewUser) SetRecoveryQuestion(questionIn string, answerIn string) {
 	}
 }
 
+// SetUserType - Creates the user as a user of the user type with ID typeID instead of the default user type
+func (u *NewUser) SetUserType(typeID string) {
+	if typeID != "" {
+		u.Type = &UserTypeRef{ID: typeID}
+	} else {
+		u.Type = nil
+	}
+}
+
+// SetProfile - Sends profile instead of the Profile fields, for example a struct generated by okta-schemagen
+// with the custom attributes of the org. Pass nil to send the Profile fields again
+func (u *NewUser) SetProfile(profile interface{}) {
+	u.profile = profile
+}
+
 func (u User) String() string {
 	return stringify(u)
 	// return fmt.Sprintf("ID: %v \tLogin: %v", u.ID, u.Profile.Login)
@@ -342,60 +366,60 @@ func appendToFilterString(currFilterString string, appendFilterKey string, appen
 	return rs
 }
 
-// ListWithFilter will use the input UserListFilterOptions to find users and return a paged result set
-func (s *UsersService) ListWithFilter(opt *UserListFilterOptions) ([]User, *Response, error) {
-	var u string
-	var err error
-
-	pagesRetreived := 0
-
+// userListURL builds the relative "users" URL for the input UserListFilterOptions.
+// If opt.NextURL is set it is returned as-is so callers can follow pagination links
+func userListURL(opt *UserListFilterOptions) (string, error) {
 	if opt.NextURL != nil {
-		u = opt.NextURL.String()
-	} else {
-		if opt.EmailEqualTo != "" {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileEmailFilter, FilterEqualOperator, opt.EmailEqualTo)
-		}
-		if opt.LoginEqualTo != "" {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileLoginFilter, FilterEqualOperator, opt.LoginEqualTo)
-		}
+		return opt.NextURL.String(), nil
+	}
+	if opt.EmailEqualTo != "" {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileEmailFilter, FilterEqualOperator, opt.EmailEqualTo)
+	}
+	if opt.LoginEqualTo != "" {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileLoginFilter, FilterEqualOperator, opt.LoginEqualTo)
+	}
 
-		if opt.StatusEqualTo != "" {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileStatusFilter, FilterEqualOperator, opt.StatusEqualTo)
-		}
+	if opt.StatusEqualTo != "" {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileStatusFilter, FilterEqualOperator, opt.StatusEqualTo)
+	}
 
-		if opt.IDEqualTo != "" {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileIDFilter, FilterEqualOperator, opt.IDEqualTo)
-		}
+	if opt.IDEqualTo != "" {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileIDFilter, FilterEqualOperator, opt.IDEqualTo)
+	}
 
-		if opt.FirstNameEqualTo != "" {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileFirstNameFilter, FilterEqualOperator, opt.FirstNameEqualTo)
-		}
+	if opt.FirstNameEqualTo != "" {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileFirstNameFilter, FilterEqualOperator, opt.FirstNameEqualTo)
+	}
 
-		if opt.LastNameEqualTo != "" {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileLastNameFilter, FilterEqualOperator, opt.LastNameEqualTo)
-		}
+	if opt.LastNameEqualTo != "" {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileLastNameFilter, FilterEqualOperator, opt.LastNameEqualTo)
+	}
 
-		//  API documenation says you can search with "starts with" but these don't work
-		// if opt.FirstNameStartsWith != "" {
-		// 	opt.FilterString = appendToFilterString(opt.FilterString, profileFirstNameFilter, filterStartsWithOperator, opt.FirstNameStartsWith)
-		// }
+	//  API documenation says you can search with "starts with" but these don't work
+	// if opt.FirstNameStartsWith != "" {
+	// 	opt.FilterString = appendToFilterString(opt.FilterString, profileFirstNameFilter, filterStartsWithOperator, opt.FirstNameStartsWith)
+	// }
 
-		// if opt.LastNameStartsWith != "" {
-		// 	opt.FilterString = appendToFilterString(opt.FilterString, profileLastNameFilter, filterStartsWithOperator, opt.LastNameStartsWith)
-		// }
+	// if opt.LastNameStartsWith != "" {
+	// 	opt.FilterString = appendToFilterString(opt.FilterString, profileLastNameFilter, filterStartsWithOperator, opt.LastNameStartsWith)
+	// }
 
-		if !opt.LastUpdated.Value.IsZero() {
-			opt.FilterString = appendToFilterString(opt.FilterString, profileLastUpdatedFilter, opt.LastUpdated.Operator, opt.LastUpdated.Value.UTC().Format(oktaFilterTimeFormat))
-		}
+	if !opt.LastUpdated.Value.IsZero() {
+		opt.FilterString = appendToFilterString(opt.FilterString, profileLastUpdatedFilter, opt.LastUpdated.Operator, opt.LastUpdated.Value.UTC().Format(oktaFilterTimeFormat))
+	}
 
-		if opt.Limit == 0 {
-			opt.Limit = defaultLimit
-		}
+	if opt.Limit == 0 {
+		opt.Limit = defaultLimit
+	}
 
-		u, err = addOptions("users", opt)
+	return addOptions("users", opt)
+}
 
-	}
+// ListWithFilter will use the input UserListFilterOptions to find users and return a paged result set
+func (s *UsersService) ListWithFilter(opt *UserListFilterOptions) ([]User, *Response, error) {
+	pagesRetreived := 0
 
+	u, err := userListURL(opt)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -590,85 +614,32 @@ func (s *UsersService) Unlock(id string) (*Response, error) {
 	return resp, err
 }
 
-// List User Roles. id must be User.ID
+// ListRoles - List User Roles. id must be User.ID
 // will return a struct containing a slice for each role assigned to the user
 // if the user has no roles, return nil
-func (s *UsersService) ListRoles(id string) (*userRoles, *Response, error) {
-	u := fmt.Sprintf("users/%v/roles", id)
-
-	req, err := s.client.NewRequest("GET", u, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-	role := make([]userRole, 0)
-	resp, err := s.client.Do(req, &role)
+// Roles.ListUserRoles returns the same roles as a slice
+func (s *UsersService) ListRoles(id string) (*UserRoles, *Response, error) {
+	roles, resp, err := s.client.Roles.ListUserRoles(id)
 	if err != nil {
 		return nil, resp, err
 	}
-	if len(role) > 0 {
-		myRoles := new(userRoles)
-		for _, v := range role {
-			myRoles.Role = append(myRoles.Role, v)
-		}
-		return myRoles, resp, err
+	if len(roles) > 0 {
+		return &UserRoles{Role: roles}, resp, err
 	}
 
 	return nil, resp, err
 }
 
-// Assign Role to User. id must be User.ID
+// AssignRole - Assign a built in Role to User. id must be User.ID, role is a role type such as RoleTypeUserAdmin
+// Use Roles.AssignUserRole to assign custom roles
 func (s *UsersService) AssignRole(id string, role string) (*Response, error) {
-
-	// verify the role is a valid Okta role
-	// https://help.okta.com/en/prod/Content/Topics/Security/Administrators.htm?cshid=Security_Administrators#Security_Administrators
-	allowedRoles := map[string]bool{
-		"SUPER_ADMIN":                 true,
-		"ORG_ADMIN":                   true,
-		"API_ACCESS_MANAGEMENT_ADMIN": true,
-		"APP_ADMIN":                   true,
-		"USER_ADMIN":                  true,
-		"MOBILE_ADMIN":                true,
-		"READ_ONLY_ADMIN":             true,
-		"HELP_DESK_ADMIN":             true,
-	}
-	if !allowedRoles[role] {
-		return nil, fmt.Errorf("Role %v is not a valid Okta role. Please review https://help.okta.com/en/prod/Content/Topics/Security/Administrators.htm?cshid=Security_Administrators#Security_Administrators", role)
-	}
-
-	type roleType struct {
-		Type string `json:"type"`
-	}
-
-	u := fmt.Sprintf("users/%v/roles", id)
-	body := roleType{
-		Type: role,
-	}
-	req, err := s.client.NewRequest("POST", u, body)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := s.client.Do(req, nil)
-	if err != nil {
-		return resp, err
-	}
-
+	_, resp, err := s.client.Roles.AssignUserRole(id, RoleAssignment{Type: role})
 	return resp, err
 }
 
-// Unassign Role from User. id must be User.ID, role must be []userRole.ID from ListRoles
+// UnAssignRole - Unassign Role from User. id must be User.ID, role must be Role.ID from ListRoles
 func (s *UsersService) UnAssignRole(id string, role string) (*Response, error) {
-	u := fmt.Sprintf("users/%v/roles/%v", id, role)
-
-	req, err := s.client.NewRequest("DELETE", u, nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := s.client.Do(req, nil)
-	if err != nil {
-		return resp, err
-	}
-
-	return resp, err
+	return s.client.Roles.UnassignUserRole(id, role)
 }
 
 // SetPassword - Sets a user password to an Admin provided String